@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lightstep/go-expohisto/structure"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -33,12 +34,33 @@ type Dimension struct {
 	Default *string `mapstructure:"default"`
 }
 
+// ExponentialHistogramConfig defines the settings of the exponential latency histogram.
+type ExponentialHistogramConfig struct {
+	// MaxSize is the maximum number of buckets per positive or negative range of the histogram.
+	// Optional. See structure.DefaultMaxSize of github.com/lightstep/go-expohisto for the default value.
+	MaxSize int32 `mapstructure:"max_size"`
+}
+
+// ExemplarsConfig defines the exemplar settings of the latency histogram.
+type ExemplarsConfig struct {
+	// Enabled attaches exemplars carrying the span's trace ID and span ID to the latency histogram data points.
+	// At most one exemplar is kept per histogram bucket and flush interval.
+	Enabled bool `mapstructure:"enabled"`
+}
+
 // Config defines the configuration options for spanmetricsconnector.
 type Config struct {
 	// LatencyHistogramBuckets is the list of durations representing latency histogram buckets.
 	// See defaultLatencyHistogramBucketsMs in connector.go for the default value.
 	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
 
+	// ExponentialHistogram, if set, makes the connector emit the latency metric as an exponential histogram
+	// instead of an explicit bucket histogram. It cannot be combined with LatencyHistogramBuckets.
+	ExponentialHistogram *ExponentialHistogramConfig `mapstructure:"exponential_histogram"`
+
+	// Exemplars defines whether trace exemplars are attached to the latency histogram data points.
+	Exemplars ExemplarsConfig `mapstructure:"exemplars"`
+
 	// Dimensions defines the list of additional dimensions on top of the provided:
 	// - service.name
 	// - span.kind
@@ -78,6 +100,19 @@ func (c Config) Validate() error {
 		)
 	}
 
+	if c.ExponentialHistogram != nil {
+		if c.LatencyHistogramBuckets != nil {
+			return fmt.Errorf("latency_histogram_buckets cannot be used together with exponential_histogram")
+		}
+		if c.ExponentialHistogram.MaxSize != 0 &&
+			(c.ExponentialHistogram.MaxSize < structure.MinSize || c.ExponentialHistogram.MaxSize > structure.MaximumMaxSize) {
+			return fmt.Errorf(
+				"invalid exponential histogram max size: %v, it should be between %v and %v",
+				c.ExponentialHistogram.MaxSize, structure.MinSize, structure.MaximumMaxSize,
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -56,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 			AggregationTemporality: cumulative,
 			DimensionsCacheSize:    defaultDimensionsCacheSize,
 			MetricsFlushInterval:   15 * time.Second,
+			Exemplars:              ExemplarsConfig{Enabled: true},
 		},
 		simpleCfg.Connectors[component.NewID(typeStr)],
 	)
@@ -73,9 +74,70 @@ func TestLoadConfig(t *testing.T) {
 			AggregationTemporality: delta,
 			DimensionsCacheSize:    1500,
 			MetricsFlushInterval:   30 * time.Second,
+			Exemplars:              ExemplarsConfig{Enabled: true},
 		},
 		fullCfg.Connectors[component.NewID(typeStr)],
 	)
+
+	exponentialCfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "config-exponential-histogram-connector.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, exponentialCfg)
+	assert.Equal(t,
+		&Config{
+			ExponentialHistogram:   &ExponentialHistogramConfig{MaxSize: 80},
+			Exemplars:              ExemplarsConfig{Enabled: true},
+			AggregationTemporality: cumulative,
+			DimensionsCacheSize:    defaultDimensionsCacheSize,
+			MetricsFlushInterval:   15 * time.Second,
+		},
+		exponentialCfg.Connectors[component.NewID(typeStr)],
+	)
+}
+
+func TestValidateHistogram(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "explicit buckets",
+			modify: func(cfg *Config) { cfg.LatencyHistogramBuckets = []time.Duration{time.Millisecond} },
+		},
+		{
+			name:   "exponential histogram with default max size",
+			modify: func(cfg *Config) { cfg.ExponentialHistogram = &ExponentialHistogramConfig{} },
+		},
+		{
+			name: "exponential histogram with explicit buckets",
+			modify: func(cfg *Config) {
+				cfg.LatencyHistogramBuckets = []time.Duration{time.Millisecond}
+				cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+			},
+			expectedErr: "latency_histogram_buckets cannot be used together with exponential_histogram",
+		},
+		{
+			name:        "exponential histogram max size too small",
+			modify:      func(cfg *Config) { cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 1} },
+			expectedErr: "invalid exponential histogram max size: 1, it should be between 2 and 16384",
+		},
+		{
+			name:        "exponential histogram max size too large",
+			modify:      func(cfg *Config) { cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 16385} },
+			expectedErr: "invalid exponential histogram max size: 16385, it should be between 2 and 16384",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tc.modify(cfg)
+			err := cfg.Validate()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
 }
 
 func TestGetAggregationTemporality(t *testing.T) {
@@ -17,10 +17,15 @@ package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-
 import (
 	"bytes"
 	"context"
+	"math/rand"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/lightstep/go-expohisto/mapping"
+	"github.com/lightstep/go-expohisto/mapping/exponent"
+	"github.com/lightstep/go-expohisto/mapping/logarithm"
+	"github.com/lightstep/go-expohisto/structure"
 	"github.com/tilinna/clock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -71,6 +76,12 @@ type connectorImp struct {
 	histograms    map[metricKey]*histogram
 	latencyBounds []float64
 
+	// expHistogramConfig is set when latencies are aggregated into exponential histograms.
+	expHistogramConfig *structure.Config
+
+	// rand is used to sample exemplars, it is nil when exemplars are disabled.
+	rand *rand.Rand
+
 	keyBuf *bytes.Buffer
 
 	// An LRU cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
@@ -86,13 +97,19 @@ type connectorImp struct {
 
 type histogram struct {
 	attributes pcommon.Map
-	exemplars  pmetric.ExemplarSlice
+	// exemplars is nil when exemplars are disabled.
+	exemplars *exemplarReservoir
 
 	bucketCounts []uint64
 	count        uint64
 	sum          float64
 
 	latencyBounds []float64
+
+	// expHistogram replaces bucketCounts and latencyBounds when exponential histograms are configured.
+	expHistogram *structure.Histogram[float64]
+	// expMapping maps latencies to the bucket indexes of expHistogram at its current scale.
+	expMapping mapping.Mapping
 }
 
 type sum struct {
@@ -134,11 +151,24 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		return nil, err
 	}
 
+	var expHistogramConfig *structure.Config
+	if pConfig.ExponentialHistogram != nil {
+		cfg := expoHistogramConfig(*pConfig.ExponentialHistogram)
+		expHistogramConfig = &cfg
+	}
+
+	var r *rand.Rand
+	if pConfig.Exemplars.Enabled {
+		r = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	}
+
 	return &connectorImp{
 		logger:                logger,
 		config:                *pConfig,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		latencyBounds:         bounds,
+		expHistogramConfig:    expHistogramConfig,
+		rand:                  r,
 		sums:                  make(map[metricKey]*sum),
 		histograms:            make(map[metricKey]*histogram),
 		dimensions:            newDimensions(pConfig.Dimensions),
@@ -163,6 +193,32 @@ func mapDurationsToMillis(vs []time.Duration) []float64 {
 	return vsm
 }
 
+func expoHistogramConfig(cfg ExponentialHistogramConfig) structure.Config {
+	var opts []structure.Option
+	if cfg.MaxSize >= structure.MinSize {
+		opts = append(opts, structure.WithMaxSize(cfg.MaxSize))
+	}
+	return structure.NewConfig(opts...)
+}
+
+// newExponentialMapping returns the mapping of latencies to bucket indexes used by an exponential histogram at the given scale.
+func newExponentialMapping(scale int32) mapping.Mapping {
+	var (
+		m   mapping.Mapping
+		err error
+	)
+	if scale <= 0 {
+		m, err = exponent.NewMapping(scale)
+	} else {
+		m, err = logarithm.NewMapping(scale)
+	}
+	if err != nil {
+		// The scale of a histogram always stays within the range supported by the mappings.
+		panic(err)
+	}
+	return m
+}
+
 // Start implements the component.Component interface.
 func (p *connectorImp) Start(ctx context.Context, _ component.Host) error {
 	p.logger.Info("Starting spanmetricsconnector")
@@ -234,7 +290,11 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 	ilm.Scope().SetName("spanmetricsconnector")
 
 	p.buildCallsSumMetrics(ilm)
-	p.buildLatencyHistogramMetrics(ilm)
+	if p.expHistogramConfig != nil {
+		p.buildLatencyExponentialHistogramMetrics(ilm)
+	} else {
+		p.buildLatencyHistogramMetrics(ilm)
+	}
 
 	return m
 }
@@ -258,8 +318,48 @@ func (p *connectorImp) buildLatencyHistogramMetrics(ilm pmetric.ScopeMetrics) {
 		dp.BucketCounts().FromRaw(hist.bucketCounts)
 		dp.SetCount(hist.count)
 		dp.SetSum(hist.sum)
-		for i := 0; i < dp.Exemplars().Len(); i++ {
-			dp.Exemplars().At(i).SetTimestamp(timestamp)
+		if hist.exemplars != nil {
+			hist.exemplars.copyTo(dp.Exemplars())
+		}
+		hist.attributes.CopyTo(dp.Attributes())
+	}
+}
+
+// buildLatencyExponentialHistogramMetrics collects the raw latency metrics and builds
+// an exponential histogram scope metric.
+func (p *connectorImp) buildLatencyExponentialHistogramMetrics(ilm pmetric.ScopeMetrics) {
+	m := ilm.Metrics().AppendEmpty()
+	m.SetName(buildMetricName(p.config.Namespace, metricNameLatency))
+	m.SetUnit("ms")
+	m.SetEmptyExponentialHistogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
+
+	dps := m.ExponentialHistogram().DataPoints()
+	dps.EnsureCapacity(len(p.histograms))
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for _, hist := range p.histograms {
+		agg := hist.expHistogram
+		dp := dps.AppendEmpty()
+		dp.SetStartTimestamp(p.startTimestamp)
+		dp.SetTimestamp(timestamp)
+		dp.SetCount(agg.Count())
+		dp.SetSum(agg.Sum())
+		if agg.Count() != 0 {
+			dp.SetMin(agg.Min())
+			dp.SetMax(agg.Max())
+		}
+		dp.SetScale(agg.Scale())
+		dp.SetZeroCount(agg.ZeroCount())
+
+		// Latencies are never negative, so only the positive range has to be copied.
+		positive := agg.Positive()
+		dp.Positive().SetOffset(positive.Offset())
+		dp.Positive().BucketCounts().EnsureCapacity(int(positive.Len()))
+		for i := uint32(0); i < positive.Len(); i++ {
+			dp.Positive().BucketCounts().Append(positive.At(i))
+		}
+
+		if hist.exemplars != nil {
+			hist.exemplars.copyTo(dp.Exemplars())
 		}
 		hist.attributes.CopyTo(dp.Attributes())
 	}
@@ -289,7 +389,9 @@ func (p *connectorImp) resetState() {
 	// Exemplars are only relevant to this batch of traces, so must be cleared within the lock,
 	// regardless of error while building metrics, before the next batch of spans is received.
 	for _, h := range p.histograms {
-		h.exemplars = pmetric.NewExemplarSlice()
+		if h.exemplars != nil {
+			h.exemplars.reset()
+		}
 	}
 
 	// If delta metrics, reset accumulated data
@@ -351,28 +453,66 @@ func (p *connectorImp) aggregateLatencies(
 ) {
 	h, ok := p.histograms[key]
 	if !ok {
-		h = &histogram{
-			attributes:    attributes,
-			bucketCounts:  make([]uint64, len(p.latencyBounds)+1),
-			latencyBounds: p.latencyBounds,
-			exemplars:     pmetric.NewExemplarSlice(),
-		}
+		h = p.newHistogram(attributes)
 		p.histograms[key] = h
 	}
 
 	h.sum += latency
 	h.count++
 
-	// Binary search to find the latencyMs bucket index.
-	index := sort.SearchFloat64s(h.latencyBounds, latency)
-	h.bucketCounts[index]++
+	var index int32
+	if h.expHistogram != nil {
+		index = h.observeExponential(latency)
+	} else {
+		// Binary search to find the latencyMs bucket index.
+		i := sort.SearchFloat64s(h.latencyBounds, latency)
+		h.bucketCounts[i]++
+		index = int32(i)
+	}
+
+	if h.exemplars != nil && !span.TraceID().IsEmpty() {
+		h.exemplars.offer(index, latency, spanContext{
+			traceID:   span.TraceID(),
+			spanID:    span.SpanID(),
+			timestamp: span.EndTimestamp(),
+		})
+	}
+}
 
-	if !span.TraceID().IsEmpty() {
-		e := h.exemplars.AppendEmpty()
-		e.SetTraceID(span.TraceID())
-		e.SetSpanID(span.SpanID())
-		e.SetDoubleValue(latency)
+func (p *connectorImp) newHistogram(attributes pcommon.Map) *histogram {
+	h := &histogram{
+		attributes: attributes,
+	}
+	if p.expHistogramConfig != nil {
+		h.expHistogram = new(structure.Histogram[float64])
+		h.expHistogram.Init(*p.expHistogramConfig)
+	} else {
+		h.bucketCounts = make([]uint64, len(p.latencyBounds)+1)
+		h.latencyBounds = p.latencyBounds
+	}
+	if p.rand != nil {
+		h.exemplars = newExemplarReservoir(p.rand)
+	}
+	return h
+}
+
+// observeExponential records the latency in the exponential histogram and returns the index
+// of the bucket it was recorded in, keeping the exemplar buckets aligned with the histogram scale.
+func (h *histogram) observeExponential(latency float64) int32 {
+	scale := h.expHistogram.Scale()
+	h.expHistogram.Update(latency)
+	newScale := h.expHistogram.Scale()
+	if newScale < scale && h.exemplars != nil {
+		h.exemplars.downscale(scale - newScale)
+	}
+
+	if latency == 0 {
+		return zeroBucketIndex
+	}
+	if h.expMapping == nil || h.expMapping.Scale() != newScale {
+		h.expMapping = newExponentialMapping(newScale)
 	}
+	return h.expMapping.MapToIndex(latency)
 }
 
 func (p *connectorImp) aggregateCalls(key metricKey, attributes pcommon.Map) {
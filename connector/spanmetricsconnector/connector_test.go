@@ -718,6 +718,7 @@ func newConnectorImp(mcon consumer.Metrics, defaultNullValue *pcommon.Value, tem
 func TestConnector_AggregateLatencies(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Exemplars.Enabled = true
 
 	traces := buildSampleTrace()
 	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
@@ -728,22 +729,27 @@ func TestConnector_AggregateLatencies(t *testing.T) {
 
 	c.aggregateLatencies("key", span.Attributes(), span, 1.5)
 	c.aggregateLatencies("key", span.Attributes(), span, 1.7)
+	c.aggregateLatencies("key", span.Attributes(), span, 3)
 
 	got, ok := c.histograms["key"]
 	require.True(t, ok)
-	assert.Equal(t, uint64(2), got.count)
-	assert.Equal(t, 3.2, got.sum)
+	assert.Equal(t, uint64(3), got.count)
+	assert.Equal(t, 6.2, got.sum)
 	assert.Equal(t, uint64(2), got.bucketCounts[0])
+	assert.Equal(t, uint64(1), got.bucketCounts[1])
+
+	// Only one exemplar is sampled per bucket.
 	exemplars := pmetric.NewExemplarSlice()
-	e := exemplars.AppendEmpty()
-	e.SetTraceID(span.TraceID())
-	e.SetSpanID(span.SpanID())
-	e.SetDoubleValue(1.5)
-	e = exemplars.AppendEmpty()
-	e.SetTraceID(span.TraceID())
-	e.SetSpanID(span.SpanID())
-	e.SetDoubleValue(1.7)
-	assert.Equal(t, exemplars, got.exemplars)
+	got.exemplars.copyTo(exemplars)
+	require.Equal(t, 2, exemplars.Len())
+	assert.Contains(t, []float64{1.5, 1.7}, exemplars.At(0).DoubleValue())
+	assert.Equal(t, 3.0, exemplars.At(1).DoubleValue())
+	for i := 0; i < exemplars.Len(); i++ {
+		assert.Equal(t, span.TraceID(), exemplars.At(i).TraceID())
+		assert.Equal(t, span.SpanID(), exemplars.At(i).SpanID())
+		assert.Equal(t, span.EndTimestamp(), exemplars.At(i).Timestamp())
+	}
+	assert.Equal(t, uint64(2), got.exemplars.buckets[0].seen)
 
 	// aggregate over different metric keys
 	c, err = newConnector(zaptest.NewLogger(t), cfg, nil)
@@ -758,11 +764,103 @@ func TestConnector_AggregateLatencies(t *testing.T) {
 	assert.Equal(t, 1.5, got.sum)
 	assert.Equal(t, uint64(1), got.bucketCounts[0])
 	exemplars = pmetric.NewExemplarSlice()
-	e = exemplars.AppendEmpty()
-	e.SetTraceID(span.TraceID())
-	e.SetSpanID(span.SpanID())
-	e.SetDoubleValue(1.5)
-	assert.Equal(t, exemplars, got.exemplars)
+	got.exemplars.copyTo(exemplars)
+	require.Equal(t, 1, exemplars.Len())
+	assert.Equal(t, 1.5, exemplars.At(0).DoubleValue())
+
+	// exemplars are not recorded unless enabled
+	cfg.Exemplars.Enabled = false
+	c, err = newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	c.aggregateLatencies("key", span.Attributes(), span, 1.5)
+	assert.Nil(t, c.histograms["key"].exemplars)
+}
+
+func TestConnector_AggregateExponentialLatencies(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{MaxSize: 4}
+	cfg.Exemplars.Enabled = true
+
+	traces := buildSampleTrace()
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+
+	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+
+	latencies := []float64{0, 1, 2, 4, 8, 16, 1000}
+	for _, l := range latencies {
+		c.aggregateLatencies("key", span.Attributes(), span, l)
+	}
+
+	got, ok := c.histograms["key"]
+	require.True(t, ok)
+	assert.Nil(t, got.bucketCounts)
+	assert.Equal(t, uint64(len(latencies)), got.expHistogram.Count())
+	assert.Equal(t, uint64(1), got.expHistogram.ZeroCount())
+	assert.LessOrEqual(t, got.expHistogram.Positive().Len(), uint32(4))
+
+	// The exemplar buckets follow the downscaled histogram buckets.
+	assert.LessOrEqual(t, len(got.exemplars.buckets), int(got.expHistogram.Positive().Len())+1)
+	var seen uint64
+	for index, e := range got.exemplars.buckets {
+		seen += e.seen
+		if index == zeroBucketIndex {
+			assert.Equal(t, 0.0, e.value)
+			continue
+		}
+		assert.Equal(t, index, got.expMapping.MapToIndex(e.value))
+	}
+	assert.Equal(t, uint64(len(latencies)), seen)
+}
+
+func TestBuildLatencyExponentialHistogramMetrics(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.ExponentialHistogram = &ExponentialHistogramConfig{}
+	cfg.Exemplars.Enabled = true
+
+	mcon := &consumertest.MetricsSink{}
+	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	require.NoError(t, err)
+	c.metricsConsumer = mcon
+
+	require.NoError(t, c.ConsumeTraces(context.Background(), buildSampleTrace()))
+	c.exportMetrics(context.Background())
+
+	require.Len(t, mcon.AllMetrics(), 1)
+	m := mcon.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, m.Len())
+	assert.Equal(t, metricNameLatency, m.At(1).Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.At(1).Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.At(1).ExponentialHistogram().AggregationTemporality())
+
+	dps := m.At(1).ExponentialHistogram().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		assert.Equal(t, uint64(1), dp.Count())
+		assert.Equal(t, sampleLatency, dp.Sum())
+		assert.Equal(t, sampleLatency, dp.Min())
+		assert.Equal(t, sampleLatency, dp.Max())
+		assert.Equal(t, 1, dp.Positive().BucketCounts().Len())
+		assert.Equal(t, uint64(1), dp.Positive().BucketCounts().At(0))
+
+		require.Equal(t, 1, dp.Exemplars().Len())
+		assert.Equal(t, sampleLatency, dp.Exemplars().At(0).DoubleValue())
+		assert.Equal(t, pcommon.TraceID([16]byte{byte(42)}), dp.Exemplars().At(0).TraceID())
+	}
+
+	// Exemplars are only emitted for the spans of the last interval.
+	c.exportMetrics(context.Background())
+	require.Len(t, mcon.AllMetrics(), 2)
+	dps = mcon.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).ExponentialHistogram().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		assert.Equal(t, uint64(1), dps.At(i).Count())
+		assert.Equal(t, 0, dps.At(i).Exemplars().Len())
+	}
 }
 
 func TestConnector_AggregateCalls(t *testing.T) {
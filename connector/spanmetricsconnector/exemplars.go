@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// zeroBucketIndex is the reservoir index used for exemplars falling into the zero bucket of an exponential histogram.
+// It is never affected by downscaling.
+const zeroBucketIndex = math.MinInt32
+
+type exemplar struct {
+	traceID   pcommon.TraceID
+	spanID    pcommon.SpanID
+	timestamp pcommon.Timestamp
+	value     float64
+
+	// seen is the number of measurements offered to the bucket holding this exemplar.
+	seen uint64
+}
+
+// exemplarReservoir keeps at most one exemplar per histogram bucket. Each bucket is sampled
+// uniformly over all measurements recorded in it since the last reset, which bounds the memory
+// used for exemplars by the number of buckets rather than by the number of spans.
+type exemplarReservoir struct {
+	buckets map[int32]*exemplar
+	rand    *rand.Rand
+}
+
+func newExemplarReservoir(r *rand.Rand) *exemplarReservoir {
+	return &exemplarReservoir{
+		buckets: make(map[int32]*exemplar),
+		rand:    r,
+	}
+}
+
+// offer records a measurement for the bucket at the given index, replacing the
+// sampled exemplar of that bucket with probability 1/n for the n-th measurement.
+func (r *exemplarReservoir) offer(index int32, value float64, span spanContext) {
+	e, ok := r.buckets[index]
+	if !ok {
+		e = &exemplar{}
+		r.buckets[index] = e
+	}
+	e.seen++
+	if e.seen > 1 && r.rand.Int63n(int64(e.seen)) != 0 {
+		return
+	}
+	e.traceID = span.traceID
+	e.spanID = span.spanID
+	e.timestamp = span.timestamp
+	e.value = value
+}
+
+// downscale re-indexes the reservoir after the buckets of an exponential histogram have been
+// merged by the given scale change. Exemplars of merged buckets are sampled proportionally to
+// the number of measurements each of them represents.
+func (r *exemplarReservoir) downscale(change int32) {
+	if change <= 0 {
+		return
+	}
+	buckets := make(map[int32]*exemplar, len(r.buckets))
+	for _, index := range r.sortedIndexes() {
+		e := r.buckets[index]
+		if index != zeroBucketIndex {
+			index >>= change
+		}
+		existing, ok := buckets[index]
+		if !ok {
+			buckets[index] = e
+			continue
+		}
+		total := existing.seen + e.seen
+		if uint64(r.rand.Int63n(int64(total))) < e.seen {
+			existing.traceID, existing.spanID, existing.timestamp, existing.value = e.traceID, e.spanID, e.timestamp, e.value
+		}
+		existing.seen = total
+	}
+	r.buckets = buckets
+}
+
+// reset drops all the sampled exemplars.
+func (r *exemplarReservoir) reset() {
+	r.buckets = make(map[int32]*exemplar)
+}
+
+// copyTo writes the sampled exemplars, ordered by bucket, into the destination slice.
+func (r *exemplarReservoir) copyTo(dest pmetric.ExemplarSlice) {
+	dest.EnsureCapacity(len(r.buckets))
+	for _, index := range r.sortedIndexes() {
+		e := r.buckets[index]
+		de := dest.AppendEmpty()
+		de.SetTraceID(e.traceID)
+		de.SetSpanID(e.spanID)
+		de.SetTimestamp(e.timestamp)
+		de.SetDoubleValue(e.value)
+	}
+}
+
+// sortedIndexes returns the bucket indexes of the reservoir in ascending order,
+// keeping both the output and the random sampling sequence deterministic.
+func (r *exemplarReservoir) sortedIndexes() []int32 {
+	indexes := make([]int32, 0, len(r.buckets))
+	for index := range r.buckets {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes
+}
+
+// spanContext holds the span fields recorded in an exemplar.
+type spanContext struct {
+	traceID   pcommon.TraceID
+	spanID    pcommon.SpanID
+	timestamp pcommon.Timestamp
+}
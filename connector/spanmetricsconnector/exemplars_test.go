@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsconnector
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestExemplarReservoirOffer(t *testing.T) {
+	r := newExemplarReservoir(rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		r.offer(0, float64(i), spanContext{spanID: pcommon.SpanID([8]byte{byte(i)})})
+	}
+	r.offer(3, 1000, spanContext{spanID: pcommon.SpanID([8]byte{byte(1)})})
+
+	require.Len(t, r.buckets, 2)
+	assert.Equal(t, uint64(100), r.buckets[0].seen)
+	assert.Equal(t, uint64(1), r.buckets[3].seen)
+
+	exemplars := pmetric.NewExemplarSlice()
+	r.copyTo(exemplars)
+	require.Equal(t, 2, exemplars.Len())
+	assert.Less(t, exemplars.At(0).DoubleValue(), 100.0)
+	assert.Equal(t, pcommon.SpanID([8]byte{byte(exemplars.At(0).DoubleValue())}), exemplars.At(0).SpanID())
+	assert.Equal(t, 1000.0, exemplars.At(1).DoubleValue())
+
+	r.reset()
+	exemplars = pmetric.NewExemplarSlice()
+	r.copyTo(exemplars)
+	assert.Equal(t, 0, exemplars.Len())
+}
+
+func TestExemplarReservoirDownscale(t *testing.T) {
+	r := newExemplarReservoir(rand.New(rand.NewSource(1)))
+	r.offer(zeroBucketIndex, 0, spanContext{})
+	for _, index := range []int32{-4, -3, 0, 1, 2, 3, 4} {
+		r.offer(index, float64(index), spanContext{})
+	}
+
+	r.downscale(1)
+
+	require.Len(t, r.buckets, 5)
+	assert.Equal(t, uint64(1), r.buckets[zeroBucketIndex].seen)
+	assert.Contains(t, []float64{-4, -3}, r.buckets[-2].value)
+	assert.Equal(t, uint64(2), r.buckets[-2].seen)
+	assert.Contains(t, []float64{0, 1}, r.buckets[0].value)
+	assert.Equal(t, uint64(2), r.buckets[0].seen)
+	assert.Contains(t, []float64{2, 3}, r.buckets[1].value)
+	assert.Equal(t, uint64(2), r.buckets[1].seen)
+	assert.Equal(t, 4.0, r.buckets[2].value)
+
+	r.downscale(0)
+	assert.Len(t, r.buckets, 5)
+}
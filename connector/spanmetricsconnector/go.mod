@@ -4,6 +4,7 @@ go 1.19
 
 require (
 	github.com/hashicorp/golang-lru v0.6.0
+	github.com/lightstep/go-expohisto v1.0.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.72.0
 	github.com/stretchr/testify v1.8.2
 	github.com/tilinna/clock v1.1.0
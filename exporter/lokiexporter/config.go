@@ -15,9 +15,12 @@
 package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 )
@@ -48,6 +51,44 @@ type Config struct {
 	// Deprecated: [v0.57.0] use the attribute processor to add a `loki.tenant` hint.
 	// See this component's documentation for more information on how to specify the hint.
 	Tenant *Tenant `mapstructure:"tenant"`
+
+	// StreamLabels defines rules promoting attributes to Loki stream labels, in addition to
+	// the labels selected through the `loki.attribute.labels` and `loki.resource.labels` hints.
+	StreamLabels []StreamLabelRule `mapstructure:"stream_labels"`
+
+	// StructuredMetadata sends the attributes that are not used as labels as Loki structured
+	// metadata, instead of encoding them into the log line.
+	StructuredMetadata bool `mapstructure:"structured_metadata"`
+}
+
+// StreamLabelRule defines how a single stream label is obtained from the log data.
+type StreamLabelRule struct {
+	// Label is the name of the stream label.
+	Label string `mapstructure:"label"`
+
+	// FromAttribute is the log record attribute holding the label value.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// FromResourceAttribute is the resource attribute holding the label value.
+	FromResourceAttribute string `mapstructure:"from_resource_attribute"`
+
+	// Pattern is an optional regular expression applied to the attribute value. When it has a
+	// capture group, the first group becomes the label value, otherwise the whole match is used.
+	// The label is not set when the value does not match.
+	Pattern string `mapstructure:"pattern"`
+}
+
+func (r *StreamLabelRule) validate() error {
+	if !model.LabelName(r.Label).IsValid() {
+		return fmt.Errorf("%q is not a valid label name, label names must match %s", r.Label, model.LabelNameRE.String())
+	}
+	if (r.FromAttribute == "") == (r.FromResourceAttribute == "") {
+		return errors.New("exactly one of \"from_attribute\" or \"from_resource_attribute\" must be set")
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	return nil
 }
 
 func (c *Config) Validate() error {
@@ -61,9 +102,18 @@ func (c *Config) Validate() error {
 
 	// further validation is needed only if we are in legacy mode
 	if !c.isLegacy() {
+		for i, rule := range c.StreamLabels {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("stream_labels[%d]: %w", i, err)
+			}
+		}
 		return nil
 	}
 
+	if len(c.StreamLabels) > 0 || c.StructuredMetadata {
+		return fmt.Errorf("\"stream_labels\" and \"structured_metadata\" cannot be used with the deprecated settings")
+	}
+
 	if c.Tenant != nil {
 		if c.Tenant.Source != "attributes" && c.Tenant.Source != "context" && c.Tenant.Source != "static" {
 			return fmt.Errorf("invalid tenant source, must be one of 'attributes', 'context', 'static', but is %s", c.Tenant.Source)
@@ -76,6 +76,25 @@ func TestLoadConfigNewExporter(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "streamlabels"),
+			expected: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint:        "https://loki:3100/loki/api/v1/push",
+					Headers:         map[string]configopaque.String{},
+					Timeout:         30 * time.Second,
+					ReadBufferSize:  0,
+					WriteBufferSize: 512 * 1024,
+				},
+				RetrySettings: exporterhelper.NewDefaultRetrySettings(),
+				QueueSettings: exporterhelper.NewDefaultQueueSettings(),
+				StreamLabels: []StreamLabelRule{
+					{Label: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+					{Label: "level", FromAttribute: "log.level", Pattern: "^(?i)(debug|info|warn|error)"},
+				},
+				StructuredMetadata: true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -194,6 +213,79 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "Stream labels are valid",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				StreamLabels: []StreamLabelRule{
+					{Label: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+					{Label: "status_class", FromAttribute: "http.status", Pattern: "^([1-5])"},
+				},
+				StructuredMetadata: true,
+			},
+			err: nil,
+		},
+		{
+			desc: "Stream label name is invalid",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				StreamLabels: []StreamLabelRule{
+					{Label: "k8s.namespace", FromResourceAttribute: "k8s.namespace.name"},
+				},
+			},
+			err: fmt.Errorf("stream_labels[0]: \"k8s.namespace\" is not a valid label name"),
+		},
+		{
+			desc: "Stream label has no source",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				StreamLabels: []StreamLabelRule{
+					{Label: "namespace"},
+				},
+			},
+			err: fmt.Errorf("stream_labels[0]: exactly one of \"from_attribute\" or \"from_resource_attribute\" must be set"),
+		},
+		{
+			desc: "Stream label has two sources",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				StreamLabels: []StreamLabelRule{
+					{Label: "namespace", FromAttribute: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+				},
+			},
+			err: fmt.Errorf("stream_labels[0]: exactly one of \"from_attribute\" or \"from_resource_attribute\" must be set"),
+		},
+		{
+			desc: "Stream label pattern is invalid",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				StreamLabels: []StreamLabelRule{
+					{Label: "level", FromAttribute: "log.level", Pattern: "(unclosed"},
+				},
+			},
+			err: fmt.Errorf("stream_labels[0]: invalid pattern"),
+		},
+		{
+			desc: "Structured metadata with legacy settings",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+				TenantID:           stringp("acme"),
+				StructuredMetadata: true,
+			},
+			err: fmt.Errorf("\"stream_labels\" and \"structured_metadata\" cannot be used with the deprecated settings"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"regexp"
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
@@ -29,6 +30,8 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/prometheus/common/model"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 )
 
@@ -36,20 +39,39 @@ type nextLokiExporter struct {
 	config   *Config
 	settings component.TelemetrySettings
 	client   *http.Client
+	options  loki.Options
 	wg       sync.WaitGroup
 }
 
-func newNextExporter(config *Config, settings component.TelemetrySettings) *nextLokiExporter {
+func newNextExporter(config *Config, settings component.TelemetrySettings) (*nextLokiExporter, error) {
 	settings.Logger.Info("using the new Loki exporter")
 
+	options := loki.Options{StructuredMetadata: config.StructuredMetadata}
+	for _, rule := range config.StreamLabels {
+		labelRule := loki.LabelRule{
+			Label:                 model.LabelName(rule.Label),
+			FromAttribute:         rule.FromAttribute,
+			FromResourceAttribute: rule.FromResourceAttribute,
+		}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern for label %q: %w", rule.Label, err)
+			}
+			labelRule.Pattern = pattern
+		}
+		options.LabelRules = append(options.LabelRules, labelRule)
+	}
+
 	return &nextLokiExporter{
 		config:   config,
 		settings: settings,
-	}
+		options:  options,
+	}, nil
 }
 
 func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld)
+	requests := loki.LogsToLokiRequestsWithOptions(ld, l.options)
 
 	var errs error
 	for tenant, request := range requests {
@@ -35,12 +35,15 @@ import (
 
 func TestPushLogData(t *testing.T) {
 	testCases := []struct {
-		desc          string
-		hints         map[string]interface{}
-		attrs         map[string]interface{}
-		res           map[string]interface{}
-		expectedLabel string
-		expectedLine  string
+		desc                     string
+		hints                    map[string]interface{}
+		attrs                    map[string]interface{}
+		res                      map[string]interface{}
+		streamLabels             []StreamLabelRule
+		structuredMetadata       bool
+		expectedLabel            string
+		expectedLine             string
+		expectedStructuredFields push.LabelsAdapter
 	}{
 		{
 			desc: "with attribute to label and regular attribute",
@@ -66,6 +69,42 @@ func TestPushLogData(t *testing.T) {
 			expectedLabel: `{exporter="OTLP", host.name="guarana"}`,
 			expectedLine:  `{"traceid":"01020304000000000000000000000000","resources":{"region.az":"eu-west-1a"}}`,
 		},
+		{
+			desc: "with stream label rules",
+			res: map[string]interface{}{
+				"k8s.namespace.name": "prod",
+				"k8s.pod.name":       "api-7d9f8b6c5-x2x4z",
+			},
+			attrs: map[string]interface{}{
+				"http.status": 503,
+			},
+			streamLabels: []StreamLabelRule{
+				{Label: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+				{Label: "status_class", FromAttribute: "http.status", Pattern: "^([1-5])"},
+			},
+			expectedLabel: `{exporter="OTLP", namespace="prod", status_class="5"}`,
+			expectedLine:  `{"traceid":"01020304000000000000000000000000","attributes":{"http.status":503},"resources":{"k8s.pod.name":"api-7d9f8b6c5-x2x4z"}}`,
+		},
+		{
+			desc: "with structured metadata",
+			res: map[string]interface{}{
+				"region.az": "eu-west-1a",
+			},
+			attrs: map[string]interface{}{
+				"host.name":   "guarana",
+				"http.status": 200,
+			},
+			hints: map[string]interface{}{
+				"loki.attribute.labels": "host.name",
+			},
+			structuredMetadata: true,
+			expectedLabel:      `{exporter="OTLP", host.name="guarana"}`,
+			expectedLine:       `{"traceid":"01020304000000000000000000000000"}`,
+			expectedStructuredFields: push.LabelsAdapter{
+				{Name: "http_status", Value: "200"},
+				{Name: "region_az", Value: "eu-west-1a"},
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -88,6 +127,8 @@ func TestPushLogData(t *testing.T) {
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: ts.URL,
 				},
+				StreamLabels:       tC.streamLabels,
+				StructuredMetadata: tC.structuredMetadata,
 			}
 
 			f := NewFactory()
@@ -127,6 +168,7 @@ func TestPushLogData(t *testing.T) {
 
 			assert.Len(t, actualPushRequest.Streams[0].Entries, 1)
 			assert.Equal(t, tC.expectedLine, actualPushRequest.Streams[0].Entries[0].Line)
+			assert.Equal(t, tC.expectedStructuredFields, actualPushRequest.Streams[0].Entries[0].StructuredMetadata)
 
 			// cleanup
 			err = exp.Shutdown(context.Background())
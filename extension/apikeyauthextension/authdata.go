@@ -0,0 +1,54 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+var _ client.AuthData = (*authData)(nil)
+
+// authData exposes the tenant and attributes of the API key used by an authenticated request.
+type authData struct {
+	key *apiKey
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "tenant":
+		return a.key.Tenant
+	case "key_id":
+		return a.key.ID
+	default:
+		if v, ok := a.key.Attributes[name]; ok {
+			return v
+		}
+		return nil
+	}
+}
+
+func (a *authData) GetAttributeNames() []string {
+	names := []string{"tenant", "key_id"}
+	attributes := make([]string, 0, len(a.key.Attributes))
+	for name := range a.key.Attributes {
+		if name != "tenant" && name != "key_id" {
+			attributes = append(attributes, name)
+		}
+	}
+	sort.Strings(attributes)
+	return append(names, attributes...)
+}
@@ -0,0 +1,49 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"errors"
+)
+
+var (
+	errNoKeysFile = errors.New("`keys_file` must be specified")
+	errNoHeader   = errors.New("`header` must be specified")
+)
+
+// Config has the configuration for the API key authenticator extension.
+type Config struct {
+	// Header is the request header holding the API key. Optional, default value: "authorization".
+	Header string `mapstructure:"header"`
+
+	// Scheme is the authorization scheme preceding the API key in the header value, e.g. "Bearer".
+	// When empty, the whole header value is used as the API key. Optional, default value: "Bearer".
+	Scheme string `mapstructure:"scheme"`
+
+	// KeysFile is the path to the file holding the SHA-256 hashes of the accepted API keys along with
+	// their tenant and attributes. The file is reloaded when it changes.
+	// Required.
+	KeysFile string `mapstructure:"keys_file"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.KeysFile == "" {
+		return errNoKeysFile
+	}
+	if cfg.Header == "" {
+		return errNoHeader
+	}
+	return nil
+}
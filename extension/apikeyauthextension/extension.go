@@ -0,0 +1,174 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.uber.org/zap"
+)
+
+var (
+	errNoAPIKey            = errors.New("no API key provided")
+	errInvalidSchemePrefix = errors.New("invalid authorization scheme prefix")
+	errInvalidAPIKey       = errors.New("invalid API key")
+)
+
+type apiKeyAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu sync.RWMutex
+	// keys holds the accepted keys by their hex encoded hash.
+	keys map[string]*apiKey
+
+	watcher    *fsnotify.Watcher
+	shutdownCH chan struct{}
+	done       sync.WaitGroup
+}
+
+func newServerAuthExtension(cfg *Config, logger *zap.Logger) auth.Server {
+	a := &apiKeyAuth{
+		cfg:    cfg,
+		logger: logger,
+	}
+	return auth.NewServer(
+		auth.WithServerStart(a.start),
+		auth.WithServerAuthenticate(a.authenticate),
+		auth.WithServerShutdown(a.shutdown),
+	)
+}
+
+// start loads the keys file and starts watching it for changes.
+func (a *apiKeyAuth) start(context.Context, component.Host) error {
+	keys, err := loadKeys(a.cfg.KeysFile)
+	if err != nil {
+		return err
+	}
+	a.setKeys(keys)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(a.cfg.KeysFile); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	a.watcher = watcher
+	a.shutdownCH = make(chan struct{})
+	a.done.Add(1)
+	go a.watch()
+	return nil
+}
+
+func (a *apiKeyAuth) watch() {
+	defer a.done.Done()
+	for {
+		select {
+		case <-a.shutdownCH:
+			return
+		case err, ok := <-a.watcher.Errors:
+			if ok {
+				a.logger.Error("error watching keys file", zap.Error(err))
+			}
+		case event, ok := <-a.watcher.Events:
+			if !ok {
+				continue
+			}
+			// NOTE: k8s configmaps and secrets use symlinks, the original file is removed
+			// when they are updated and the watch has to be added again.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+				_ = a.watcher.Remove(event.Name)
+				if err := a.watcher.Add(a.cfg.KeysFile); err != nil {
+					a.logger.Error("error watching keys file", zap.Error(err))
+				}
+				a.reload()
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				a.reload()
+			}
+		}
+	}
+}
+
+// reload loads the keys file again, keeping the current keys if it isn't valid.
+func (a *apiKeyAuth) reload() {
+	keys, err := loadKeys(a.cfg.KeysFile)
+	if err != nil {
+		a.logger.Error("failed to reload keys file, keeping the previous keys", zap.String("filename", a.cfg.KeysFile), zap.Error(err))
+		return
+	}
+	a.setKeys(keys)
+	a.logger.Info("reloaded keys file", zap.String("filename", a.cfg.KeysFile), zap.Int("keys", len(keys)))
+}
+
+func (a *apiKeyAuth) setKeys(keys map[string]*apiKey) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keys = keys
+}
+
+func (a *apiKeyAuth) shutdown(context.Context) error {
+	if a.watcher == nil {
+		return nil
+	}
+	close(a.shutdownCH)
+	a.done.Wait()
+	err := a.watcher.Close()
+	a.watcher = nil
+	return err
+}
+
+// authenticate looks up the hash of the API key from the configured header. Successfully authenticated
+// calls return a context with the tenant and attributes of the key as auth data.
+func (a *apiKeyAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values := client.NewMetadata(headers).Get(a.cfg.Header)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, errNoAPIKey
+	}
+
+	// we only use the first header, if multiple values exist
+	key := values[0]
+	if a.cfg.Scheme != "" {
+		prefix := a.cfg.Scheme + " "
+		if len(key) < len(prefix) || !strings.EqualFold(key[:len(prefix)], prefix) {
+			return ctx, errInvalidSchemePrefix
+		}
+		key = key[len(prefix):]
+	}
+
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	a.mu.RLock()
+	k, ok := a.keys[hex.EncodeToString(sum[:])]
+	a.mu.RUnlock()
+	if !ok {
+		return ctx, errInvalidAPIKey
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{key: k}
+	return client.NewContext(ctx, cl), nil
+}
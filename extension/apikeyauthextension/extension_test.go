@@ -0,0 +1,151 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuthenticate(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		cfg                *Config
+		headers            map[string][]string
+		expectedErr        error
+		expectedAttributes map[string]interface{}
+	}{
+		{
+			name:    "bearer key",
+			cfg:     &Config{Header: "authorization", Scheme: "Bearer"},
+			headers: map[string][]string{"Authorization": {"Bearer agent-key-1"}},
+			expectedAttributes: map[string]interface{}{
+				"tenant": "acme",
+				"key_id": "agent-eu-1",
+				"region": "eu",
+				"env":    "prod",
+			},
+		},
+		{
+			name:    "custom header without scheme",
+			cfg:     &Config{Header: "x-api-key"},
+			headers: map[string][]string{"x-api-key": {"agent-key-2"}},
+			expectedAttributes: map[string]interface{}{
+				"tenant": "globex",
+				"key_id": "",
+			},
+		},
+		{
+			name:        "missing header",
+			cfg:         &Config{Header: "authorization", Scheme: "Bearer"},
+			headers:     map[string][]string{"x-api-key": {"agent-key-1"}},
+			expectedErr: errNoAPIKey,
+		},
+		{
+			name:        "invalid scheme",
+			cfg:         &Config{Header: "authorization", Scheme: "Bearer"},
+			headers:     map[string][]string{"authorization": {"Basic agent-key-1"}},
+			expectedErr: errInvalidSchemePrefix,
+		},
+		{
+			name:        "unknown key",
+			cfg:         &Config{Header: "authorization", Scheme: "Bearer"},
+			headers:     map[string][]string{"authorization": {"Bearer agent-key-3"}},
+			expectedErr: errInvalidAPIKey,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.KeysFile = filepath.Join("testdata", "keys.yaml")
+			ext := newServerAuthExtension(tt.cfg, zap.NewNop())
+			require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+			ctx, err := ext.Authenticate(context.Background(), tt.headers)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			authData := client.FromContext(ctx).Auth
+			require.NotNil(t, authData)
+			assert.Len(t, authData.GetAttributeNames(), len(tt.expectedAttributes))
+			for name, value := range tt.expectedAttributes {
+				assert.Equal(t, value, authData.GetAttribute(name))
+			}
+			assert.Nil(t, authData.GetAttribute("raw"))
+		})
+	}
+}
+
+func TestStartInvalidKeysFile(t *testing.T) {
+	ext := newServerAuthExtension(&Config{Header: "authorization", KeysFile: filepath.Join("testdata", "missing.yaml")}, zap.NewNop())
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestReloadKeysFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keys.yaml")
+	writeKeys := func(content string) {
+		require.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	}
+	writeKeys("keys:\n  - sha256: " + hashKey("old-key") + "\n    tenant: acme\n")
+
+	ext := newServerAuthExtension(&Config{Header: "authorization", Scheme: "Bearer", KeysFile: filename}, zap.NewNop())
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+	authenticate := func(key string) error {
+		_, err := ext.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + key}})
+		return err
+	}
+	require.NoError(t, authenticate("old-key"))
+
+	// rotate the key
+	writeKeys("keys:\n  - sha256: " + hashKey("new-key") + "\n    tenant: acme\n")
+	require.Eventually(t, func() bool {
+		return authenticate("new-key") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, authenticate("old-key"), errInvalidAPIKey)
+
+	// an invalid file keeps the previous keys
+	writeKeys("keys: {")
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, authenticate("new-key"))
+
+	// replacing the file, as done for k8s secrets, is picked up as well
+	replacement := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(replacement, []byte("keys:\n  - sha256: "+hashKey("replaced-key")+"\n    tenant: acme\n"), 0600))
+	require.NoError(t, os.Rename(replacement, filename))
+	require.Eventually(t, func() bool {
+		return authenticate("replaced-key") == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// keysFile is the content of the keys file.
+type keysFile struct {
+	Keys []apiKey `yaml:"keys"`
+}
+
+// apiKey is an accepted API key, identified by its hash.
+type apiKey struct {
+	// ID is an optional name of the key, exposed as the `key_id` auth attribute.
+	ID string `yaml:"id"`
+	// SHA256 is the hex encoded SHA-256 hash of the key.
+	SHA256 string `yaml:"sha256"`
+	// Tenant is the ID of the tenant the key belongs to.
+	Tenant string `yaml:"tenant"`
+	// Attributes are additional auth attributes for requests using the key.
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// loadKeys reads the keys file and returns the keys by their lower-case hex encoded hash.
+func loadKeys(filename string) (map[string]*apiKey, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read keys file: %w", err)
+	}
+
+	var file keysFile
+	if err = yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("parse keys file: %w", err)
+	}
+	if len(file.Keys) == 0 {
+		// most likely a partially written file, which must not lock out every client.
+		return nil, errors.New("keys file contains no keys")
+	}
+
+	keys := make(map[string]*apiKey, len(file.Keys))
+	for i := range file.Keys {
+		key := &file.Keys[i]
+		if err = key.validate(); err != nil {
+			return nil, fmt.Errorf("keys[%d]: %w", i, err)
+		}
+
+		hash := strings.ToLower(key.SHA256)
+		if _, ok := keys[hash]; ok {
+			return nil, fmt.Errorf("keys[%d]: duplicate sha256", i)
+		}
+		keys[hash] = key
+	}
+	return keys, nil
+}
+
+func (k *apiKey) validate() error {
+	if k.Tenant == "" {
+		return errors.New("tenant must be specified")
+	}
+	hash, err := hex.DecodeString(k.SHA256)
+	if err != nil || len(hash) != 32 {
+		return errors.New("sha256 must be a hex encoded SHA-256 hash")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeys(t *testing.T) {
+	keys, err := loadKeys(filepath.Join("testdata", "keys.yaml"))
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	// hashes are looked up in lower case.
+	key, ok := keys[hashKey("agent-key-2")]
+	require.True(t, ok)
+	assert.Equal(t, "globex", key.Tenant)
+}
+
+func TestLoadKeysErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "invalid yaml",
+			content:     "keys: {",
+			expectedErr: "parse keys file: yaml: line 1: did not find expected node content",
+		},
+		{
+			name:        "no keys",
+			content:     "",
+			expectedErr: "keys file contains no keys",
+		},
+		{
+			name:        "missing tenant",
+			content:     "keys:\n  - sha256: " + hashKey("key"),
+			expectedErr: "keys[0]: tenant must be specified",
+		},
+		{
+			name:        "invalid hash",
+			content:     "keys:\n  - sha256: plaintext-key\n    tenant: acme",
+			expectedErr: "keys[0]: sha256 must be a hex encoded SHA-256 hash",
+		},
+		{
+			name:        "duplicate hash",
+			content:     "keys:\n  - sha256: " + hashKey("key") + "\n    tenant: acme\n  - sha256: " + hashKey("key") + "\n    tenant: globex",
+			expectedErr: "keys[1]: duplicate sha256",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "keys.yaml")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0600))
+
+			_, err := loadKeys(filename)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+
+	_, err := loadKeys(filepath.Join("testdata", "missing.yaml"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -17,18 +17,27 @@ package source // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/client"
 )
 
 var _ Source = (*ContextSource)(nil)
 
+// authPrefix is the prefix of keys that are looked up in the auth data
+// instead of the request metadata.
+const authPrefix = "auth."
+
 type ContextSource struct {
 	Key string
 }
 
 func (ts *ContextSource) Get(ctx context.Context) (string, error) {
 	cl := client.FromContext(ctx)
+	if strings.HasPrefix(ts.Key, authPrefix) {
+		return authAttribute(cl.Auth, strings.TrimPrefix(ts.Key, authPrefix))
+	}
+
 	ss := cl.Metadata.Get(ts.Key)
 
 	if len(ss) == 0 {
@@ -41,3 +50,18 @@ func (ts *ContextSource) Get(ctx context.Context) (string, error) {
 
 	return ss[0], nil
 }
+
+func authAttribute(authData client.AuthData, attr string) (string, error) {
+	if authData == nil {
+		return "", nil
+	}
+
+	switch v := authData.GetAttribute(attr).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("auth attribute %q is of type %T, only strings are supported", attr, v)
+	}
+}
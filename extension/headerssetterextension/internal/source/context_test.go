@@ -57,3 +57,40 @@ func TestContextSourceMultipleFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, header)
 }
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestContextSourceAuth(t *testing.T) {
+	cl := client.FromContext(context.Background())
+	cl.Metadata = client.NewMetadata(map[string][]string{"tenant": {"from-metadata"}})
+	cl.Auth = authData{"tenant": "acme", "groups": []string{"admin"}}
+	ctx := client.NewContext(context.Background(), cl)
+
+	header, err := (&ContextSource{Key: "auth.tenant"}).Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", header)
+
+	header, err = (&ContextSource{Key: "auth.missing"}).Get(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, header)
+
+	header, err = (&ContextSource{Key: "auth.groups"}).Get(ctx)
+	assert.Error(t, err)
+	assert.Empty(t, header)
+
+	header, err = (&ContextSource{Key: "auth.tenant"}).Get(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, header)
+}
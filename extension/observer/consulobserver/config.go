@@ -0,0 +1,72 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+const (
+	defaultWaitTime = 5 * time.Minute
+	// maxWaitTime is the upper bound Consul applies to the wait time of blocking queries.
+	maxWaitTime = 10 * time.Minute
+)
+
+// Config defines configuration for the Consul observer. The Consul agent's default
+// configuration, including the CONSUL_HTTP_* environment variables, is used for
+// unset connection settings.
+type Config struct {
+	// Address is the address of the Consul agent or server.
+	Address string `mapstructure:"address"`
+
+	// Datacenter to query. If not provided, the default agent datacenter is used.
+	Datacenter string `mapstructure:"datacenter"`
+
+	// Token is the ACL token used for the catalog and health queries.
+	Token configopaque.String `mapstructure:"token"`
+
+	// TokenFile is a file containing the ACL token to use.
+	TokenFile string `mapstructure:"token_file"`
+
+	// Namespace is the Consul Enterprise namespace to query.
+	Namespace string `mapstructure:"namespace"`
+
+	// Services limits the observed services to the listed names.
+	// All services in the catalog are observed by default.
+	Services []string `mapstructure:"services"`
+
+	// Tags limits the observed service instances to the ones having all of the listed tags.
+	Tags []string `mapstructure:"tags"`
+
+	// PassingOnly limits the observed service instances to the ones with all health checks passing.
+	PassingOnly bool `mapstructure:"passing_only"`
+
+	// WaitTime is the maximum duration of the blocking queries used to watch the catalog.
+	WaitTime time.Duration `mapstructure:"wait_time"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.WaitTime <= 0 || cfg.WaitTime > maxWaitTime {
+		return errors.New("wait_time must be positive and at most 10m")
+	}
+	if cfg.Token != "" && cfg.TokenFile != "" {
+		return errors.New("only one of token and token_file can be set")
+	}
+	return nil
+}
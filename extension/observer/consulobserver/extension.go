@@ -0,0 +1,280 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+var _ extension.Extension = (*consulObserver)(nil)
+var _ observer.EndpointsLister = (*consulObserver)(nil)
+var _ observer.Observable = (*consulObserver)(nil)
+
+// retryInterval is the delay before a failed catalog or health query is retried.
+var retryInterval = 5 * time.Second
+
+type consulObserver struct {
+	*observer.EndpointsWatcher
+	config      *Config
+	client      *api.Client
+	idNamespace string
+	logger      *zap.Logger
+
+	mu sync.Mutex
+	// endpoints holds the latest endpoints of every watched service by service name.
+	endpoints map[string][]observer.Endpoint
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newObserver creates a new Consul observer extension.
+func newObserver(config *Config, set extension.CreateSettings) (extension.Extension, error) {
+	cfg := api.DefaultConfig()
+	if config.Address != "" {
+		cfg.Address = config.Address
+	}
+	if config.Datacenter != "" {
+		cfg.Datacenter = config.Datacenter
+	}
+	if config.Namespace != "" {
+		cfg.Namespace = config.Namespace
+	}
+	if config.Token != "" {
+		cfg.Token = string(config.Token)
+	}
+	if config.TokenFile != "" {
+		cfg.TokenFile = config.TokenFile
+	}
+
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating consul client: %w", err)
+	}
+
+	o := &consulObserver{
+		config:      config,
+		client:      client,
+		idNamespace: set.ID.String(),
+		logger:      set.Logger,
+		endpoints:   map[string][]observer.Endpoint{},
+	}
+	o.EndpointsWatcher = observer.NewEndpointsWatcher(o, time.Second, set.Logger)
+	return o, nil
+}
+
+// Start watches the Consul catalog for services to observe.
+func (o *consulObserver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.wg.Add(1)
+	go o.watchCatalog(ctx)
+	return nil
+}
+
+// Shutdown stops all catalog and service watches.
+func (o *consulObserver) Shutdown(context.Context) error {
+	if o.cancel != nil {
+		o.cancel()
+	}
+	o.wg.Wait()
+	o.StopListAndWatch()
+	return nil
+}
+
+// ListEndpoints is invoked by an observer.EndpointsWatcher helper to report the service instance endpoints
+// collected by the service watches.
+func (o *consulObserver) ListEndpoints() []observer.Endpoint {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var endpoints []observer.Endpoint
+	for _, serviceEndpoints := range o.endpoints {
+		endpoints = append(endpoints, serviceEndpoints...)
+	}
+	return endpoints
+}
+
+// watchCatalog runs a blocking query on the catalog services and starts a watch of the health
+// endpoint for every service to observe. Watches of services leaving the catalog are stopped.
+func (o *consulObserver) watchCatalog(ctx context.Context) {
+	defer o.wg.Done()
+
+	watches := map[string]context.CancelFunc{}
+	defer func() {
+		for _, cancel := range watches {
+			cancel()
+		}
+	}()
+
+	var index uint64
+	for {
+		opts := &api.QueryOptions{WaitIndex: index, WaitTime: o.config.WaitTime}
+		services, meta, err := o.client.Catalog().Services(opts.WithContext(ctx))
+		if err != nil {
+			if !o.retry(ctx, "failed listing consul catalog services", err) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
+
+		for name, tags := range services {
+			if _, ok := watches[name]; ok || !o.observes(name, tags) {
+				continue
+			}
+			watchCtx, cancel := context.WithCancel(ctx)
+			watches[name] = cancel
+			o.wg.Add(1)
+			go o.watchService(watchCtx, name)
+		}
+
+		for name, cancel := range watches {
+			if _, ok := services[name]; !ok {
+				cancel()
+				delete(watches, name)
+			}
+		}
+	}
+}
+
+// watchService runs blocking queries on the health endpoint of a service and keeps the endpoints
+// of its instances up to date until ctx is done.
+func (o *consulObserver) watchService(ctx context.Context, name string) {
+	defer o.wg.Done()
+	defer o.setEndpoints(name, nil)
+
+	var index uint64
+	for {
+		opts := &api.QueryOptions{WaitIndex: index, WaitTime: o.config.WaitTime}
+		entries, meta, err := o.client.Health().ServiceMultipleTags(name, o.config.Tags, o.config.PassingOnly, opts.WithContext(ctx))
+		if err != nil {
+			if !o.retry(ctx, "failed listing consul service instances", err, zap.String("service", name)) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
+		o.setEndpoints(name, o.endpointsFromEntries(entries))
+	}
+}
+
+// retry logs a failed query and waits for retryInterval. It returns false if ctx
+// is done and the watch should stop.
+func (o *consulObserver) retry(ctx context.Context, msg string, err error, fields ...zap.Field) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	o.logger.Warn(msg, append(fields, zap.Error(err))...)
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(retryInterval):
+		return true
+	}
+}
+
+func (o *consulObserver) setEndpoints(name string, endpoints []observer.Endpoint) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(endpoints) == 0 {
+		delete(o.endpoints, name)
+		return
+	}
+	o.endpoints[name] = endpoints
+}
+
+// observes returns whether the service is selected by the services and tags settings.
+// The catalog reports the tags of all instances of a service, the tags of
+// each instance are filtered by the health query.
+func (o *consulObserver) observes(name string, tags []string) bool {
+	if len(o.config.Services) > 0 && !contains(o.config.Services, name) {
+		return false
+	}
+	for _, tag := range o.config.Tags {
+		if !contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// endpointsFromEntries converts the service instances returned by the health endpoint into
+// consul.service endpoints. The service address is used as target, or the node address
+// if the service was registered without one.
+func (o *consulObserver) endpointsFromEntries(entries []*api.ServiceEntry) []observer.Endpoint {
+	var endpoints []observer.Endpoint
+	for _, entry := range entries {
+		if entry.Node == nil || entry.Service == nil {
+			continue
+		}
+
+		address := entry.Service.Address
+		if address == "" {
+			address = entry.Node.Address
+		}
+		target := address
+		if entry.Service.Port != 0 {
+			target = net.JoinHostPort(address, strconv.Itoa(entry.Service.Port))
+		}
+
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%s", o.idNamespace, entry.Node.Node, entry.Service.ID)),
+			Target: target,
+			Details: &observer.ConsulService{
+				Name:         entry.Service.Service,
+				ServiceID:    entry.Service.ID,
+				Node:         entry.Node.Node,
+				Datacenter:   entry.Node.Datacenter,
+				Address:      address,
+				Port:         uint16(entry.Service.Port),
+				Tags:         entry.Service.Tags,
+				Meta:         entry.Service.Meta,
+				HealthStatus: entry.Checks.AggregatedStatus(),
+			},
+		})
+	}
+	return endpoints
+}
+
+// nextIndex returns the index for the next blocking query. The index is reset
+// if it went backwards, e.g. after a snapshot restore of the Consul servers.
+func nextIndex(previous, last uint64) uint64 {
+	if last < previous {
+		return 0
+	}
+	return last
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
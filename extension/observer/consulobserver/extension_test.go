@@ -0,0 +1,338 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// fakeConsul is an httptest stand-in for the catalog services and health service
+// endpoints of the Consul HTTP API, including blocking query semantics.
+type fakeConsul struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	index   uint64
+	changed chan struct{}
+	entries map[string][]*api.ServiceEntry
+}
+
+func newFakeConsul(t *testing.T) *fakeConsul {
+	f := &fakeConsul{index: 1, changed: make(chan struct{}), entries: map[string][]*api.ServiceEntry{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/catalog/services", func(w http.ResponseWriter, r *http.Request) {
+		index := f.wait(r)
+		f.mu.Lock()
+		services := map[string][]string{}
+		for name, entries := range f.entries {
+			services[name] = []string{}
+			for _, entry := range entries {
+				services[name] = append(services[name], entry.Service.Tags...)
+			}
+		}
+		f.mu.Unlock()
+		f.respond(w, index, services)
+	})
+	mux.HandleFunc("/v1/health/service/", func(w http.ResponseWriter, r *http.Request) {
+		index := f.wait(r)
+		name := strings.TrimPrefix(r.URL.Path, "/v1/health/service/")
+		f.mu.Lock()
+		entries := []*api.ServiceEntry{}
+		for _, entry := range f.entries[name] {
+			if hasTags(entry.Service.Tags, r.URL.Query()["tag"]) {
+				entries = append(entries, entry)
+			}
+		}
+		f.mu.Unlock()
+		f.respond(w, index, entries)
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// wait blocks until the index is past the one of the query, the request is
+// canceled or a second elapsed, and returns the current index.
+func (f *fakeConsul) wait(r *http.Request) uint64 {
+	waitIndex, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+	f.mu.Lock()
+	index, changed := f.index, f.changed
+	f.mu.Unlock()
+	if waitIndex < index {
+		return index
+	}
+	select {
+	case <-changed:
+	case <-r.Context().Done():
+	case <-time.After(time.Second):
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.index
+}
+
+func (f *fakeConsul) respond(w http.ResponseWriter, index uint64, body interface{}) {
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(index, 10))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// register adds or replaces a service instance and unblocks pending queries.
+func (f *fakeConsul) register(entry *api.ServiceEntry) {
+	f.update(func() {
+		name := entry.Service.Service
+		for i, existing := range f.entries[name] {
+			if existing.Service.ID == entry.Service.ID {
+				f.entries[name][i] = entry
+				return
+			}
+		}
+		f.entries[name] = append(f.entries[name], entry)
+	})
+}
+
+func (f *fakeConsul) deregister(name string) {
+	f.update(func() {
+		delete(f.entries, name)
+	})
+}
+
+func (f *fakeConsul) update(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn()
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func hasTags(tags, required []string) bool {
+	for _, tag := range required {
+		if !contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func newEntry(service, id, address string, port int, tags []string, status string) *api.ServiceEntry {
+	return &api.ServiceEntry{
+		Node: &api.Node{Node: "node-1", Address: "10.0.0.1", Datacenter: "dc1"},
+		Service: &api.AgentService{
+			ID:      id,
+			Service: service,
+			Address: address,
+			Port:    port,
+			Tags:    tags,
+			Meta:    map[string]string{"version": "7"},
+		},
+		Checks: api.HealthChecks{
+			{Node: "node-1", CheckID: "serfHealth", Status: api.HealthPassing},
+			{Node: "node-1", CheckID: "service:" + id, ServiceID: id, Status: status},
+		},
+	}
+}
+
+type endpointSink struct {
+	sync.Mutex
+	added   []observer.Endpoint
+	removed []observer.Endpoint
+	changed []observer.Endpoint
+}
+
+func (e *endpointSink) ID() observer.NotifyID {
+	return "endpointSink"
+}
+
+func (e *endpointSink) OnAdd(added []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.added = append(e.added, added...)
+}
+
+func (e *endpointSink) OnRemove(removed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.removed = append(e.removed, removed...)
+}
+
+func (e *endpointSink) OnChange(changed []observer.Endpoint) {
+	e.Lock()
+	defer e.Unlock()
+	e.changed = append(e.changed, changed...)
+}
+
+func requireSink(t *testing.T, sink *endpointSink, f func() bool) {
+	require.Eventually(t, func() bool {
+		sink.Lock()
+		defer sink.Unlock()
+		return f()
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestObserverWatchesCatalog(t *testing.T) {
+	consul := newFakeConsul(t)
+	consul.register(newEntry("redis", "redis-1", "10.0.0.5", 6379, []string{"metrics"}, api.HealthPassing))
+	consul.register(newEntry("web", "web-1", "", 8080, nil, api.HealthPassing))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Address = strings.TrimPrefix(consul.URL, "http://")
+	cfg.Tags = []string{"metrics"}
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	ext, err := newObserver(cfg, set)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	ext.(*consulObserver).ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+	expected := observer.Endpoint{
+		ID:     "consul_observer/node-1/redis-1",
+		Target: "10.0.0.5:6379",
+		Details: &observer.ConsulService{
+			Name:         "redis",
+			ServiceID:    "redis-1",
+			Node:         "node-1",
+			Datacenter:   "dc1",
+			Address:      "10.0.0.5",
+			Port:         6379,
+			Tags:         []string{"metrics"},
+			Meta:         map[string]string{"version": "7"},
+			HealthStatus: "passing",
+		},
+	}
+	assert.Equal(t, expected, sink.added[0])
+
+	consul.register(newEntry("redis", "redis-1", "10.0.0.5", 6379, []string{"metrics"}, api.HealthCritical))
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 1
+	})
+	expected.Details.(*observer.ConsulService).HealthStatus = "critical"
+	assert.Equal(t, expected, sink.changed[0])
+
+	consul.deregister("redis")
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+	assert.Equal(t, expected, sink.removed[0])
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestObserverRetriesFailedQueries(t *testing.T) {
+	retryInterval = 10 * time.Millisecond
+	defer func() { retryInterval = 5 * time.Second }()
+
+	var mu sync.Mutex
+	failures := 2
+	consul := newFakeConsul(t)
+	consul.register(newEntry("redis", "redis-1", "10.0.0.5", 6379, nil, api.HealthPassing))
+	handler := consul.Config.Handler
+	consul.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if failures > 0 {
+			failures--
+			mu.Unlock()
+			http.Error(w, "no cluster leader", http.StatusInternalServerError)
+			return
+		}
+		mu.Unlock()
+		handler.ServeHTTP(w, r)
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Address = strings.TrimPrefix(consul.URL, "http://")
+	set := extensiontest.NewNopCreateSettings()
+	ext, err := newObserver(cfg, set)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	ext.(*consulObserver).ListAndWatch(sink)
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestObserves(t *testing.T) {
+	tests := []struct {
+		name     string
+		services []string
+		tags     []string
+		service  string
+		expected bool
+	}{
+		{name: "all services", service: "redis", expected: true},
+		{name: "listed service", services: []string{"redis", "postgres"}, service: "redis", expected: true},
+		{name: "unlisted service", services: []string{"postgres"}, service: "redis", expected: false},
+		{name: "matching tags", tags: []string{"metrics", "primary"}, service: "redis", expected: true},
+		{name: "missing tag", tags: []string{"metrics", "replica"}, service: "redis", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &consulObserver{config: &Config{Services: tt.services, Tags: tt.tags}, logger: zap.NewNop()}
+			assert.Equal(t, tt.expected, o.observes(tt.service, []string{"metrics", "primary"}))
+		})
+	}
+}
+
+func TestEndpointsFromEntries(t *testing.T) {
+	o := &consulObserver{idNamespace: "consul_observer", logger: zap.NewNop()}
+
+	endpoints := o.endpointsFromEntries([]*api.ServiceEntry{
+		newEntry("web", "web-1", "", 8080, nil, api.HealthWarning),
+		newEntry("worker", "worker-1", "fd00::1", 0, nil, api.HealthPassing),
+	})
+	require.Len(t, endpoints, 2)
+
+	// The node address is used for services registered without one.
+	assert.Equal(t, observer.EndpointID("consul_observer/node-1/web-1"), endpoints[0].ID)
+	assert.Equal(t, "10.0.0.1:8080", endpoints[0].Target)
+	assert.Equal(t, "warning", endpoints[0].Details.(*observer.ConsulService).HealthStatus)
+
+	// Services without a port only report the address.
+	assert.Equal(t, "fd00::1", endpoints[1].Target)
+}
+
+func TestNextIndex(t *testing.T) {
+	assert.Equal(t, uint64(10), nextIndex(0, 10))
+	assert.Equal(t, uint64(10), nextIndex(10, 10))
+	assert.Equal(t, uint64(0), nextIndex(10, 5))
+}
@@ -40,6 +40,12 @@ const (
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
 	ContainerType EndpointType = "container"
+	// K8sServiceType is a Kubernetes Service port endpoint.
+	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
+	// ConsulServiceType is a Consul service instance endpoint.
+	ConsulServiceType EndpointType = "consul.service"
 )
 
 var (
@@ -48,6 +54,9 @@ var (
 	_ EndpointDetails = (*K8sNode)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
+	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
+	_ EndpointDetails = (*ConsulService)(nil)
 )
 
 // EndpointDetails provides additional context about an endpoint such as a Pod or Port.
@@ -273,3 +282,124 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// K8sService is a port of a discovered Kubernetes Service.
+type K8sService struct {
+	// Name is the name of the Kubernetes Service.
+	Name string
+	// UID is the unique ID in the cluster for the service.
+	UID string
+	// Namespace is the namespace of the service.
+	Namespace string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// ServiceType is the type of the service, e.g. ClusterIP, NodePort or LoadBalancer.
+	ServiceType string
+	// ClusterIP is the IP address of the service, or "None" for headless services.
+	ClusterIP string
+	// PortName is the name of the service port.
+	PortName string
+	// Port number of the endpoint.
+	Port uint16
+	// Transport is the transport protocol used by the Endpoint. (TCP or UDP).
+	Transport Transport
+}
+
+func (s *K8sService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":         s.Name,
+		"uid":          s.UID,
+		"namespace":    s.Namespace,
+		"labels":       s.Labels,
+		"annotations":  s.Annotations,
+		"service_type": s.ServiceType,
+		"cluster_ip":   s.ClusterIP,
+		"port_name":    s.PortName,
+		"port":         s.Port,
+		"transport":    s.Transport,
+	}
+}
+
+func (s *K8sService) Type() EndpointType {
+	return K8sServiceType
+}
+
+// K8sIngress is a host and path served by a discovered Kubernetes Ingress.
+type K8sIngress struct {
+	// Name is the name of the Kubernetes Ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Namespace is the namespace of the ingress.
+	Namespace string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Scheme is "https" if the host is covered by the TLS configuration of the ingress, "http" otherwise.
+	Scheme string
+	// Host is the host name the ingress rule applies to.
+	Host string
+	// Path is the path of the ingress rule.
+	Path string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":        i.Name,
+		"uid":         i.UID,
+		"namespace":   i.Namespace,
+		"labels":      i.Labels,
+		"annotations": i.Annotations,
+		"scheme":      i.Scheme,
+		"host":        i.Host,
+		"path":        i.Path,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
+
+// ConsulService is a service instance registered in the Consul catalog.
+type ConsulService struct {
+	// Name is the name of the service.
+	Name string
+	// ServiceID is the ID of the service instance, unique per Consul agent.
+	ServiceID string
+	// Node is the name of the Consul node the instance is registered on.
+	Node string
+	// Datacenter is the Consul datacenter of the node.
+	Datacenter string
+	// Address is the service address, or the node address if the service has none.
+	Address string
+	// Port number of the endpoint.
+	Port uint16
+	// Tags is a list of tags of the service instance.
+	Tags []string
+	// Meta is a map of user-specified service instance metadata.
+	Meta map[string]string
+	// HealthStatus is the aggregated status of the health checks of the instance,
+	// one of "passing", "warning", "critical" or "maintenance".
+	HealthStatus string
+}
+
+func (c *ConsulService) Env() EndpointEnv {
+	return map[string]interface{}{
+		"name":          c.Name,
+		"service_id":    c.ServiceID,
+		"node":          c.Node,
+		"datacenter":    c.Datacenter,
+		"address":       c.Address,
+		"port":          c.Port,
+		"tags":          c.Tags,
+		"meta":          c.Meta,
+		"health_status": c.HealthStatus,
+	}
+}
+
+func (c *ConsulService) Type() EndpointType {
+	return ConsulServiceType
+}
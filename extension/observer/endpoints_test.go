@@ -205,6 +205,102 @@ func TestEndpointEnv(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "K8s service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "10.96.0.10:6379",
+				Details: &K8sService{
+					Name:        "redis",
+					UID:         "redis-uid",
+					Namespace:   "default",
+					Labels:      map[string]string{"app": "redis"},
+					Annotations: map[string]string{"annotation_key": "annotation_val"},
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.96.0.10",
+					PortName:    "redis",
+					Port:        6379,
+					Transport:   ProtocolTCP,
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"id":           "k8s_service_endpoint_id",
+				"endpoint":     "10.96.0.10:6379",
+				"name":         "redis",
+				"uid":          "redis-uid",
+				"namespace":    "default",
+				"labels":       map[string]string{"app": "redis"},
+				"annotations":  map[string]string{"annotation_key": "annotation_val"},
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.96.0.10",
+				"port_name":    "redis",
+				"port":         uint16(6379),
+				"transport":    ProtocolTCP,
+			},
+		},
+		{
+			name: "K8s ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://example.com/api",
+				Details: &K8sIngress{
+					Name:        "web",
+					UID:         "web-uid",
+					Namespace:   "default",
+					Labels:      map[string]string{"app": "web"},
+					Annotations: map[string]string{"annotation_key": "annotation_val"},
+					Scheme:      "https",
+					Host:        "example.com",
+					Path:        "/api",
+				},
+			},
+			want: EndpointEnv{
+				"type":        "k8s.ingress",
+				"id":          "k8s_ingress_endpoint_id",
+				"endpoint":    "https://example.com/api",
+				"name":        "web",
+				"uid":         "web-uid",
+				"namespace":   "default",
+				"labels":      map[string]string{"app": "web"},
+				"annotations": map[string]string{"annotation_key": "annotation_val"},
+				"scheme":      "https",
+				"host":        "example.com",
+				"path":        "/api",
+			},
+		},
+		{
+			name: "Consul service",
+			endpoint: Endpoint{
+				ID:     EndpointID("consul_service_endpoint_id"),
+				Target: "10.0.0.5:6379",
+				Details: &ConsulService{
+					Name:         "redis",
+					ServiceID:    "redis-1",
+					Node:         "node-1",
+					Datacenter:   "dc1",
+					Address:      "10.0.0.5",
+					Port:         6379,
+					Tags:         []string{"primary"},
+					Meta:         map[string]string{"version": "7"},
+					HealthStatus: "passing",
+				},
+			},
+			want: EndpointEnv{
+				"type":          "consul.service",
+				"id":            "consul_service_endpoint_id",
+				"endpoint":      "10.0.0.5:6379",
+				"name":          "redis",
+				"service_id":    "redis-1",
+				"node":          "node-1",
+				"datacenter":    "dc1",
+				"address":       "10.0.0.5",
+				"port":          uint16(6379),
+				"tags":          []string{"primary"},
+				"meta":          map[string]string{"version": "7"},
+				"health_status": "passing",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
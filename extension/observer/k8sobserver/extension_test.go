@@ -229,3 +229,141 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServices(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveServices = true
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.Nil(t, obs.podListerWatcher)
+	require.NotNil(t, obs.serviceListerWatcher)
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+
+	expected := observer.Endpoint{
+		ID:     "k8s_observer/service1-UID/redis(6379)",
+		Target: "10.96.0.10:6379",
+		Details: &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Namespace:   "default",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.96.0.10",
+			PortName:    "redis",
+			Port:        6379,
+			Transport:   observer.ProtocolTCP,
+		},
+	}
+	assert.Equal(t, expected, sink.added[0])
+
+	serviceListerWatcher.Modify(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 1
+	})
+
+	expected.Details.(*observer.K8sService).Labels = map[string]string{
+		"env":             "prod",
+		"service-version": "2",
+	}
+	assert.Equal(t, expected, sink.changed[0])
+
+	serviceListerWatcher.Delete(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+
+	assert.Equal(t, expected, sink.removed[0])
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
+
+func TestExtensionObserveIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveIngresses = true
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.NotNil(t, obs.ingressListerWatcher)
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	ingressListerWatcher.Add(ingress1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 1
+	})
+
+	expected := observer.Endpoint{
+		ID:     "k8s_observer/ingress1-UID/example.com/api",
+		Target: "https://example.com/api",
+		Details: &observer.K8sIngress{
+			Name:      "ingress1",
+			UID:       "ingress1-UID",
+			Namespace: "default",
+			Labels:    map[string]string{"env": "prod"},
+			Scheme:    "https",
+			Host:      "example.com",
+			Path:      "/api",
+		},
+	}
+	assert.Equal(t, expected, sink.added[0])
+
+	ingressListerWatcher.Modify(ingress1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 1
+	})
+
+	expected.Details.(*observer.K8sIngress).Labels = map[string]string{
+		"env":             "prod",
+		"ingress-version": "2",
+	}
+	assert.Equal(t, expected, sink.changed[0])
+
+	ingressListerWatcher.Delete(ingress1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 1
+	})
+
+	assert.Equal(t, expected, sink.removed[0])
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
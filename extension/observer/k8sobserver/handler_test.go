@@ -203,3 +203,75 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID/redis(6379)",
+			Target: "10.96.0.10:6379",
+			Details: &observer.K8sService{
+				Name:        "service1",
+				UID:         "service1-UID",
+				Namespace:   "default",
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{"annotation-key": "annotation-value"},
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.96.0.10",
+				PortName:    "redis",
+				Port:        6379,
+				Transport:   observer.ProtocolTCP,
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+
+	// Port changed, the old port endpoint is removed and a new one added.
+	updatedService := service1V1.DeepCopy()
+	updatedService.Spec.Ports[0].Port = 6380
+	th.OnUpdate(service1V1, updatedService)
+
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, observer.EndpointID("test-1/service1-UID/redis(6380)"), endpoints[0].ID)
+	assert.Equal(t, "10.96.0.10:6380", endpoints[0].Target)
+}
+
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/ingress1-UID/example.com/api",
+			Target: "https://example.com/api",
+			Details: &observer.K8sIngress{
+				Name:      "ingress1",
+				UID:       "ingress1-UID",
+				Namespace: "default",
+				Labels:    map[string]string{"env": "prod"},
+				Scheme:    "https",
+				Host:      "example.com",
+				Path:      "/api",
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	th.OnDelete(ingress1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
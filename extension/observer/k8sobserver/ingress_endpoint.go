@@ -0,0 +1,84 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+
+	netv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a slice of k8s.ingress endpoints, one for
+// each host and path of its rules. Rules without a host use the load balancer address of the ingress
+// and are skipped until one has been assigned.
+func convertIngressToEndpoints(idNamespace string, ingress *netv1.Ingress) []observer.Endpoint {
+	ingressID := observer.EndpointID(fmt.Sprintf("%s/%s", idNamespace, ingress.UID))
+
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var loadBalancer string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			loadBalancer = lb.IP
+			break
+		}
+		if lb.Hostname != "" {
+			loadBalancer = lb.Hostname
+			break
+		}
+	}
+
+	var endpoints []observer.Endpoint
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = loadBalancer
+		}
+		if host == "" || rule.HTTP == nil {
+			continue
+		}
+
+		scheme := "http"
+		if tlsHosts[rule.Host] {
+			scheme = "https"
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     observer.EndpointID(fmt.Sprintf("%s/%s%s", ingressID, host, path.Path)),
+				Target: fmt.Sprintf("%s://%s%s", scheme, host, path.Path),
+				Details: &observer.K8sIngress{
+					Name:        ingress.Name,
+					UID:         string(ingress.UID),
+					Namespace:   ingress.Namespace,
+					Labels:      ingress.Labels,
+					Annotations: ingress.Annotations,
+					Scheme:      scheme,
+					Host:        host,
+					Path:        path.Path,
+				},
+			})
+		}
+	}
+
+	return endpoints
+}
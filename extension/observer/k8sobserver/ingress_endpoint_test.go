@@ -0,0 +1,80 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	ingress := NewIngress("ingress1", "example.com")
+	ingress.Spec.Rules = append(ingress.Spec.Rules,
+		netv1.IngressRule{
+			Host: "internal.example.com",
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{Path: "/"}},
+				},
+			},
+		},
+		// Rules without a host are skipped until a load balancer address is assigned.
+		netv1.IngressRule{
+			IngressRuleValue: netv1.IngressRuleValue{
+				HTTP: &netv1.HTTPIngressRuleValue{
+					Paths: []netv1.HTTPIngressPath{{Path: "/default"}},
+				},
+			},
+		},
+	)
+
+	details := func(scheme, host, path string) *observer.K8sIngress {
+		return &observer.K8sIngress{
+			Name:      "ingress1",
+			UID:       "ingress1-UID",
+			Namespace: "default",
+			Labels:    map[string]string{"env": "prod"},
+			Scheme:    scheme,
+			Host:      host,
+			Path:      path,
+		}
+	}
+
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/ingress1-UID/example.com/api",
+			Target:  "https://example.com/api",
+			Details: details("https", "example.com", "/api"),
+		},
+		{
+			ID:      "namespace/ingress1-UID/internal.example.com/",
+			Target:  "http://internal.example.com/",
+			Details: details("http", "internal.example.com", "/"),
+		},
+	}, convertIngressToEndpoints("namespace", ingress))
+
+	ingress.Status.LoadBalancer.Ingress = []netv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}}
+	endpoints := convertIngressToEndpoints("namespace", ingress)
+	require.Len(t, endpoints, 3)
+	require.Equal(t, observer.Endpoint{
+		ID:      "namespace/ingress1-UID/10.0.0.1/default",
+		Target:  "http://10.0.0.1/default",
+		Details: details("http", "10.0.0.1", "/default"),
+	}, endpoints[2])
+}
@@ -16,6 +16,7 @@ package k8sobserver
 
 import (
 	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -164,3 +165,72 @@ var node1V2 = func() *v1.Node {
 	node.Labels["node-version"] = "2"
 	return node
 }()
+
+// NewService is a helper function for creating Services for testing.
+func NewService(name, clusterIP string) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+			Annotations: map[string]string{
+				"annotation-key": "annotation-value",
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type:      v1.ServiceTypeClusterIP,
+			ClusterIP: clusterIP,
+			Ports: []v1.ServicePort{
+				{Name: "redis", Port: 6379, Protocol: v1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+var service1V1 = NewService("service1", "10.96.0.10")
+var service1V2 = func() *v1.Service {
+	service := service1V1.DeepCopy()
+	service.Labels["service-version"] = "2"
+	return service
+}()
+
+// NewIngress is a helper function for creating Ingresses for testing.
+func NewIngress(name, host string) *netv1.Ingress {
+	return &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name + "-UID"),
+			Labels: map[string]string{
+				"env": "prod",
+			},
+		},
+		Spec: netv1.IngressSpec{
+			TLS: []netv1.IngressTLS{
+				{Hosts: []string{host}},
+			},
+			Rules: []netv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: netv1.IngressRuleValue{
+						HTTP: &netv1.HTTPIngressRuleValue{
+							Paths: []netv1.HTTPIngressPath{
+								{Path: "/api"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var ingress1V1 = NewIngress("ingress1", "example.com")
+var ingress1V2 = func() *netv1.Ingress {
+	ingress := ingress1V1.DeepCopy()
+	ingress.Labels["ingress-version"] = "2"
+	return ingress
+}()
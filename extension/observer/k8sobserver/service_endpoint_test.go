@@ -0,0 +1,64 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	service := NewService("service1", "10.96.0.10")
+	service.Spec.Ports = append(service.Spec.Ports, v1.ServicePort{Name: "metrics", Port: 9121, Protocol: v1.ProtocolUDP})
+
+	details := func(portName string, port uint16, transport observer.Transport) *observer.K8sService {
+		return &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Namespace:   "default",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			ServiceType: "ClusterIP",
+			ClusterIP:   "10.96.0.10",
+			PortName:    portName,
+			Port:        port,
+			Transport:   transport,
+		}
+	}
+
+	require.Equal(t, []observer.Endpoint{
+		{
+			ID:      "namespace/service1-UID/redis(6379)",
+			Target:  "10.96.0.10:6379",
+			Details: details("redis", 6379, observer.ProtocolTCP),
+		},
+		{
+			ID:      "namespace/service1-UID/metrics(9121)",
+			Target:  "10.96.0.10:9121",
+			Details: details("metrics", 9121, observer.ProtocolUDP),
+		},
+	}, convertServiceToEndpoints("namespace", service))
+}
+
+func TestHeadlessServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	endpoints := convertServiceToEndpoints("namespace", NewService("service1", v1.ClusterIPNone))
+	require.Len(t, endpoints, 1)
+	require.Equal(t, "service1.default.svc:6379", endpoints[0].Target)
+	require.Equal(t, "None", endpoints[0].Details.(*observer.K8sService).ClusterIP)
+}
@@ -14,7 +14,11 @@
 
 package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
 
-import "go.opentelemetry.io/collector/client"
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/client"
+)
 
 var _ client.AuthData = (*authData)(nil)
 
@@ -22,6 +26,9 @@ type authData struct {
 	raw        string
 	subject    string
 	membership []string
+	issuer     string
+	// claims holds the required claims matched by the token.
+	claims map[string]interface{}
 }
 
 func (a *authData) GetAttribute(name string) interface{} {
@@ -32,11 +39,26 @@ func (a *authData) GetAttribute(name string) interface{} {
 		return a.membership
 	case "raw":
 		return a.raw
+	case "issuer":
+		return a.issuer
 	default:
+		if v, ok := a.claims[name]; ok {
+			return v
+		}
 		return nil
 	}
 }
 
-func (*authData) GetAttributeNames() []string {
-	return []string{"subject", "membership", "raw"}
+func (a *authData) GetAttributeNames() []string {
+	names := []string{"subject", "membership", "raw", "issuer"}
+	claims := make([]string, 0, len(a.claims))
+	for name := range a.claims {
+		switch name {
+		case "subject", "membership", "raw", "issuer":
+		default:
+			claims = append(claims, name)
+		}
+	}
+	sort.Strings(claims)
+	return append(names, claims...)
 }
@@ -40,4 +40,59 @@ type Config struct {
 	// The claim that holds the subject's group membership information.
 	// Optional.
 	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// RequiredClaims maps claim names to their allowed values. Tokens from any provider are
+	// rejected unless each listed claim holds at least one of the allowed values, or is
+	// present at all when no values are listed. Matched claims are added to the auth data.
+	// Optional.
+	RequiredClaims map[string][]string `mapstructure:"required_claims"`
+
+	// Providers is a list of additional OIDC providers to accept tokens from. Tokens are
+	// verified by the provider whose issuer URL matches their "iss" claim.
+	// Optional.
+	Providers []ProviderConfig `mapstructure:"providers"`
+}
+
+// ProviderConfig has the configuration for one of the OIDC providers accepted by the authenticator.
+type ProviderConfig struct {
+	// IssuerURL is the base URL for the OIDC provider.
+	// Required.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// Audience of the token, used during the verification.
+	// Required.
+	Audience string `mapstructure:"audience"`
+
+	// The local path for the issuer CA's TLS server cert.
+	// Optional.
+	IssuerCAPath string `mapstructure:"issuer_ca_path"`
+
+	// The claim to use as the username, in case the token's 'sub' isn't the suitable source.
+	// Optional.
+	UsernameClaim string `mapstructure:"username_claim"`
+
+	// The claim that holds the subject's group membership information.
+	// Optional.
+	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// RequiredClaims maps claim names to their allowed values for tokens issued by this provider,
+	// in addition to the top-level required claims.
+	// Optional.
+	RequiredClaims map[string][]string `mapstructure:"required_claims"`
+}
+
+// providers returns the provider configured by the top-level settings, if any, followed by
+// the ones from the providers list.
+func (cfg *Config) providers() []ProviderConfig {
+	var providers []ProviderConfig
+	if cfg.IssuerURL != "" || cfg.Audience != "" || len(cfg.Providers) == 0 {
+		providers = append(providers, ProviderConfig{
+			IssuerURL:     cfg.IssuerURL,
+			Audience:      cfg.Audience,
+			IssuerCAPath:  cfg.IssuerCAPath,
+			UsernameClaim: cfg.UsernameClaim,
+			GroupsClaim:   cfg.GroupsClaim,
+		})
+	}
+	return append(providers, cfg.Providers...)
 }
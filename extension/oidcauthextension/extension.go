@@ -18,6 +18,8 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -38,12 +40,17 @@ import (
 type oidcExtension struct {
 	cfg *Config
 
-	provider *oidc.Provider
-	verifier *oidc.IDTokenVerifier
+	// providers holds the configured providers by issuer URL.
+	providers map[string]*provider
 
 	logger *zap.Logger
 }
 
+type provider struct {
+	cfg      ProviderConfig
+	verifier *oidc.IDTokenVerifier
+}
+
 var (
 	errNoAudienceProvided                = errors.New("no Audience provided for the OIDC configuration")
 	errNoIssuerURL                       = errors.New("no IssuerURL provided for the OIDC configuration")
@@ -53,14 +60,34 @@ var (
 	errUsernameNotString                 = errors.New("the username returned by the OIDC provider isn't a regular string")
 	errGroupsClaimNotFound               = errors.New("groups claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errDuplicateIssuerURL                = errors.New("duplicate IssuerURL in the OIDC configuration")
+	errUnknownIssuer                     = errors.New("the token issuer isn't one of the configured OIDC providers")
+	errRequiredClaimNotFound             = errors.New("required claim not found on the token")
+	errRequiredClaimNotAllowed           = errors.New("required claim has none of the allowed values")
 )
 
 func newExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
-	if cfg.Audience == "" {
-		return nil, errNoAudienceProvided
-	}
-	if cfg.IssuerURL == "" {
-		return nil, errNoIssuerURL
+	providers := cfg.providers()
+	// the top-level settings come first, if set
+	offset := len(providers) - len(cfg.Providers)
+	issuers := map[string]bool{}
+	for i, p := range providers {
+		var err error
+		switch {
+		case p.Audience == "":
+			err = errNoAudienceProvided
+		case p.IssuerURL == "":
+			err = errNoIssuerURL
+		case issuers[p.IssuerURL]:
+			err = errDuplicateIssuerURL
+		}
+		if err != nil && i >= offset {
+			return nil, fmt.Errorf("providers[%d]: %w", i-offset, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		issuers[p.IssuerURL] = true
 	}
 
 	if cfg.Attribute == "" {
@@ -75,15 +102,20 @@ func newExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
 }
 
 func (e *oidcExtension) start(context.Context, component.Host) error {
-	provider, err := getProviderForConfig(e.cfg)
-	if err != nil {
-		return fmt.Errorf("failed to get configuration from the auth server: %w", err)
-	}
-	e.provider = provider
+	e.providers = map[string]*provider{}
+	for _, cfg := range e.cfg.providers() {
+		p, err := getProviderForConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to get configuration from the auth server %q: %w", cfg.IssuerURL, err)
+		}
 
-	e.verifier = e.provider.Verifier(&oidc.Config{
-		ClientID: e.cfg.Audience,
-	})
+		e.providers[cfg.IssuerURL] = &provider{
+			cfg: cfg,
+			verifier: p.Verifier(&oidc.Config{
+				ClientID: cfg.Audience,
+			}),
+		}
+	}
 
 	return nil
 }
@@ -103,7 +135,12 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 	}
 
 	raw := parts[1]
-	idToken, err := e.verifier.Verify(ctx, raw)
+	p, err := e.providerForToken(raw)
+	if err != nil {
+		return ctx, err
+	}
+
+	idToken, err := p.verifier.Verify(ctx, raw)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to verify token: %w", err)
 	}
@@ -119,11 +156,18 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		return ctx, errFailedToObtainClaimsFromToken
 	}
 
-	subject, err := getSubjectFromClaims(claims, e.cfg.UsernameClaim, idToken.Subject)
+	matchedClaims := map[string]interface{}{}
+	for _, required := range []map[string][]string{e.cfg.RequiredClaims, p.cfg.RequiredClaims} {
+		if err = matchRequiredClaims(claims, required, matchedClaims); err != nil {
+			return ctx, fmt.Errorf("token not authorized: %w", err)
+		}
+	}
+
+	subject, err := getSubjectFromClaims(claims, p.cfg.UsernameClaim, idToken.Subject)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get subject from claims in the token: %w", err)
 	}
-	membership, err := getGroupsFromClaims(claims, e.cfg.GroupsClaim)
+	membership, err := getGroupsFromClaims(claims, p.cfg.GroupsClaim)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get groups from claims in the token: %w", err)
 	}
@@ -133,10 +177,96 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		raw:        raw,
 		subject:    subject,
 		membership: membership,
+		issuer:     idToken.Issuer,
+		claims:     matchedClaims,
 	}
 	return client.NewContext(ctx, cl), nil
 }
 
+// providerForToken returns the provider to verify the token with. With a single provider
+// configured, the verification itself checks the issuer. Otherwise, the provider is picked
+// by the unverified "iss" claim of the token.
+func (e *oidcExtension) providerForToken(raw string) (*provider, error) {
+	if len(e.providers) == 1 {
+		for _, p := range e.providers {
+			return p, nil
+		}
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("failed to verify token: malformed jwt, expected 3 parts got %d", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: malformed jwt payload: %w", err)
+	}
+	var token struct {
+		Issuer string `json:"iss"`
+	}
+	if err = json.Unmarshal(payload, &token); err != nil {
+		return nil, fmt.Errorf("failed to verify token: failed to unmarshal claims: %w", err)
+	}
+
+	p, ok := e.providers[token.Issuer]
+	if !ok {
+		return nil, errUnknownIssuer
+	}
+	return p, nil
+}
+
+// matchRequiredClaims checks that each required claim holds one of its allowed values, or is
+// present when it has no allowed values, and adds the matched claims to matched.
+func matchRequiredClaims(claims map[string]interface{}, required map[string][]string, matched map[string]interface{}) error {
+	for name, allowed := range required {
+		rawValue, ok := claims[name]
+		if !ok {
+			return fmt.Errorf("%w: %q", errRequiredClaimNotFound, name)
+		}
+
+		values := claimValues(rawValue)
+		if len(allowed) > 0 && !containsAny(values, allowed) {
+			return fmt.Errorf("%w: %q", errRequiredClaimNotAllowed, name)
+		}
+
+		if _, isString := rawValue.(string); isString {
+			matched[name] = values[0]
+		} else {
+			matched[name] = values
+		}
+	}
+	return nil
+}
+
+// claimValues returns the values of a claim holding a single value or a list of values.
+func claimValues(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for i := range v {
+			values = append(values, fmt.Sprintf("%v", v[i]))
+		}
+		return values
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
+
+func containsAny(values []string, allowed []string) bool {
+	for _, value := range values {
+		for _, a := range allowed {
+			if value == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func getSubjectFromClaims(claims map[string]interface{}, usernameClaim string, fallback string) (string, error) {
 	if len(usernameClaim) > 0 {
 		username, found := claims[usernameClaim]
@@ -180,7 +310,7 @@ func getGroupsFromClaims(claims map[string]interface{}, groupsClaim string) ([]s
 	return []string{}, nil
 }
 
-func getProviderForConfig(config *Config) (*oidc.Provider, error) {
+func getProviderForConfig(config ProviderConfig) (*oidc.Provider, error) {
 	t := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -31,6 +31,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 )
@@ -81,6 +82,182 @@ func TestOIDCAuthenticationSucceeded(t *testing.T) {
 	// TODO(jpkroehling): assert that the authentication routine set the subject/membership to the resource
 }
 
+func TestOIDCMultipleProviders(t *testing.T) {
+	// prepare
+	servers := make([]*oidcServer, 3)
+	for i := range servers {
+		server, err := newOIDCServer()
+		require.NoError(t, err)
+		server.Start()
+		defer server.Close()
+		servers[i] = server
+	}
+
+	config := &Config{
+		IssuerURL: servers[0].URL,
+		Audience:  "unit-test",
+		Providers: []ProviderConfig{
+			{
+				IssuerURL:     servers[1].URL,
+				Audience:      "other-audience",
+				UsernameClaim: "email",
+			},
+		},
+	}
+	p, err := newExtension(config, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, tt := range []struct {
+		casename        string
+		server          *oidcServer
+		audience        string
+		expectedSubject string
+		expectedError   error
+	}{
+		{
+			casename:        "first provider",
+			server:          servers[0],
+			audience:        "unit-test",
+			expectedSubject: "jdoe",
+		},
+		{
+			casename:        "second provider",
+			server:          servers[1],
+			audience:        "other-audience",
+			expectedSubject: "jdoe@example.com",
+		},
+		{
+			casename:      "unknown issuer",
+			server:        servers[2],
+			audience:      "unit-test",
+			expectedError: errUnknownIssuer,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			payload, _ := json.Marshal(map[string]interface{}{
+				"sub":   "jdoe",
+				"email": "jdoe@example.com",
+				"iss":   tt.server.URL,
+				"aud":   tt.audience,
+				"exp":   time.Now().Add(time.Minute).Unix(),
+			})
+			token, err := tt.server.token(payload)
+			require.NoError(t, err)
+
+			// test
+			ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			authData := client.FromContext(ctx).Auth
+			assert.Equal(t, tt.expectedSubject, authData.GetAttribute("subject"))
+			assert.Equal(t, tt.server.URL, authData.GetAttribute("issuer"))
+		})
+	}
+}
+
+func TestOIDCRequiredClaims(t *testing.T) {
+	// prepare
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	for _, tt := range []struct {
+		casename       string
+		required       map[string][]string
+		providerClaims map[string][]string
+		expectedClaims map[string]interface{}
+		expectedError  error
+	}{
+		{
+			casename:       "list claim contains allowed value",
+			required:       map[string][]string{"groups": {"telemetry-writers"}},
+			expectedClaims: map[string]interface{}{"groups": []string{"developers", "telemetry-writers"}},
+		},
+		{
+			casename:       "string claim in allowed set",
+			required:       map[string][]string{"tenant": {"acme", "globex"}},
+			expectedClaims: map[string]interface{}{"tenant": "acme"},
+		},
+		{
+			casename:       "claim presence only",
+			required:       map[string][]string{"tenant": nil},
+			expectedClaims: map[string]interface{}{"tenant": "acme"},
+		},
+		{
+			casename:       "top-level and provider claims",
+			required:       map[string][]string{"tenant": {"acme"}},
+			providerClaims: map[string][]string{"groups": {"telemetry-writers"}},
+			expectedClaims: map[string]interface{}{
+				"tenant": "acme",
+				"groups": []string{"developers", "telemetry-writers"},
+			},
+		},
+		{
+			casename:      "claim not found",
+			required:      map[string][]string{"region": {"eu"}},
+			expectedError: errRequiredClaimNotFound,
+		},
+		{
+			casename:      "value not allowed",
+			required:      map[string][]string{"tenant": {"globex"}},
+			expectedError: errRequiredClaimNotAllowed,
+		},
+		{
+			casename:       "provider value not allowed",
+			providerClaims: map[string][]string{"groups": {"admins"}},
+			expectedError:  errRequiredClaimNotAllowed,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			config := &Config{
+				RequiredClaims: tt.required,
+				Providers: []ProviderConfig{{
+					IssuerURL:      oidcServer.URL,
+					Audience:       "unit-test",
+					RequiredClaims: tt.providerClaims,
+				}},
+			}
+			p, err := newExtension(config, zap.NewNop())
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+			payload, _ := json.Marshal(map[string]interface{}{
+				"sub":    "jdoe",
+				"iss":    oidcServer.URL,
+				"aud":    "unit-test",
+				"exp":    time.Now().Add(time.Minute).Unix(),
+				"groups": []string{"developers", "telemetry-writers"},
+				"tenant": "acme",
+			})
+			token, err := oidcServer.token(payload)
+			require.NoError(t, err)
+
+			// test
+			ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			authData := client.FromContext(ctx).Auth
+			for name, value := range tt.expectedClaims {
+				assert.Equal(t, value, authData.GetAttribute(name))
+				assert.Contains(t, authData.GetAttributeNames(), name)
+			}
+			assert.Nil(t, authData.GetAttribute("sub"))
+		})
+	}
+}
+
 func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	// prepare the CA cert for the TLS handler
 	cert := x509.Certificate{
@@ -117,7 +294,7 @@ func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	oidcServer.StartTLS()
 
 	// prepare the processor configuration
-	config := &Config{
+	config := ProviderConfig{
 		IssuerURL:    oidcServer.URL,
 		IssuerCAPath: caFile.Name(),
 		Audience:     "unit-test",
@@ -192,7 +369,7 @@ func TestOIDCFailedToLoadIssuerCAFromPathInvalidContent(t *testing.T) {
 	_, err = file.Write([]byte("foobar"))
 	require.NoError(t, err)
 
-	config := &Config{
+	config := ProviderConfig{
 		IssuerCAPath: file.Name(),
 	}
 
@@ -446,6 +623,49 @@ func TestMissingIssuerURL(t *testing.T) {
 	assert.Equal(t, errNoIssuerURL, err)
 }
 
+func TestInvalidProviders(t *testing.T) {
+	for _, tt := range []struct {
+		casename      string
+		config        *Config
+		expectedError string
+	}{
+		{
+			"missing audience",
+			&Config{
+				Providers: []ProviderConfig{{IssuerURL: "http://example.com/"}},
+			},
+			"providers[0]: no Audience provided for the OIDC configuration",
+		},
+		{
+			"missing issuer",
+			&Config{
+				IssuerURL: "http://example.com/",
+				Audience:  "some-audience",
+				Providers: []ProviderConfig{{Audience: "some-audience"}},
+			},
+			"providers[0]: no IssuerURL provided for the OIDC configuration",
+		},
+		{
+			"duplicate issuer",
+			&Config{
+				IssuerURL: "http://example.com/",
+				Audience:  "some-audience",
+				Providers: []ProviderConfig{{IssuerURL: "http://example.com/", Audience: "other-audience"}},
+			},
+			"providers[0]: duplicate IssuerURL in the OIDC configuration",
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			// test
+			p, err := newExtension(tt.config, zap.NewNop())
+
+			// verify
+			assert.Nil(t, p)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestShutdown(t *testing.T) {
 	// prepare
 	config := &Config{
@@ -35,6 +35,7 @@ import (
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
@@ -42,6 +43,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/oauth2clientauthextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/ecstaskobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/hostobserver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
@@ -117,6 +119,17 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "apikeyauth",
+			getConfigFn: func() component.Config {
+				cfg := extFactories["apikeyauth"].CreateDefaultConfig().(*apikeyauthextension.Config)
+				// No need to clean up, t.TempDir will be deleted entirely.
+				cfg.KeysFile = filepath.Join(t.TempDir(), "keys.yaml")
+				keys := "keys:\n  - sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08\n    tenant: test\n"
+				require.NoError(t, os.WriteFile(cfg.KeysFile, []byte(keys), 0600))
+				return cfg
+			},
+		},
 		{
 			extension: "memory_ballast",
 			getConfigFn: func() component.Config {
@@ -150,6 +163,14 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "consul_observer",
+			getConfigFn: func() component.Config {
+				cfg := extFactories["consul_observer"].CreateDefaultConfig().(*consulobserver.Config)
+				cfg.Address = endpoint
+				return cfg
+			},
+		},
 		{
 			extension:     "awsproxy",
 			skipLifecycle: true, // Requires EC2 metadata service to be running
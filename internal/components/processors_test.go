@@ -34,6 +34,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor"
 )
@@ -66,6 +67,9 @@ func TestDefaultProcessors(t *testing.T) {
 			processor:     "datadog",
 			skipLifecycle: true, // requires external exporters to be configured to route data
 		},
+		{
+			processor: "deltatocumulative",
+		},
 		{
 			processor: "deltatorate",
 		},
@@ -100,6 +104,15 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "probabilistic_sampler",
 		},
+		{
+			processor: "ratelimit",
+			getConfigFn: func() component.Config {
+				cfg := procFactories["ratelimit"].CreateDefaultConfig().(*ratelimitprocessor.Config)
+				cfg.Key.FromAttribute = "tenant.id"
+				cfg.Traces = &ratelimitprocessor.Limit{Rate: 1000}
+				return cfg
+			},
+		},
 		{
 			processor: "resourcedetection",
 		},
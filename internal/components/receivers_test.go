@@ -148,6 +148,9 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "datadog",
 		},
+		{
+			receiver: "dnscheck",
+		},
 		{
 			receiver:     "docker_stats",
 			skipLifecyle: true,
@@ -239,6 +242,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "kubeletstats",
 			skipLifecyle: true, // Requires access to certificates to auth against kubelet
 		},
+		{
+			receiver: "loki",
+		},
 		{
 			receiver: "memcached",
 		},
@@ -256,6 +262,9 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "mysql",
 		},
+		{
+			receiver: "netcheck",
+		},
 		{
 			receiver: "nginx",
 		},
@@ -303,6 +312,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "prometheus_exec",
 			skipLifecyle: true, // Requires running a subproccess that can not be easily set across platforms
 		},
+		{
+			receiver: "prometheusremotewrite",
+		},
 		{
 			receiver:     "pulsar",
 			skipLifecyle: true, // TODO It requires a running pulsar instance to start successfully.
@@ -369,10 +381,12 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "sshcheck",
 		},
-
 		{
 			receiver: "statsd",
 		},
+		{
+			receiver: "tlscheck",
+		},
 		{
 			receiver:     "wavefront",
 			skipLifecyle: true, // Depends on carbon receiver to be running correctly
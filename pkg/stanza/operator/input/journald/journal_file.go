@@ -0,0 +1,431 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// The structures below follow the systemd journal file format, as described in
+// https://systemd.io/JOURNAL_FILE_FORMAT/. All fields are little endian and
+// every object is aligned to 8 bytes.
+
+const (
+	journalSignature = "LPKSHHRH"
+
+	// Offsets of the header fields that are needed to iterate over entries.
+	headerIncompatibleFlags = 12
+	headerFileID            = 24
+	headerSeqnumID          = 72
+	headerEntryArrayOffset  = 176
+	headerMinSize           = 208
+
+	incompatibleCompressedXZ   = 1 << 0
+	incompatibleCompressedLZ4  = 1 << 1
+	incompatibleKeyedHash      = 1 << 2
+	incompatibleCompressedZSTD = 1 << 3
+	incompatibleCompact        = 1 << 4
+	incompatibleSupported      = incompatibleCompressedXZ | incompatibleCompressedLZ4 |
+		incompatibleKeyedHash | incompatibleCompressedZSTD | incompatibleCompact
+
+	objectHeaderSize = 16
+
+	objectData       = 1
+	objectEntry      = 3
+	objectEntryArray = 6
+
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+
+	dataPayloadOffset        = objectHeaderSize + 48
+	dataCompactPayloadOffset = dataPayloadOffset + 8
+	entryItemsOffset         = objectHeaderSize + 48
+	entryArrayItemsOffset    = objectHeaderSize + 8
+
+	// maxObjectSize guards against allocating huge buffers for corrupted files.
+	maxObjectSize = 64 << 20
+)
+
+var errNotJournal = errors.New("not a journal file")
+
+// journalFile reads entries sequentially from a single journal file. It keeps
+// track of the next entry to read so that entries appended by journald after
+// the last read are picked up on the next call to next.
+type journalFile struct {
+	path     string
+	file     *os.File
+	info     os.FileInfo
+	fileID   string
+	seqnumID string
+	compact  bool
+
+	// arrayOffset and arrayIndex point to the entry array item holding the
+	// next entry to read.
+	arrayOffset uint64
+	arrayIndex  uint64
+
+	// head caches the header of the next entry.
+	head *journalEntry
+}
+
+// journalEntry is a single decoded journal entry.
+type journalEntry struct {
+	seqnumID  string
+	seqnum    uint64
+	realtime  uint64
+	monotonic uint64
+	bootID    string
+	xorHash   uint64
+	fields    map[string]interface{}
+}
+
+// cursor formats the entry position the same way journalctl does, so that
+// cursors can be used interchangeably between both reader modes.
+func (e *journalEntry) cursor() string {
+	return fmt.Sprintf("s=%s;i=%x;b=%s;m=%x;t=%x;x=%x", e.seqnumID, e.seqnum, e.bootID, e.monotonic, e.realtime, e.xorHash)
+}
+
+// before reports whether e was written before other. Like journalctl, it
+// compares sequence numbers when possible, then monotonic timestamps within
+// the same boot, and falls back to wall clock time.
+func (e *journalEntry) before(other *journalEntry) bool {
+	switch {
+	case e.seqnumID == other.seqnumID:
+		return e.seqnum < other.seqnum
+	case e.bootID == other.bootID:
+		return e.monotonic < other.monotonic
+	default:
+		return e.realtime < other.realtime
+	}
+}
+
+func openJournalFile(path string) (*journalFile, error) {
+	f, err := os.Open(path) // #nosec - the operator must read journal files from the configured locations
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerMinSize)
+	if _, err = f.ReadAt(header, 0); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("read header of %s: %w", path, err)
+	}
+	if string(header[:len(journalSignature)]) != journalSignature {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, errNotJournal)
+	}
+
+	flags := binary.LittleEndian.Uint32(header[headerIncompatibleFlags:])
+	if flags&^incompatibleSupported != 0 {
+		f.Close()
+		return nil, fmt.Errorf("%s: unsupported incompatible flags 0x%x", path, flags&^incompatibleSupported)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &journalFile{
+		path:     path,
+		file:     f,
+		info:     info,
+		fileID:   hex.EncodeToString(header[headerFileID : headerFileID+16]),
+		seqnumID: hex.EncodeToString(header[headerSeqnumID : headerSeqnumID+16]),
+		compact:  flags&incompatibleCompact != 0,
+	}, nil
+}
+
+func (j *journalFile) Close() error {
+	return j.file.Close()
+}
+
+// next returns the next entry of the file, or nil if all entries that are
+// currently linked into the file have been read.
+func (j *journalFile) next() (*journalEntry, error) {
+	offset, err := j.nextEntryOffset()
+	if err != nil || offset == 0 {
+		return nil, err
+	}
+	entry, err := j.readEntry(offset)
+	if err != nil {
+		return nil, err
+	}
+	j.arrayIndex++
+	j.head = nil
+	return entry, nil
+}
+
+// skip advances past the next entry without decoding its fields. It returns
+// false if there is no entry to skip.
+func (j *journalFile) skip() (bool, error) {
+	offset, err := j.nextEntryOffset()
+	if err != nil || offset == 0 {
+		return false, err
+	}
+	j.arrayIndex++
+	j.head = nil
+	return true, nil
+}
+
+// seek skips entries for as long as skip returns true for them.
+func (j *journalFile) seek(skip func(*journalEntry) bool) error {
+	for {
+		head, err := j.peekHeader()
+		if err != nil || head == nil || !skip(head) {
+			return err
+		}
+		if _, err := j.skip(); err != nil {
+			return err
+		}
+	}
+}
+
+// peekHeader returns the next entry with only the header fields populated.
+func (j *journalFile) peekHeader() (*journalEntry, error) {
+	if j.head != nil {
+		return j.head, nil
+	}
+	offset, err := j.nextEntryOffset()
+	if err != nil || offset == 0 {
+		return nil, err
+	}
+	obj, err := j.readObject(offset, objectEntry)
+	if err != nil {
+		return nil, err
+	}
+	j.head = j.parseEntryHeader(obj)
+	return j.head, nil
+}
+
+// nextEntryOffset walks the chain of entry arrays until it finds the offset of
+// the next unread entry. It returns 0 if there is none yet.
+func (j *journalFile) nextEntryOffset() (uint64, error) {
+	if j.arrayOffset == 0 {
+		header := make([]byte, 8)
+		if _, err := j.file.ReadAt(header, headerEntryArrayOffset); err != nil {
+			return 0, fmt.Errorf("read header of %s: %w", j.path, err)
+		}
+		j.arrayOffset = binary.LittleEndian.Uint64(header)
+		j.arrayIndex = 0
+		if j.arrayOffset == 0 {
+			return 0, nil
+		}
+	}
+
+	itemSize := uint64(8)
+	if j.compact {
+		itemSize = 4
+	}
+
+	for {
+		obj, err := j.readObject(j.arrayOffset, objectEntryArray)
+		if err != nil {
+			return 0, err
+		}
+		items := uint64(len(obj)-entryArrayItemsOffset) / itemSize
+		if j.arrayIndex < items {
+			item := obj[entryArrayItemsOffset+j.arrayIndex*itemSize:]
+			var offset uint64
+			if j.compact {
+				offset = uint64(binary.LittleEndian.Uint32(item))
+			} else {
+				offset = binary.LittleEndian.Uint64(item)
+			}
+			// Unused items at the end of the last array are zero.
+			return offset, nil
+		}
+
+		nextArray := binary.LittleEndian.Uint64(obj[objectHeaderSize:])
+		if nextArray == 0 {
+			return 0, nil
+		}
+		j.arrayOffset = nextArray
+		j.arrayIndex = 0
+	}
+}
+
+// readObject reads the complete object at offset and checks its type.
+func (j *journalFile) readObject(offset uint64, objectType uint8) ([]byte, error) {
+	if offset%8 != 0 {
+		return nil, fmt.Errorf("%s: misaligned object offset %d", j.path, offset)
+	}
+	header := make([]byte, objectHeaderSize)
+	if _, err := j.file.ReadAt(header, int64(offset)); err != nil {
+		return nil, fmt.Errorf("read object header of %s at %d: %w", j.path, offset, err)
+	}
+	if header[0] != objectType {
+		return nil, fmt.Errorf("%s: expected object type %d at %d, got %d", j.path, objectType, offset, header[0])
+	}
+	size := binary.LittleEndian.Uint64(header[8:])
+	if size < objectMinSize(objectType) || size > maxObjectSize {
+		return nil, fmt.Errorf("%s: invalid object size %d at %d", j.path, size, offset)
+	}
+
+	obj := make([]byte, size)
+	if _, err := j.file.ReadAt(obj, int64(offset)); err != nil {
+		return nil, fmt.Errorf("read object of %s at %d: %w", j.path, offset, err)
+	}
+	return obj, nil
+}
+
+// objectMinSize returns the size of the fixed fields of the object type, which
+// the object must hold before any of its items.
+func objectMinSize(objectType uint8) uint64 {
+	switch objectType {
+	case objectData:
+		return dataPayloadOffset
+	case objectEntry:
+		return entryItemsOffset
+	case objectEntryArray:
+		return entryArrayItemsOffset
+	default:
+		return objectHeaderSize
+	}
+}
+
+func (j *journalFile) parseEntryHeader(obj []byte) *journalEntry {
+	return &journalEntry{
+		seqnumID:  j.seqnumID,
+		seqnum:    binary.LittleEndian.Uint64(obj[objectHeaderSize:]),
+		realtime:  binary.LittleEndian.Uint64(obj[objectHeaderSize+8:]),
+		monotonic: binary.LittleEndian.Uint64(obj[objectHeaderSize+16:]),
+		bootID:    hex.EncodeToString(obj[objectHeaderSize+24 : objectHeaderSize+40]),
+		xorHash:   binary.LittleEndian.Uint64(obj[objectHeaderSize+40:]),
+	}
+}
+
+func (j *journalFile) readEntry(offset uint64) (*journalEntry, error) {
+	obj, err := j.readObject(offset, objectEntry)
+	if err != nil {
+		return nil, err
+	}
+	entry := j.parseEntryHeader(obj)
+	entry.fields = make(map[string]interface{})
+
+	itemSize := 16
+	if j.compact {
+		itemSize = 4
+	}
+	for item := obj[entryItemsOffset:]; len(item) >= itemSize; item = item[itemSize:] {
+		var dataOffset uint64
+		if j.compact {
+			dataOffset = uint64(binary.LittleEndian.Uint32(item))
+		} else {
+			dataOffset = binary.LittleEndian.Uint64(item)
+		}
+
+		payload, err := j.readData(dataOffset)
+		if err != nil {
+			return nil, err
+		}
+		name, value, found := bytes.Cut(payload, []byte{'='})
+		if !found {
+			return nil, fmt.Errorf("%s: invalid data object at %d", j.path, dataOffset)
+		}
+		addField(entry.fields, string(name), string(value))
+	}
+	return entry, nil
+}
+
+// addField adds a field to the entry body. Fields that occur multiple times
+// are collected into a list, like journalctl does in its JSON output.
+func addField(fields map[string]interface{}, name, value string) {
+	existing, ok := fields[name]
+	if !ok {
+		fields[name] = value
+		return
+	}
+	switch v := existing.(type) {
+	case []interface{}:
+		fields[name] = append(v, value)
+	default:
+		fields[name] = []interface{}{v, value}
+	}
+}
+
+func (j *journalFile) readData(offset uint64) ([]byte, error) {
+	obj, err := j.readObject(offset, objectData)
+	if err != nil {
+		return nil, err
+	}
+	payloadOffset := dataPayloadOffset
+	if j.compact {
+		payloadOffset = dataCompactPayloadOffset
+	}
+	if len(obj) < payloadOffset {
+		return nil, fmt.Errorf("%s: truncated data object at %d", j.path, offset)
+	}
+	payload := obj[payloadOffset:]
+
+	switch flags := obj[1]; {
+	case flags&objectCompressedZSTD != 0:
+		return decompressZSTD(payload)
+	case flags&objectCompressedLZ4 != 0:
+		return decompressLZ4(payload)
+	case flags&objectCompressedXZ != 0:
+		return nil, fmt.Errorf("%s: XZ compressed data objects are not supported", j.path)
+	default:
+		return payload, nil
+	}
+}
+
+var (
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+	zstdDecoderOnce sync.Once
+)
+
+func decompressZSTD(payload []byte) ([]byte, error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(maxObjectSize))
+	})
+	if zstdDecoderErr != nil {
+		return nil, zstdDecoderErr
+	}
+	return zstdDecoder.DecodeAll(payload, nil)
+}
+
+// decompressLZ4 decodes an LZ4 data object, which is a raw LZ4 block prefixed
+// with the uncompressed size.
+func decompressLZ4(payload []byte) ([]byte, error) {
+	if len(payload) < 8 {
+		return nil, errors.New("truncated LZ4 payload")
+	}
+	size := binary.LittleEndian.Uint64(payload)
+	if size > maxObjectSize {
+		return nil, fmt.Errorf("invalid LZ4 uncompressed size %d", size)
+	}
+	out := make([]byte, size)
+	n, err := lz4.UncompressBlock(payload[8:], out)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
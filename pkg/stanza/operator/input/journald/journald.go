@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"io"
 	"os/exec"
+	"regexp"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -36,7 +38,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 )
 
-const operatorType = "journald_input"
+const (
+	operatorType = "journald_input"
+
+	modeJournalctl = "journalctl"
+	modeNative     = "native"
+)
 
 func init() {
 	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
@@ -50,9 +57,11 @@ func NewConfig() *Config {
 // NewConfigWithID creates a new input config with default values
 func NewConfigWithID(operatorID string) *Config {
 	return &Config{
-		InputConfig: helper.NewInputConfig(operatorID, operatorType),
-		StartAt:     "end",
-		Priority:    "info",
+		InputConfig:  helper.NewInputConfig(operatorID, operatorType),
+		StartAt:      "end",
+		Priority:     "info",
+		Mode:         modeJournalctl,
+		PollInterval: 200 * time.Millisecond,
 	}
 }
 
@@ -65,6 +74,40 @@ type Config struct {
 	StartAt   string   `mapstructure:"start_at,omitempty"`
 	Units     []string `mapstructure:"units,omitempty"`
 	Priority  string   `mapstructure:"priority,omitempty"`
+
+	// Matches restricts the entries to those matching any of the match
+	// groups. An entry matches a group if it matches all of its fields.
+	Matches []MatchConfig `mapstructure:"matches,omitempty"`
+	// Grep restricts the entries to those with a MESSAGE matching the
+	// regular expression.
+	Grep string `mapstructure:"grep,omitempty"`
+	// NormalizeFields renames well-known journal fields to the OpenTelemetry
+	// semantic conventions, e.g. _PID to process.pid.
+	NormalizeFields bool `mapstructure:"normalize_fields,omitempty"`
+
+	// Mode selects how the journal is read. "journalctl" runs the journalctl
+	// binary, "native" reads the journal files directly.
+	Mode         string        `mapstructure:"mode,omitempty"`
+	PollInterval time.Duration `mapstructure:"poll_interval,omitempty"`
+}
+
+// MatchConfig is a group of journal field values that must all match
+type MatchConfig map[string]string
+
+var fieldNameRegex = regexp.MustCompile(`^[A-Z0-9_]+$`)
+
+func (c Config) validateMatches() error {
+	for _, match := range c.Matches {
+		if len(match) == 0 {
+			return errors.New("'matches' must not contain empty groups")
+		}
+		for field := range match {
+			if !fieldNameRegex.MatchString(field) {
+				return fmt.Errorf("invalid journal field name '%s' in 'matches'", field)
+			}
+		}
+	}
+	return nil
 }
 
 // Build will build a journald input operator from the supplied configuration
@@ -74,6 +117,18 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	if err = c.validateMatches(); err != nil {
+		return nil, err
+	}
+
+	switch c.Mode {
+	case modeJournalctl:
+	case modeNative:
+		return c.buildNative(inputOperator)
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'mode'", c.Mode)
+	}
+
 	args := make([]string, 0, 10)
 
 	// Export logs in UTC time
@@ -99,6 +154,10 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 
 	args = append(args, "--priority", c.Priority)
 
+	if c.Grep != "" {
+		args = append(args, "--grep", c.Grep)
+	}
+
 	switch {
 	case c.Directory != nil:
 		args = append(args, "--directory", *c.Directory)
@@ -108,6 +167,21 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		}
 	}
 
+	// Matches are passed as positional arguments, with "+" separating the groups
+	for i, match := range c.Matches {
+		if i > 0 {
+			args = append(args, "+")
+		}
+		fields := make([]string, 0, len(match))
+		for field := range match {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			args = append(args, field+"="+match[field])
+		}
+	}
+
 	return &Input{
 		InputOperator: inputOperator,
 		newCmd: func(ctx context.Context, cursor []byte) cmd {
@@ -117,7 +191,8 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 			return exec.CommandContext(ctx, "journalctl", args...) // #nosec - ...
 			// journalctl is an executable that is required for this operator to function
 		},
-		json: jsoniter.ConfigFastest,
+		json:      jsoniter.ConfigFastest,
+		normalize: c.NormalizeFields,
 	}, nil
 }
 
@@ -129,6 +204,7 @@ type Input struct {
 
 	persister operator.Persister
 	json      jsoniter.API
+	normalize bool
 	cancel    context.CancelFunc
 	wg        sync.WaitGroup
 }
@@ -229,6 +305,10 @@ func (operator *Input) parseJournalEntry(line []byte) (*entry.Entry, string, err
 		return nil, "", errors.New("journald field for cursor is not a string")
 	}
 
+	if operator.normalize {
+		normalizeFields(body)
+	}
+
 	entry, err := operator.NewEntry(body)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create entry: %w", err)
@@ -244,3 +324,35 @@ func (operator *Input) Stop() error {
 	operator.wg.Wait()
 	return nil
 }
+
+// normalizedFields maps journal fields to the OpenTelemetry semantic conventions
+var normalizedFields = map[string]string{
+	"_PID":           "process.pid",
+	"_PPID":          "process.parent_pid",
+	"_COMM":          "process.executable.name",
+	"_EXE":           "process.executable.path",
+	"_CMDLINE":       "process.command_line",
+	"_HOSTNAME":      "host.name",
+	"_MACHINE_ID":    "host.id",
+	"CONTAINER_ID":   "container.id",
+	"CONTAINER_NAME": "container.name",
+	"IMAGE_NAME":     "container.image.name",
+}
+
+// normalizeFields renames the well-known fields of a journal entry body.
+// Process IDs are converted to integers, like the conventions require.
+func normalizeFields(body map[string]interface{}) {
+	for field, name := range normalizedFields {
+		value, ok := body[field]
+		if !ok {
+			continue
+		}
+		delete(body, field)
+		if s, ok := value.(string); ok && (field == "_PID" || field == "_PPID") {
+			if pid, err := strconv.ParseInt(s, 10, 64); err == nil {
+				value = pid
+			}
+		}
+		body[name] = value
+	}
+}
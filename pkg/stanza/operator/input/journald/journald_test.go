@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"os/exec"
 	"testing"
 	"time"
 
@@ -115,3 +116,43 @@ func TestInputJournald(t *testing.T) {
 		require.FailNow(t, "Timed out waiting for entry to be read")
 	}
 }
+
+func TestBuildJournalctlArgs(t *testing.T) {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.Units = []string{"ssh"}
+	cfg.Grep = "session opened"
+	cfg.Matches = []MatchConfig{
+		{"_SYSTEMD_SLICE": "system.slice", "_TRANSPORT": "stdout"},
+		{"CONTAINER_NAME": "web"},
+	}
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	journal := op.(*Input).newCmd(context.Background(), []byte("s=1;i=2"))
+	require.Equal(t, []string{
+		"journalctl", "--utc", "--output=json", "--follow", "--unit", "ssh", "--priority", "info",
+		"--grep", "session opened",
+		"_SYSTEMD_SLICE=system.slice", "_TRANSPORT=stdout", "+", "CONTAINER_NAME=web",
+		"--after-cursor", "s=1;i=2",
+	}, journal.(*exec.Cmd).Args)
+}
+
+func TestInputJournaldNormalizeFields(t *testing.T) {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.NormalizeFields = true
+
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+
+	line := []byte(`{"_PID":"13894","_HOSTNAME":"myhostname","_COMM":"systemd","MESSAGE":"Started.","__REALTIME_TIMESTAMP":"1587047866229555","__CURSOR":"s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30"}`)
+	e, _, err := op.(*Input).parseJournalEntry(line)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"process.pid":             int64(13894),
+		"host.name":               "myhostname",
+		"process.executable.name": "systemd",
+		"MESSAGE":                 "Started.",
+		"__CURSOR":                "s=b1e713b587ae4001a9ca482c4b12c005;i=1eed30",
+	}, e.Body)
+}
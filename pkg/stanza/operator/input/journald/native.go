@@ -0,0 +1,515 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// defaultDirectories are the locations journalctl reads from by default.
+var defaultDirectories = []string{"/run/log/journal", "/var/log/journal"}
+
+var priorityNames = map[string]int{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+var unitSuffixes = map[string]bool{
+	".service":   true,
+	".socket":    true,
+	".target":    true,
+	".device":    true,
+	".mount":     true,
+	".automount": true,
+	".swap":      true,
+	".timer":     true,
+	".path":      true,
+	".slice":     true,
+	".scope":     true,
+}
+
+func (c Config) buildNative(inputOperator helper.InputOperator) (operator.Operator, error) {
+	var startAtBeginning bool
+	switch c.StartAt {
+	case "end":
+	case "beginning":
+		startAtBeginning = true
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+	}
+
+	minPriority, maxPriority, err := parsePriority(c.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.PollInterval <= 0 {
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'poll_interval'", c.PollInterval)
+	}
+
+	units := make([]string, 0, len(c.Units))
+	for _, unit := range c.Units {
+		units = append(units, mangleUnit(unit))
+	}
+
+	var grep *regexp.Regexp
+	if c.Grep != "" {
+		// Like journalctl, patterns without uppercase characters are case insensitive
+		pattern := c.Grep
+		if strings.ToLower(pattern) == pattern {
+			pattern = "(?i)" + pattern
+		}
+		if grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'grep': %w", c.Grep, err)
+		}
+	}
+
+	input := &NativeInput{
+		InputOperator:    inputOperator,
+		files:            c.Files,
+		startAtBeginning: startAtBeginning,
+		pollInterval:     c.PollInterval,
+		units:            units,
+		minPriority:      minPriority,
+		maxPriority:      maxPriority,
+		matches:          c.Matches,
+		grep:             grep,
+		normalize:        c.NormalizeFields,
+	}
+	switch {
+	case c.Directory != nil:
+		input.directories = []string{*c.Directory}
+	case len(c.Files) == 0:
+		input.directories = defaultDirectories
+	}
+	return input, nil
+}
+
+// parsePriority parses a priority the way journalctl does: either a single
+// level, which matches that level and all more important ones, or a range of
+// two levels separated by "..".
+func parsePriority(value string) (int, int, error) {
+	parseLevel := func(level string) (int, error) {
+		if p, ok := priorityNames[level]; ok {
+			return p, nil
+		}
+		if p, err := strconv.Atoi(level); err == nil && p >= 0 && p <= 7 {
+			return p, nil
+		}
+		return 0, fmt.Errorf("invalid value '%s' for parameter 'priority'", value)
+	}
+
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		p, err := parseLevel(value)
+		return 0, p, err
+	}
+
+	minPriority, err := parseLevel(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxPriority, err := parseLevel(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if minPriority > maxPriority {
+		minPriority, maxPriority = maxPriority, minPriority
+	}
+	return minPriority, maxPriority, nil
+}
+
+// mangleUnit appends the ".service" suffix to unit names without a unit
+// type, like journalctl does for the --unit flag.
+func mangleUnit(unit string) string {
+	if strings.ContainsAny(unit, "*?[") || unitSuffixes[path.Ext(unit)] {
+		return unit
+	}
+	return unit + ".service"
+}
+
+// NativeInput is an operator that reads journal files directly, without
+// relying on the journalctl binary.
+type NativeInput struct {
+	helper.InputOperator
+
+	directories      []string
+	files            []string
+	startAtBeginning bool
+	pollInterval     time.Duration
+	units            []string
+	minPriority      int
+	maxPriority      int
+	matches          []MatchConfig
+	grep             *regexp.Regexp
+	normalize        bool
+
+	persister operator.Persister
+	journals  []*journalFile
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// Start will start reading the journal files.
+func (operator *NativeInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	operator.cancel = cancel
+
+	cursor, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journal state: %w", err)
+	}
+
+	operator.persister = persister
+
+	// Files that exist at startup are positioned after the saved cursor, or
+	// at their end if configured to. Files found later are read completely.
+	var seek func(*journalEntry) bool
+	switch {
+	case cursor != nil:
+		last, err := parseCursor(string(cursor))
+		if err != nil {
+			return fmt.Errorf("failed to parse journal cursor: %w", err)
+		}
+		seek = func(e *journalEntry) bool { return !last.before(e) }
+	case !operator.startAtBeginning:
+		seek = func(*journalEntry) bool { return true }
+	}
+	operator.discover(seek)
+
+	operator.wg.Add(1)
+	go func() {
+		defer operator.wg.Done()
+
+		ticker := time.NewTicker(operator.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			operator.readEntries(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				operator.discover(nil)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop will stop reading the journal files.
+func (operator *NativeInput) Stop() error {
+	if operator.cancel != nil {
+		operator.cancel()
+	}
+	operator.wg.Wait()
+	for _, journal := range operator.journals {
+		if err := journal.Close(); err != nil {
+			operator.Warnw("Failed to close journal file", zap.String("path", journal.path), zap.Error(err))
+		}
+	}
+	operator.journals = nil
+	return nil
+}
+
+func (operator *NativeInput) journalPaths() []string {
+	patterns := operator.files
+	for _, dir := range operator.directories {
+		// Journal files are usually placed in a subdirectory named after the machine ID.
+		patterns = append(patterns, filepath.Join(dir, "*.journal"), filepath.Join(dir, "*", "*.journal"))
+	}
+
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			operator.Warnw("Invalid journal file pattern", zap.String("pattern", pattern), zap.Error(err))
+			continue
+		}
+		paths = append(paths, matches...)
+	}
+	return paths
+}
+
+// discover opens journal files that appeared since the last call and closes
+// the ones that were removed. Files are matched by identity rather than by
+// path, so that a file being renamed on rotation keeps its position.
+func (operator *NativeInput) discover(seek func(*journalEntry) bool) {
+	found := make([]*journalFile, 0, len(operator.journals))
+	seen := make(map[*journalFile]bool, len(operator.journals))
+
+	for _, p := range operator.journalPaths() {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+
+		var journal *journalFile
+		for _, j := range operator.journals {
+			if os.SameFile(info, j.info) {
+				journal = j
+				break
+			}
+		}
+
+		if journal == nil {
+			journal, err = openJournalFile(p)
+			if err != nil {
+				operator.Debugw("Failed to open journal file", zap.String("path", p), zap.Error(err))
+				continue
+			}
+			if seek != nil {
+				if err := journal.seek(seek); err != nil {
+					operator.Warnw("Failed to seek in journal file", zap.String("path", p), zap.Error(err))
+				}
+			}
+		}
+
+		if seen[journal] {
+			continue
+		}
+		journal.path = p
+		seen[journal] = true
+		found = append(found, journal)
+	}
+
+	for _, journal := range operator.journals {
+		if !seen[journal] {
+			if err := journal.Close(); err != nil {
+				operator.Warnw("Failed to close journal file", zap.String("path", journal.path), zap.Error(err))
+			}
+		}
+	}
+	operator.journals = found
+}
+
+// readEntries emits all entries that are available in the journal files,
+// interleaving the files in the same order journalctl would.
+func (operator *NativeInput) readEntries(ctx context.Context) {
+	for ctx.Err() == nil {
+		var next *journalFile
+		var nextHead *journalEntry
+		for _, journal := range operator.journals {
+			head, err := journal.peekHeader()
+			if err != nil {
+				operator.Warnw("Failed to read journal file", zap.String("path", journal.path), zap.Error(err))
+				continue
+			}
+			if head != nil && (nextHead == nil || head.before(nextHead)) {
+				next, nextHead = journal, head
+			}
+		}
+		if next == nil {
+			return
+		}
+
+		journalEntry, err := next.next()
+		if err != nil {
+			operator.Warnw("Failed to read journal entry", zap.String("path", next.path), zap.Error(err))
+			if _, err := next.skip(); err != nil {
+				return
+			}
+			continue
+		}
+		if !operator.include(journalEntry.fields) {
+			continue
+		}
+
+		cursor := journalEntry.cursor()
+		entry, err := operator.newEntry(journalEntry, cursor)
+		if err != nil {
+			operator.Warnw("Failed to create entry", zap.Error(err))
+			continue
+		}
+		if err := operator.persister.Set(ctx, lastReadCursorKey, []byte(cursor)); err != nil {
+			operator.Warnw("Failed to set offset", zap.Error(err))
+		}
+		operator.Write(ctx, entry)
+	}
+}
+
+func (operator *NativeInput) newEntry(journalEntry *journalEntry, cursor string) (*entry.Entry, error) {
+	body := journalEntry.fields
+	body["__CURSOR"] = cursor
+	body["__MONOTONIC_TIMESTAMP"] = strconv.FormatUint(journalEntry.monotonic, 10)
+	body["_BOOT_ID"] = journalEntry.bootID
+	if operator.normalize {
+		normalizeFields(body)
+	}
+
+	entry, err := operator.NewEntry(body)
+	if err != nil {
+		return nil, err
+	}
+	entry.Timestamp = time.UnixMicro(int64(journalEntry.realtime))
+	return entry, nil
+}
+
+// include applies the priority, unit, field and message filters, mirroring
+// the matches that journalctl adds for its flags.
+func (operator *NativeInput) include(fields map[string]interface{}) bool {
+	if priority, ok := fields["PRIORITY"].(string); ok {
+		p, err := strconv.Atoi(priority)
+		if err != nil || p < operator.minPriority || p > operator.maxPriority {
+			return false
+		}
+	} else if operator.maxPriority < 7 || operator.minPriority > 0 {
+		return false
+	}
+
+	if len(operator.units) > 0 && !operator.matchesUnit(fields) {
+		return false
+	}
+
+	if len(operator.matches) > 0 && !operator.matchesGroup(fields) {
+		return false
+	}
+
+	if operator.grep != nil {
+		message, ok := fields["MESSAGE"].(string)
+		if !ok || !operator.grep.MatchString(message) {
+			return false
+		}
+	}
+	return true
+}
+
+func (operator *NativeInput) matchesUnit(fields map[string]interface{}) bool {
+	for _, unit := range operator.units {
+		if fieldMatches(fields, "_SYSTEMD_UNIT", unit) {
+			return true
+		}
+		// Messages about the unit from systemd itself
+		if fieldMatches(fields, "_PID", "1") && fieldMatches(fields, "UNIT", unit) {
+			return true
+		}
+		// Messages from privileged processes, like systemd-coredump
+		if fieldMatches(fields, "_UID", "0") &&
+			(fieldMatches(fields, "COREDUMP_UNIT", unit) || fieldMatches(fields, "OBJECT_SYSTEMD_UNIT", unit)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesGroup reports whether the fields match all values of any group.
+// Unlike units, match values are compared literally, like journalctl does.
+func (operator *NativeInput) matchesGroup(fields map[string]interface{}) bool {
+	for _, match := range operator.matches {
+		matched := true
+		for field, expected := range match {
+			if !fieldEquals(fields[field], expected) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldEquals compares a field value, which is a list for fields that occur
+// multiple times in an entry.
+func fieldEquals(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == expected
+	case []interface{}:
+		for _, item := range v {
+			if item == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fieldMatches(fields map[string]interface{}, name, pattern string) bool {
+	value, ok := fields[name].(string)
+	if !ok {
+		return false
+	}
+	if value == pattern {
+		return true
+	}
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+// parseCursor decodes a journalctl cursor into an entry holding only the
+// position fields.
+func parseCursor(value string) (*journalEntry, error) {
+	c := &journalEntry{}
+	var hasSeqnum, hasMonotonic, hasRealtime bool
+	for _, part := range strings.Split(value, ";") {
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid cursor %q", value)
+		}
+		var err error
+		switch key {
+		case "s":
+			c.seqnumID = val
+		case "i":
+			c.seqnum, err = strconv.ParseUint(val, 16, 64)
+			hasSeqnum = true
+		case "b":
+			c.bootID = val
+		case "m":
+			c.monotonic, err = strconv.ParseUint(val, 16, 64)
+			hasMonotonic = true
+		case "t":
+			c.realtime, err = strconv.ParseUint(val, 16, 64)
+			hasRealtime = true
+		case "x":
+			c.xorHash, err = strconv.ParseUint(val, 16, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %w", value, err)
+		}
+	}
+	if !hasSeqnum || !hasMonotonic || !hasRealtime || c.seqnumID == "" || c.bootID == "" {
+		return nil, errors.New("cursor is missing required fields")
+	}
+	return c, nil
+}
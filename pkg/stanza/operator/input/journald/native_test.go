@@ -0,0 +1,410 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+// The journal files in testdata were written by systemd-journald 252, the
+// compact one with zstd compressed fields. The matching JSON files hold the
+// output of "journalctl --file <file> --output=json" for them.
+
+func newNativeConfig(files ...string) *Config {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Mode = modeNative
+	cfg.StartAt = "beginning"
+	cfg.Priority = "debug"
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.Files = files
+	return cfg
+}
+
+func startNative(t *testing.T, cfg *Config, persister operator.Persister) *testutil.FakeOutput {
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &NativeInput{}, op)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(persister))
+	t.Cleanup(func() {
+		require.NoError(t, op.Stop())
+	})
+	return fake
+}
+
+func receive(t *testing.T, fake *testutil.FakeOutput, count int) []*entry.Entry {
+	entries := make([]*entry.Entry, 0, count)
+	for len(entries) < count {
+		select {
+		case e := <-fake.Received:
+			entries = append(entries, e)
+		case <-time.After(2 * time.Second):
+			require.FailNowf(t, "Timed out waiting for entries", "received %d of %d", len(entries), count)
+		}
+	}
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+	return entries
+}
+
+func loadJournalctlOutput(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var bodies []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &body))
+		bodies = append(bodies, body)
+	}
+	require.NoError(t, scanner.Err())
+	return bodies
+}
+
+func messages(entries []*entry.Entry) []string {
+	result := make([]string, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Body.(map[string]interface{})["MESSAGE"].(string))
+	}
+	return result
+}
+
+func TestNativeInputMatchesJournalctl(t *testing.T) {
+	for _, name := range []string{"compact", "regular"} {
+		t.Run(name, func(t *testing.T) {
+			expected := loadJournalctlOutput(t, filepath.Join("testdata", name+".json"))
+
+			fake := startNative(t, newNativeConfig(filepath.Join("testdata", name+".journal")), testutil.NewMockPersister("test"))
+			entries := receive(t, fake, len(expected))
+
+			for i, e := range entries {
+				timestamp, err := strconv.ParseInt(expected[i]["__REALTIME_TIMESTAMP"].(string), 10, 64)
+				require.NoError(t, err)
+				delete(expected[i], "__REALTIME_TIMESTAMP")
+
+				require.Equal(t, expected[i], e.Body)
+				require.Equal(t, time.UnixMicro(timestamp), e.Timestamp)
+			}
+		})
+	}
+}
+
+func TestNativeInputFilters(t *testing.T) {
+	testCases := []struct {
+		name     string
+		units    []string
+		priority string
+		matches  []MatchConfig
+		grep     string
+		expected []string
+	}{
+		{
+			name:     "unit",
+			units:    []string{"ssh"},
+			priority: "info",
+			expected: []string{
+				"Server listening on 0.0.0.0 port 22.",
+				"error: kex_exchange_identification: Connection closed by remote host",
+				"Accepted publickey for root from 10.0.0.1 port 50000 ssh2",
+			},
+		},
+		{
+			name:     "unit_with_suffix",
+			units:    []string{"kubelet.service"},
+			priority: "warning",
+			expected: []string{strings.Repeat("k", 2000)},
+		},
+		{
+			name:     "unit_pattern",
+			units:    []string{"kube*"},
+			priority: "info",
+			expected: []string{"Started kubelet", strings.Repeat("k", 2000)},
+		},
+		{
+			name:     "priority",
+			priority: "err",
+			expected: []string{"error: kex_exchange_identification: Connection closed by remote host"},
+		},
+		{
+			name:     "priority_range",
+			priority: "warning..notice",
+			expected: []string{"notice message without a unit", strings.Repeat("k", 2000)},
+		},
+		{
+			name:     "numeric_priority_range",
+			priority: "7..7",
+			expected: []string{"debug message without a unit"},
+		},
+		{
+			name:     "match_groups",
+			priority: "debug",
+			matches: []MatchConfig{
+				{"_SYSTEMD_UNIT": "kubelet.service"},
+				{"SYSLOG_IDENTIFIER": "sample"},
+			},
+			expected: []string{"debug message without a unit", "Started kubelet", "notice message without a unit", strings.Repeat("k", 2000)},
+		},
+		{
+			name:     "match_all_fields",
+			priority: "debug",
+			matches: []MatchConfig{
+				{"_SYSTEMD_UNIT": "ssh.service", "PRIORITY": "3"},
+			},
+			expected: []string{"error: kex_exchange_identification: Connection closed by remote host"},
+		},
+		{
+			name:     "match_and_unit",
+			units:    []string{"ssh"},
+			priority: "debug",
+			matches: []MatchConfig{
+				{"SYSLOG_IDENTIFIER": "sample"},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "grep_case_insensitive",
+			priority: "debug",
+			grep:     "server listening",
+			expected: []string{"Server listening on 0.0.0.0 port 22."},
+		},
+		{
+			name:     "grep_case_sensitive",
+			priority: "debug",
+			grep:     "SERVER listening",
+			expected: []string{},
+		},
+		{
+			name:     "grep_and_match",
+			priority: "debug",
+			matches: []MatchConfig{
+				{"_SYSTEMD_UNIT": "ssh.service"},
+			},
+			grep:     "^(error|accepted)",
+			expected: []string{"error: kex_exchange_identification: Connection closed by remote host", "Accepted publickey for root from 10.0.0.1 port 50000 ssh2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newNativeConfig(filepath.Join("testdata", "compact.journal"))
+			cfg.Units = tc.units
+			cfg.Priority = tc.priority
+			cfg.Matches = tc.matches
+			cfg.Grep = tc.grep
+
+			fake := startNative(t, cfg, testutil.NewMockPersister("test"))
+			require.Equal(t, tc.expected, messages(receive(t, fake, len(tc.expected))))
+		})
+	}
+}
+
+func TestNativeInputNormalizeFields(t *testing.T) {
+	cfg := newNativeConfig(filepath.Join("testdata", "compact.journal"))
+	cfg.Units = []string{"kubelet"}
+	cfg.Grep = "started"
+	cfg.NormalizeFields = true
+
+	fake := startNative(t, cfg, testutil.NewMockPersister("test"))
+	body := receive(t, fake, 1)[0].Body.(map[string]interface{})
+
+	require.Equal(t, int64(22879), body["process.pid"])
+	require.Equal(t, "vm", body["host.name"])
+	require.Equal(t, "fed6b2924c424cf1b9a322f606b4de6d", body["host.id"])
+	require.Equal(t, "logger", body["process.executable.name"])
+	require.Equal(t, "/usr/bin/logger", body["process.executable.path"])
+	require.Equal(t, "logger --journald", body["process.command_line"])
+	require.Equal(t, "kubelet.service", body["_SYSTEMD_UNIT"])
+	require.NotContains(t, body, "_PID")
+	require.NotContains(t, body, "_HOSTNAME")
+}
+
+func TestNativeInputDirectory(t *testing.T) {
+	cfg := newNativeConfig()
+	dir := "testdata"
+	cfg.Directory = &dir
+
+	fake := startNative(t, cfg, testutil.NewMockPersister("test"))
+	entries := receive(t, fake, 22)
+
+	// Both files were written during the same boot, so they are interleaved
+	// by monotonic time, with all of compact.journal written first.
+	for i, e := range entries {
+		cursor := e.Body.(map[string]interface{})["__CURSOR"].(string)
+		if i < 11 {
+			require.Contains(t, cursor, "s=a192fd7c403b4175a47164a9f9b73c11;")
+		} else {
+			require.Contains(t, cursor, "s=182e779812044036a3530301816b3575;")
+		}
+	}
+}
+
+func TestNativeInputCursor(t *testing.T) {
+	persister := testutil.NewMockPersister("test")
+	cursor := "s=a192fd7c403b4175a47164a9f9b73c11;i=8;b=3e722c10c56642069fd53728ad561db2;m=148e1265a;t=65e2c24e0ac9b;x=3d754ad215d49398"
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey, []byte(cursor)))
+
+	cfg := newNativeConfig(filepath.Join("testdata", "compact.journal"))
+	cfg.StartAt = "end"
+	fake := startNative(t, cfg, persister)
+
+	entries := receive(t, fake, 3)
+	require.Equal(t, []string{strings.Repeat("k", 2000), "Accepted publickey for root from 10.0.0.1 port 50000 ssh2", "Journal stopped"}, messages(entries))
+
+	saved, err := persister.Get(context.Background(), lastReadCursorKey)
+	require.NoError(t, err)
+	require.Equal(t, entries[2].Body.(map[string]interface{})["__CURSOR"], string(saved))
+}
+
+func TestNativeInputStartAtEnd(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, filepath.Join("testdata", "compact.journal"), filepath.Join(dir, "system.journal"))
+
+	cfg := newNativeConfig()
+	cfg.StartAt = "end"
+	cfg.Directory = &dir
+	fake := startNative(t, cfg, testutil.NewMockPersister("test"))
+	fake.ExpectNoEntry(t, 100*time.Millisecond)
+
+	// Files that show up later, e.g. after rotation, are read from the start.
+	copyFile(t, filepath.Join("testdata", "regular.journal"), filepath.Join(dir, "user-1000.journal"))
+	entries := receive(t, fake, 11)
+	require.Contains(t, entries[0].Body.(map[string]interface{})["__CURSOR"], "s=182e779812044036a3530301816b3575;i=1;")
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0600))
+}
+
+func TestJournalFileCorrupted(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "regular.journal"))
+	require.NoError(t, err)
+	arrayOffset := binary.LittleEndian.Uint64(data[headerEntryArrayOffset:])
+	entryOffset := binary.LittleEndian.Uint64(data[arrayOffset+entryArrayItemsOffset:])
+
+	testCases := []struct {
+		name    string
+		corrupt func([]byte) []byte
+	}{
+		{
+			name: "truncated_entry",
+			corrupt: func(b []byte) []byte {
+				binary.LittleEndian.PutUint64(b[entryOffset+8:], objectHeaderSize+8)
+				return b
+			},
+		},
+		{
+			name: "truncated_entry_array",
+			corrupt: func(b []byte) []byte {
+				binary.LittleEndian.PutUint64(b[arrayOffset+8:], objectHeaderSize)
+				return b
+			},
+		},
+		{
+			name: "truncated_file",
+			corrupt: func(b []byte) []byte {
+				return b[:entryOffset+objectHeaderSize+8]
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "system.journal")
+			require.NoError(t, os.WriteFile(path, tc.corrupt(append([]byte(nil), data...)), 0600))
+
+			j, err := openJournalFile(path)
+			require.NoError(t, err)
+			defer j.Close()
+
+			_, err = j.peekHeader()
+			require.Error(t, err)
+			_, err = j.next()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNativeConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{
+			name:   "invalid_mode",
+			modify: func(c *Config) { c.Mode = "other" },
+		},
+		{
+			name:   "invalid_start_at",
+			modify: func(c *Config) { c.StartAt = "middle" },
+		},
+		{
+			name:   "invalid_priority",
+			modify: func(c *Config) { c.Priority = "verbose" },
+		},
+		{
+			name:   "invalid_priority_range",
+			modify: func(c *Config) { c.Priority = "err..8" },
+		},
+		{
+			name:   "invalid_grep",
+			modify: func(c *Config) { c.Grep = "(unclosed" },
+		},
+		{
+			name:   "invalid_match_field",
+			modify: func(c *Config) { c.Matches = []MatchConfig{{"_systemd_unit": "ssh.service"}} },
+		},
+		{
+			name:   "empty_match_group",
+			modify: func(c *Config) { c.Matches = []MatchConfig{{}} },
+		},
+		{
+			name:   "invalid_poll_interval",
+			modify: func(c *Config) { c.PollInterval = 0 },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newNativeConfig()
+			tc.modify(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			require.Error(t, err)
+		})
+	}
+}
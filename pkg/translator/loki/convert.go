@@ -16,6 +16,8 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -82,6 +84,113 @@ func convertAttributesAndMerge(logAttrs pcommon.Map, resAttrs pcommon.Map) model
 	return out
 }
 
+// LabelRule promotes the value of an attribute to a stream label.
+type LabelRule struct {
+	// Label is the name of the stream label.
+	Label model.LabelName
+
+	// FromAttribute is the log record attribute holding the value.
+	FromAttribute string
+
+	// FromResourceAttribute is the resource attribute holding the value, used
+	// when FromAttribute is empty.
+	FromResourceAttribute string
+
+	// Pattern optionally extracts the value from the attribute: the label is set
+	// to the first capture group, or to the whole match if there is no group. The
+	// label isn't set when the attribute doesn't match.
+	Pattern *regexp.Regexp
+}
+
+type promotedAttribute struct {
+	attrs pcommon.Map
+	name  string
+}
+
+// applyLabelRules returns the labels set by the rules, the first rule with a value
+// winning for each label. Attributes promoted without a pattern are removed, as
+// their value is part of the stream labels.
+func applyLabelRules(rules []LabelRule, logAttrs pcommon.Map, resAttrs pcommon.Map) model.LabelSet {
+	out := model.LabelSet{}
+	var promoted []promotedAttribute
+
+	for _, rule := range rules {
+		if _, exists := out[rule.Label]; exists {
+			continue
+		}
+
+		attrs, name := logAttrs, rule.FromAttribute
+		if name == "" {
+			attrs, name = resAttrs, rule.FromResourceAttribute
+		}
+		av, ok := attrs.Get(name)
+		if !ok {
+			continue
+		}
+
+		value := av.AsString()
+		if rule.Pattern != nil {
+			match := rule.Pattern.FindStringSubmatch(value)
+			if match == nil {
+				continue
+			}
+			value = match[0]
+			if len(match) > 1 {
+				value = match[1]
+			}
+		}
+		if value == "" {
+			continue
+		}
+
+		out[rule.Label] = model.LabelValue(value)
+		if rule.Pattern == nil {
+			promoted = append(promoted, promotedAttribute{attrs: attrs, name: name})
+		}
+	}
+
+	for _, p := range promoted {
+		p.attrs.Remove(p.name)
+	}
+	return out
+}
+
+// convertAttributesToStructuredMetadata returns the resource and log record
+// attributes as structured metadata sorted by name, the log record attributes
+// taking precedence. Names are sanitized like label names so that they can be
+// used in queries.
+func convertAttributesToStructuredMetadata(logAttrs pcommon.Map, resAttrs pcommon.Map) push.LabelsAdapter {
+	values := make(map[string]string, logAttrs.Len()+resAttrs.Len())
+	for _, attrs := range []pcommon.Map{resAttrs, logAttrs} {
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			values[sanitizeName(k)] = v.AsString()
+			return true
+		})
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make(push.LabelsAdapter, 0, len(names))
+	for _, name := range names {
+		out = append(out, push.LabelAdapter{Name: name, Value: values[name]})
+	}
+	return out
+}
+
+// sanitizeName replaces the characters that aren't valid in label names with `_`.
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
 func convertAttributesToLabels(attributes pcommon.Map, attrsToSelect pcommon.Value) model.LabelSet {
 	out := model.LabelSet{}
 
@@ -15,8 +15,10 @@
 package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/grafana/loki/pkg/push"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -269,6 +271,103 @@ func TestRemoveAttributes(t *testing.T) {
 	}
 }
 
+func TestApplyLabelRules(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		rules            []LabelRule
+		expected         model.LabelSet
+		expectedAttrs    map[string]interface{}
+		expectedResAttrs map[string]interface{}
+	}{
+		{
+			desc: "promote attributes",
+			rules: []LabelRule{
+				{Label: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+				{Label: "status", FromAttribute: "http.status"},
+			},
+			expected: model.LabelSet{"namespace": "prod", "status": "200"},
+			expectedAttrs: map[string]interface{}{
+				"http.url": "https://example.com/api/users?id=1",
+			},
+			expectedResAttrs: map[string]interface{}{
+				"service.name": "api",
+			},
+		},
+		{
+			desc: "capture with pattern",
+			rules: []LabelRule{
+				{Label: "path", FromAttribute: "http.url", Pattern: regexp.MustCompile(`^https?://[^/]+(/[^/?]+)`)},
+				{Label: "scheme", FromAttribute: "http.url", Pattern: regexp.MustCompile(`^https?`)},
+				{Label: "port", FromAttribute: "http.url", Pattern: regexp.MustCompile(`:(\d+)/`)},
+			},
+			expected: model.LabelSet{"path": "/api", "scheme": "https"},
+			expectedAttrs: map[string]interface{}{
+				"http.status": int64(200),
+				"http.url":    "https://example.com/api/users?id=1",
+			},
+			expectedResAttrs: map[string]interface{}{
+				"k8s.namespace.name": "prod",
+				"service.name":       "api",
+			},
+		},
+		{
+			desc: "first rule with a value wins",
+			rules: []LabelRule{
+				{Label: "app", FromAttribute: "app"},
+				{Label: "app", FromResourceAttribute: "service.name"},
+				{Label: "app", FromResourceAttribute: "k8s.namespace.name"},
+			},
+			expected: model.LabelSet{"app": "api"},
+			expectedAttrs: map[string]interface{}{
+				"http.status": int64(200),
+				"http.url":    "https://example.com/api/users?id=1",
+			},
+			expectedResAttrs: map[string]interface{}{
+				"k8s.namespace.name": "prod",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			assert.NoError(t, attrs.FromRaw(map[string]interface{}{
+				"http.status": 200,
+				"http.url":    "https://example.com/api/users?id=1",
+			}))
+			resAttrs := pcommon.NewMap()
+			assert.NoError(t, resAttrs.FromRaw(map[string]interface{}{
+				"k8s.namespace.name": "prod",
+				"service.name":       "api",
+			}))
+
+			assert.Equal(t, tC.expected, applyLabelRules(tC.rules, attrs, resAttrs))
+			assert.Equal(t, tC.expectedAttrs, attrs.AsRaw())
+			assert.Equal(t, tC.expectedResAttrs, resAttrs.AsRaw())
+		})
+	}
+}
+
+func TestConvertAttributesToStructuredMetadata(t *testing.T) {
+	attrs := pcommon.NewMap()
+	assert.NoError(t, attrs.FromRaw(map[string]interface{}{
+		"http.status": 200,
+		"host.name":   "from-record",
+		"tags":        []interface{}{"a", "b"},
+	}))
+	resAttrs := pcommon.NewMap()
+	assert.NoError(t, resAttrs.FromRaw(map[string]interface{}{
+		"host.name":    "from-resource",
+		"service.name": "api",
+	}))
+
+	assert.Equal(t, push.LabelsAdapter{
+		{Name: "host_name", Value: "from-record"},
+		{Name: "http_status", Value: "200"},
+		{Name: "service_name", Value: "api"},
+		{Name: "tags", Value: `["a","b"]`},
+	}, convertAttributesToStructuredMetadata(attrs, resAttrs))
+}
+
 func TestGetNestedAttribute(t *testing.T) {
 	// prepare
 	attrs := pcommon.NewMap()
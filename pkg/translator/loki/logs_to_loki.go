@@ -57,6 +57,22 @@ const (
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
+	return LogsToLokiRequestsWithOptions(ld, Options{})
+}
+
+// Options customizes the conversion of logs into Loki push requests.
+type Options struct {
+	// LabelRules promote attributes to stream labels, in addition to the hints.
+	LabelRules []LabelRule
+
+	// StructuredMetadata sends the attributes that aren't promoted to labels as
+	// the structured metadata of each entry, instead of encoding them into the line.
+	StructuredMetadata bool
+}
+
+// LogsToLokiRequestsWithOptions is like LogsToLokiRequests, additionally applying
+// the given options.
+func LogsToLokiRequestsWithOptions(ld plog.Logs, opts Options) map[string]PushRequest {
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -97,6 +113,18 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 				removeAttributes(log.Attributes(), mergedLabels)
 				removeAttributes(resource.Attributes(), mergedLabels)
 
+				if len(opts.LabelRules) > 0 {
+					mergedLabels = mergedLabels.Merge(applyLabelRules(opts.LabelRules, log.Attributes(), resource.Attributes()))
+				}
+
+				// move the remaining attributes out of the line
+				var structuredMetadata push.LabelsAdapter
+				if opts.StructuredMetadata {
+					structuredMetadata = convertAttributesToStructuredMetadata(log.Attributes(), resource.Attributes())
+					log.Attributes().Clear()
+					resource.Attributes().Clear()
+				}
+
 				// create the stream name based on the labels
 				labels := mergedLabels.String()
 				entry, err := convertLogToLokiEntry(log, resource, format, scope)
@@ -106,6 +134,7 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 					group.report.NumDropped++
 					continue
 				}
+				entry.StructuredMetadata = structuredMetadata
 
 				group.report.NumSubmitted++
 
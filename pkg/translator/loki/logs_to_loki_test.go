@@ -16,7 +16,9 @@ package loki // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/grafana/loki/pkg/push"
 	"github.com/stretchr/testify/assert"
@@ -610,3 +612,60 @@ func TestLogsToLoki(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsToLokiRequestsWithOptions(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("k8s.namespace.name", "prod")
+	rl.Resource().Attributes().PutStr("k8s.pod.name", "api-7d9f8b6c5-x2x4z")
+	rl.Resource().Attributes().PutStr("host.name", "node-1")
+	rl.Resource().Attributes().PutStr(hintResources, "host.name")
+
+	sl := rl.ScopeLogs().AppendEmpty()
+	for _, status := range []int64{200, 500} {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1)))
+		lr.Body().SetStr("request handled")
+		lr.Attributes().PutInt("http.status", status)
+		lr.Attributes().PutStr("user.id", "42")
+	}
+
+	requests := LogsToLokiRequestsWithOptions(logs, Options{
+		LabelRules: []LabelRule{
+			{Label: "namespace", FromResourceAttribute: "k8s.namespace.name"},
+			{Label: "deployment", FromResourceAttribute: "k8s.pod.name", Pattern: regexp.MustCompile(`^(.+)-[^-]+-[^-]+$`)},
+		},
+		StructuredMetadata: true,
+	})
+
+	expected := push.PushRequest{
+		Streams: []push.Stream{
+			{
+				Labels: `{deployment="api", exporter="OTLP", host.name="node-1", namespace="prod"}`,
+				Entries: []push.Entry{
+					{
+						Timestamp: time.Unix(0, 1),
+						Line:      `{"body":"request handled"}`,
+						StructuredMetadata: push.LabelsAdapter{
+							{Name: "http_status", Value: "200"},
+							{Name: "k8s_pod_name", Value: "api-7d9f8b6c5-x2x4z"},
+							{Name: "user_id", Value: "42"},
+						},
+					},
+					{
+						Timestamp: time.Unix(0, 1),
+						Line:      `{"body":"request handled"}`,
+						StructuredMetadata: push.LabelsAdapter{
+							{Name: "http_status", Value: "500"},
+							{Name: "k8s_pod_name", Value: "api-7d9f8b6c5-x2x4z"},
+							{Name: "user_id", Value: "42"},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Len(t, requests, 1)
+	assert.Equal(t, expected, *requests[""].PushRequest)
+	assert.Equal(t, 2, requests[""].Report.NumSubmitted)
+}
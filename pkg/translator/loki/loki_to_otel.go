@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// PushRequestToLogs converts a Loki push request into logs. The line of each entry becomes the
+// body of a log record, and the stream labels and structured metadata become its attributes.
+// A stream label takes precedence over structured metadata with the same name. An error is
+// returned when the labels of a stream can't be parsed.
+func PushRequestToLogs(pushRequest *push.PushRequest) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	observed := pcommon.NewTimestampFromTime(time.Now())
+
+	for _, stream := range pushRequest.Streams {
+		if len(stream.Entries) == 0 {
+			continue
+		}
+
+		labels, err := parseLabels(stream.Labels)
+		if err != nil {
+			return plog.Logs{}, err
+		}
+
+		for _, entry := range stream.Entries {
+			lr := records.AppendEmpty()
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(entry.Timestamp))
+			lr.SetObservedTimestamp(observed)
+			lr.Body().SetStr(entry.Line)
+
+			attrs := lr.Attributes()
+			attrs.EnsureCapacity(len(labels) + len(entry.StructuredMetadata))
+			for _, label := range labels {
+				attrs.PutStr(label.Name, label.Value)
+			}
+			for _, md := range entry.StructuredMetadata {
+				if _, found := attrs.Get(md.Name); !found {
+					attrs.PutStr(md.Name, md.Value)
+				}
+			}
+		}
+	}
+
+	return logs, nil
+}
+
+// parseLabels parses a stream selector such as `{job="varlogs", host="node-1"}`.
+func parseLabels(selector string) (push.LabelsAdapter, error) {
+	s := strings.TrimSpace(selector)
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, fmt.Errorf("invalid stream labels %q: must be enclosed in braces", selector)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+
+	var labels push.LabelsAdapter
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("invalid stream labels %q: missing label name", selector)
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimSpace(s[eq+1:])
+
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q: value of %q is not quoted", selector, name)
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stream labels %q: %w", selector, err)
+		}
+		labels = append(labels, push.LabelAdapter{Name: name, Value: value})
+
+		s = strings.TrimSpace(s[len(quoted):])
+		if s == "" {
+			break
+		}
+		if s[0] != ',' {
+			return nil, fmt.Errorf("invalid stream labels %q: expected ',' after the value of %q", selector, name)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestPushRequestToLogs(t *testing.T) {
+	ts := time.Unix(0, 1676888496000000000)
+	req := &push.PushRequest{
+		Streams: []push.Stream{
+			{
+				Labels: `{job="varlogs", filename="/var/log/syslog", msg="say \"hi\", bye"}`,
+				Entries: []push.Entry{
+					{Timestamp: ts, Line: "first line"},
+					{
+						Timestamp: ts.Add(time.Second),
+						Line:      "second line",
+						StructuredMetadata: push.LabelsAdapter{
+							{Name: "trace_id", Value: "0102"},
+							{Name: "job", Value: "ignored"},
+						},
+					},
+				},
+			},
+			{
+				Labels: `{}`,
+				Entries: []push.Entry{
+					{Timestamp: ts, Line: "no labels"},
+				},
+			},
+			{
+				Labels: `{job="empty"}`,
+			},
+		},
+	}
+
+	logs, err := PushRequestToLogs(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.ResourceLogs().Len())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 3, records.Len())
+
+	first := records.At(0)
+	assert.Equal(t, "first line", first.Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(ts), first.Timestamp())
+	assert.NotZero(t, first.ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{
+		"job":      "varlogs",
+		"filename": "/var/log/syslog",
+		"msg":      `say "hi", bye`,
+	}, first.Attributes().AsRaw())
+
+	second := records.At(1)
+	assert.Equal(t, "second line", second.Body().Str())
+	assert.Equal(t, pcommon.NewTimestampFromTime(ts.Add(time.Second)), second.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"job":      "varlogs",
+		"filename": "/var/log/syslog",
+		"msg":      `say "hi", bye`,
+		"trace_id": "0102",
+	}, second.Attributes().AsRaw())
+
+	third := records.At(2)
+	assert.Equal(t, "no labels", third.Body().Str())
+	assert.Equal(t, 0, third.Attributes().Len())
+}
+
+func TestPushRequestToLogsRoundTrip(t *testing.T) {
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host_name", "guarana")
+	rl.Resource().Attributes().PutStr(hintResources, "host_name")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1)))
+	lr.Body().SetStr("hello")
+	lr.Attributes().PutStr("level", "info")
+	lr.Attributes().PutStr(hintAttributes, "level")
+
+	requests := LogsToLokiRequests(logs)
+	require.Len(t, requests, 1)
+
+	actual, err := PushRequestToLogs(requests[""].PushRequest)
+	require.NoError(t, err)
+	require.Equal(t, 1, actual.LogRecordCount())
+
+	record := actual.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, `{"body":"hello"}`, record.Body().Str())
+	assert.Equal(t, lr.Timestamp(), record.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"exporter":  "OTLP",
+		"host_name": "guarana",
+		"level":     "info",
+	}, record.Attributes().AsRaw())
+}
+
+func TestParseLabels(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		selector string
+		expected push.LabelsAdapter
+		err      string
+	}{
+		{
+			desc:     "empty",
+			selector: "{}",
+		},
+		{
+			desc:     "single label",
+			selector: `{job="varlogs"}`,
+			expected: push.LabelsAdapter{{Name: "job", Value: "varlogs"}},
+		},
+		{
+			desc:     "multiple labels without spaces",
+			selector: `{job="varlogs",host="node-1"}`,
+			expected: push.LabelsAdapter{{Name: "job", Value: "varlogs"}, {Name: "host", Value: "node-1"}},
+		},
+		{
+			desc:     "escaped value",
+			selector: `{ path="C:\\logs\\app.log" }`,
+			expected: push.LabelsAdapter{{Name: "path", Value: `C:\logs\app.log`}},
+		},
+		{
+			desc:     "missing braces",
+			selector: `job="varlogs"`,
+			err:      "must be enclosed in braces",
+		},
+		{
+			desc:     "missing name",
+			selector: `{="varlogs"}`,
+			err:      "missing label name",
+		},
+		{
+			desc:     "unquoted value",
+			selector: `{job=varlogs}`,
+			err:      `value of "job" is not quoted`,
+		},
+		{
+			desc:     "missing separator",
+			selector: `{job="varlogs" host="node-1"}`,
+			err:      `expected ',' after the value of "job"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			labels, err := parseLabels(tC.selector)
+			if tC.err != "" {
+				assert.ErrorContains(t, err, tC.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tC.expected, labels)
+		})
+	}
+}
@@ -0,0 +1,583 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	bucketSuffix = "_bucket"
+	sumSuffix    = "_sum"
+	countSuffix  = "_count"
+	totalSuffix  = "_total"
+)
+
+// maxNativeHistogramBuckets limits the number of buckets, including the empty buckets between
+// spans, of each range of a native histogram, like the default maximum size of the OTel SDK
+// exponential histograms. It protects against spans with huge offsets.
+const maxNativeHistogramBuckets = 160
+
+var errNoMetricName = errors.New("time series has no metric name")
+
+// ToMetrics converts a Prometheus remote write request into pmetric.Metrics. Series are grouped
+// into one resource per `job` and `instance` pair, mapped to the `service.name` and
+// `service.instance.id` resource attributes, and the other labels become data point attributes.
+//
+// The type, help and unit of a metric family are taken from the request metadata. Without
+// metadata, series with a `_bucket` suffix and a `le` label are histogram buckets, series with a
+// `quantile` label are summary quantiles, series with a `_total` suffix are counters and all the
+// others are gauges. Native histograms are converted into exponential histograms. Exemplars are
+// dropped.
+func ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	b := newMetricsBuilder(req)
+	for i := range req.Timeseries {
+		if err := b.addSeries(&req.Timeseries[i]); err != nil {
+			return pmetric.Metrics{}, err
+		}
+	}
+	return b.build(), nil
+}
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type metricsBuilder struct {
+	metrics  pmetric.Metrics
+	metadata map[string]prompb.MetricMetadata
+	// families holds the type of the histogram and summary families, which are made of several series.
+	families  map[string]prompb.MetricMetadata_MetricType
+	resources map[resourceKey]*resourceBuilder
+}
+
+type resourceBuilder struct {
+	metrics  pmetric.MetricSlice
+	families map[string]*familyBuilder
+}
+
+type familyBuilder struct {
+	metric pmetric.Metric
+	// points holds the classic histogram and summary points being assembled from their series.
+	points map[string]*compositePoint
+	order  []*compositePoint
+}
+
+type compositePoint struct {
+	labels    []prompb.Label
+	timestamp int64
+	// buckets holds the cumulative count per upper bound of histograms, or the value per quantile of summaries.
+	buckets  []bucket
+	sum      float64
+	count    float64
+	hasSum   bool
+	hasCount bool
+	stale    bool
+}
+
+type bucket struct {
+	bound float64
+	value float64
+}
+
+func newMetricsBuilder(req *prompb.WriteRequest) *metricsBuilder {
+	b := &metricsBuilder{
+		metrics:   pmetric.NewMetrics(),
+		metadata:  make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		families:  make(map[string]prompb.MetricMetadata_MetricType),
+		resources: make(map[resourceKey]*resourceBuilder),
+	}
+
+	for _, md := range req.Metadata {
+		b.metadata[md.MetricFamilyName] = md
+		if md.Type == prompb.MetricMetadata_HISTOGRAM || md.Type == prompb.MetricMetadata_SUMMARY {
+			b.families[md.MetricFamilyName] = md.Type
+		}
+	}
+
+	// infer the families the metadata doesn't describe from the series themselves
+	for _, ts := range req.Timeseries {
+		if len(ts.Histograms) > 0 {
+			continue
+		}
+		name := labelValue(ts.Labels, model.MetricNameLabel)
+		if base := strings.TrimSuffix(name, bucketSuffix); base != name && hasLabel(ts.Labels, model.BucketLabel) {
+			if _, ok := b.metadata[base]; !ok {
+				b.families[base] = prompb.MetricMetadata_HISTOGRAM
+			}
+		} else if hasLabel(ts.Labels, model.QuantileLabel) {
+			if _, ok := b.metadata[name]; !ok {
+				b.families[name] = prompb.MetricMetadata_SUMMARY
+			}
+		}
+	}
+
+	return b
+}
+
+func (b *metricsBuilder) addSeries(ts *prompb.TimeSeries) error {
+	var name, job, instance string
+	attrs := make([]prompb.Label, 0, len(ts.Labels))
+	for _, l := range ts.Labels {
+		switch l.Name {
+		case model.MetricNameLabel:
+			name = l.Value
+		case model.JobLabel:
+			job = l.Value
+		case model.InstanceLabel:
+			instance = l.Value
+		default:
+			attrs = append(attrs, l)
+		}
+	}
+	if name == "" {
+		return errNoMetricName
+	}
+	rb := b.resource(job, instance)
+
+	if len(ts.Histograms) > 0 {
+		f, err := rb.family(name, b.metadata[name], pmetric.MetricTypeExponentialHistogram)
+		if err != nil {
+			return err
+		}
+		for _, h := range ts.Histograms {
+			if err = addNativeHistogram(f.metric.ExponentialHistogram().DataPoints().AppendEmpty(), attrs, h); err != nil {
+				return fmt.Errorf("invalid native histogram %q: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	if family, typ, suffix := b.compositeFamily(name, attrs); family != "" {
+		return b.addCompositeSeries(rb, family, typ, suffix, attrs, ts.Samples)
+	}
+
+	md, ok := b.metadata[name]
+	if !ok {
+		if base := strings.TrimSuffix(name, totalSuffix); base != name {
+			md, ok = b.metadata[base]
+		}
+	}
+	var dps pmetric.NumberDataPointSlice
+	if ok && md.Type == prompb.MetricMetadata_COUNTER || !ok && strings.HasSuffix(name, totalSuffix) {
+		f, err := rb.family(name, md, pmetric.MetricTypeSum)
+		if err != nil {
+			return err
+		}
+		dps = f.metric.Sum().DataPoints()
+	} else {
+		f, err := rb.family(name, md, pmetric.MetricTypeGauge)
+		if err != nil {
+			return err
+		}
+		dps = f.metric.Gauge().DataPoints()
+	}
+
+	for _, s := range ts.Samples {
+		dp := dps.AppendEmpty()
+		setAttributes(dp.Attributes(), attrs)
+		dp.SetTimestamp(timestampFromMs(s.Timestamp))
+		dp.SetDoubleValue(s.Value)
+		if value.IsStaleNaN(s.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		}
+	}
+	return nil
+}
+
+// compositeFamily returns the histogram or summary family the series is part of, if any, along
+// with its type and the suffix identifying the part of the family held by the series.
+func (b *metricsBuilder) compositeFamily(name string, attrs []prompb.Label) (string, prompb.MetricMetadata_MetricType, string) {
+	if base := strings.TrimSuffix(name, bucketSuffix); base != name && b.families[base] == prompb.MetricMetadata_HISTOGRAM && hasLabel(attrs, model.BucketLabel) {
+		return base, prompb.MetricMetadata_HISTOGRAM, bucketSuffix
+	}
+	if b.families[name] == prompb.MetricMetadata_SUMMARY && hasLabel(attrs, model.QuantileLabel) {
+		return name, prompb.MetricMetadata_SUMMARY, ""
+	}
+	for _, suffix := range []string{sumSuffix, countSuffix} {
+		if base := strings.TrimSuffix(name, suffix); base != name {
+			if typ, ok := b.families[base]; ok {
+				return base, typ, suffix
+			}
+		}
+	}
+	return "", prompb.MetricMetadata_UNKNOWN, ""
+}
+
+func (b *metricsBuilder) addCompositeSeries(
+	rb *resourceBuilder,
+	family string,
+	typ prompb.MetricMetadata_MetricType,
+	suffix string,
+	attrs []prompb.Label,
+	samples []prompb.Sample,
+) error {
+	metricType := pmetric.MetricTypeHistogram
+	boundLabel := model.BucketLabel
+	if typ == prompb.MetricMetadata_SUMMARY {
+		metricType = pmetric.MetricTypeSummary
+		boundLabel = model.QuantileLabel
+	}
+
+	f, err := rb.family(family, b.metadata[family], metricType)
+	if err != nil {
+		return err
+	}
+
+	var bound float64
+	pointAttrs := attrs
+	if suffix == bucketSuffix || suffix == "" {
+		pointAttrs = make([]prompb.Label, 0, len(attrs))
+		for _, l := range attrs {
+			if l.Name != boundLabel {
+				pointAttrs = append(pointAttrs, l)
+				continue
+			}
+			if bound, err = strconv.ParseFloat(l.Value, 64); err != nil {
+				return fmt.Errorf("invalid %q label for metric %q: %w", boundLabel, family, err)
+			}
+		}
+	}
+
+	for _, s := range samples {
+		p := f.point(pointAttrs, s.Timestamp)
+		if value.IsStaleNaN(s.Value) {
+			p.stale = true
+		}
+		switch suffix {
+		case sumSuffix:
+			p.sum = s.Value
+			p.hasSum = true
+		case countSuffix:
+			p.count = s.Value
+			p.hasCount = true
+		default:
+			p.buckets = append(p.buckets, bucket{bound: bound, value: s.Value})
+		}
+	}
+	return nil
+}
+
+func (b *metricsBuilder) resource(job, instance string) *resourceBuilder {
+	key := resourceKey{job: job, instance: instance}
+	if rb, ok := b.resources[key]; ok {
+		return rb
+	}
+
+	rm := b.metrics.ResourceMetrics().AppendEmpty()
+	if job != "" {
+		rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, job)
+	}
+	if instance != "" {
+		rm.Resource().Attributes().PutStr(conventions.AttributeServiceInstanceID, instance)
+	}
+
+	rb := &resourceBuilder{
+		metrics:  rm.ScopeMetrics().AppendEmpty().Metrics(),
+		families: make(map[string]*familyBuilder),
+	}
+	b.resources[key] = rb
+	return rb
+}
+
+func (b *metricsBuilder) build() pmetric.Metrics {
+	for _, rb := range b.resources {
+		for _, f := range rb.families {
+			f.flush()
+		}
+	}
+	return b.metrics
+}
+
+func (rb *resourceBuilder) family(name string, md prompb.MetricMetadata, metricType pmetric.MetricType) (*familyBuilder, error) {
+	if f, ok := rb.families[name]; ok {
+		if f.metric.Type() != metricType {
+			return nil, fmt.Errorf("metric %q has conflicting types %s and %s", name, f.metric.Type(), metricType)
+		}
+		return f, nil
+	}
+
+	metric := rb.metrics.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(md.Help)
+	metric.SetUnit(md.Unit)
+	switch metricType {
+	case pmetric.MetricTypeGauge:
+		metric.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	}
+
+	f := &familyBuilder{
+		metric: metric,
+		points: make(map[string]*compositePoint),
+	}
+	rb.families[name] = f
+	return f, nil
+}
+
+// point returns the histogram or summary point with the given attributes and timestamp.
+func (f *familyBuilder) point(attrs []prompb.Label, timestamp int64) *compositePoint {
+	sorted := make([]prompb.Label, len(attrs))
+	copy(sorted, attrs)
+	sort.Sort(ByLabelName(sorted))
+
+	var sig strings.Builder
+	for _, l := range sorted {
+		sig.WriteString(l.Name)
+		sig.WriteByte(0xff)
+		sig.WriteString(l.Value)
+		sig.WriteByte(0xff)
+	}
+	sig.WriteString(strconv.FormatInt(timestamp, 10))
+
+	key := sig.String()
+	if p, ok := f.points[key]; ok {
+		return p
+	}
+	p := &compositePoint{labels: sorted, timestamp: timestamp}
+	f.points[key] = p
+	f.order = append(f.order, p)
+	return p
+}
+
+func (f *familyBuilder) flush() {
+	for _, p := range f.order {
+		switch f.metric.Type() {
+		case pmetric.MetricTypeHistogram:
+			p.toHistogram(f.metric.Histogram().DataPoints().AppendEmpty())
+		case pmetric.MetricTypeSummary:
+			p.toSummary(f.metric.Summary().DataPoints().AppendEmpty())
+		}
+	}
+}
+
+func (p *compositePoint) toHistogram(dp pmetric.HistogramDataPoint) {
+	setAttributes(dp.Attributes(), p.labels)
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(p.buckets, func(i, j int) bool { return p.buckets[i].bound < p.buckets[j].bound })
+
+	var cumulative float64
+	count, hasCount := p.count, p.hasCount
+	for _, b := range p.buckets {
+		if math.IsInf(b.bound, 1) {
+			if !hasCount {
+				count, hasCount = b.value, true
+			}
+			continue
+		}
+		dp.ExplicitBounds().Append(b.bound)
+		dp.BucketCounts().Append(uint64(math.Max(b.value-cumulative, 0)))
+		cumulative = math.Max(b.value, cumulative)
+	}
+	if !hasCount {
+		count = cumulative
+	}
+	if len(p.buckets) > 0 {
+		dp.BucketCounts().Append(uint64(math.Max(count-cumulative, 0)))
+	}
+
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+}
+
+func (p *compositePoint) toSummary(dp pmetric.SummaryDataPoint) {
+	setAttributes(dp.Attributes(), p.labels)
+	dp.SetTimestamp(timestampFromMs(p.timestamp))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	sort.Slice(p.buckets, func(i, j int) bool { return p.buckets[i].bound < p.buckets[j].bound })
+	for _, b := range p.buckets {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(b.bound)
+		qv.SetValue(b.value)
+	}
+
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+}
+
+func addNativeHistogram(dp pmetric.ExponentialHistogramDataPoint, attrs []prompb.Label, h prompb.Histogram) error {
+	setAttributes(dp.Attributes(), attrs)
+	dp.SetTimestamp(timestampFromMs(h.Timestamp))
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return nil
+	}
+
+	dp.SetScale(h.Schema)
+	dp.SetSum(h.Sum)
+	switch count := h.Count.(type) {
+	case *prompb.Histogram_CountInt:
+		dp.SetCount(count.CountInt)
+	case *prompb.Histogram_CountFloat:
+		c, err := floatCount(count.CountFloat)
+		if err != nil {
+			return fmt.Errorf("count: %w", err)
+		}
+		dp.SetCount(c)
+	}
+	switch zeroCount := h.ZeroCount.(type) {
+	case *prompb.Histogram_ZeroCountInt:
+		dp.SetZeroCount(zeroCount.ZeroCountInt)
+	case *prompb.Histogram_ZeroCountFloat:
+		c, err := floatCount(zeroCount.ZeroCountFloat)
+		if err != nil {
+			return fmt.Errorf("zero count: %w", err)
+		}
+		dp.SetZeroCount(c)
+	}
+
+	if err := convertNativeBuckets(dp.Positive(), h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts); err != nil {
+		return fmt.Errorf("positive buckets: %w", err)
+	}
+	if err := convertNativeBuckets(dp.Negative(), h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts); err != nil {
+		return fmt.Errorf("negative buckets: %w", err)
+	}
+	return nil
+}
+
+// convertNativeBuckets translates Prometheus Native Histogram sparse buckets into the OTel
+// Exponential Histogram dense representation, reversing convertBucketsLayout. Integer histograms
+// carry deltas between consecutive buckets, while float histograms carry absolute counts.
+func convertNativeBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, spans []prompb.BucketSpan, deltas []int64, counts []float64) error {
+	var length, denseLength int64
+	for i, span := range spans {
+		if i > 0 {
+			if span.Offset < 0 {
+				return fmt.Errorf("span %d has a negative offset", i)
+			}
+			denseLength += int64(span.Offset)
+		}
+		length += int64(span.Length)
+		denseLength += int64(span.Length)
+		if denseLength > maxNativeHistogramBuckets {
+			return fmt.Errorf("spans cover more than %d buckets", maxNativeHistogramBuckets)
+		}
+	}
+	if length != int64(len(deltas)+len(counts)) || len(deltas) > 0 && len(counts) > 0 {
+		return fmt.Errorf("spans cover %d buckets but %d counts are provided", length, len(deltas)+len(counts))
+	}
+	if length == 0 {
+		return nil
+	}
+
+	// The offset is adjusted by 1, since OTel bucket index 0 corresponds to Prometheus bucket index 1.
+	buckets.SetOffset(spans[0].Offset - 1)
+	bucketCounts := buckets.BucketCounts()
+	bucketCounts.EnsureCapacity(int(denseLength))
+
+	var count int64
+	var pos int
+	for i, span := range spans {
+		if i > 0 {
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts.Append(0)
+			}
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			if len(deltas) > 0 {
+				count += deltas[pos]
+				if count < 0 {
+					return fmt.Errorf("bucket %d has a negative count", pos)
+				}
+				bucketCounts.Append(uint64(count))
+			} else {
+				c, err := floatCount(counts[pos])
+				if err != nil {
+					return fmt.Errorf("bucket %d: %w", pos, err)
+				}
+				bucketCounts.Append(c)
+			}
+			pos++
+		}
+	}
+	return nil
+}
+
+// floatCount rounds a float histogram count to an integer, rejecting values that cannot be
+// represented as one.
+func floatCount(f float64) (uint64, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("count %v is not finite", f)
+	}
+	if f < 0 {
+		return 0, fmt.Errorf("count %v is negative", f)
+	}
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("count %v is too large", f)
+	}
+	return uint64(math.Round(f)), nil
+}
+
+func setAttributes(dest pcommon.Map, labels []prompb.Label) {
+	dest.EnsureCapacity(len(labels))
+	for _, l := range labels {
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func timestampFromMs(ms int64) pcommon.Timestamp {
+	return pcommon.Timestamp(ms * int64(1e6))
+}
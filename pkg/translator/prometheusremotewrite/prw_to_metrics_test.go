@@ -0,0 +1,443 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+func toWriteRequest(series ...*prompb.TimeSeries) *prompb.WriteRequest {
+	req := &prompb.WriteRequest{}
+	for _, ts := range series {
+		req.Timeseries = append(req.Timeseries, *ts)
+	}
+	return req
+}
+
+func metricsByName(t *testing.T, md pmetric.Metrics) map[string]pmetric.Metric {
+	metrics := map[string]pmetric.Metric{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		sms := md.ResourceMetrics().At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				_, found := metrics[ms.At(k).Name()]
+				require.False(t, found, "duplicate metric %q", ms.At(k).Name())
+				metrics[ms.At(k).Name()] = ms.At(k)
+			}
+		}
+	}
+	return metrics
+}
+
+func TestToMetricsResources(t *testing.T) {
+	req := toWriteRequest(
+		getTimeSeries(getPromLabels("__name__", "up", "job", "api", "instance", "host-1:9090"), getSample(1, 1000)),
+		getTimeSeries(getPromLabels("__name__", "up", "job", "api", "instance", "host-2:9090"), getSample(0, 1000)),
+		getTimeSeries(getPromLabels("__name__", "temperature", "job", "api", "instance", "host-1:9090", "room", "kitchen"), getSample(21.5, 1000)),
+		getTimeSeries(getPromLabels("__name__", "scrape_duration_seconds"), getSample(0.25, 1000)),
+	)
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 3, md.ResourceMetrics().Len())
+
+	host1 := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "api",
+		conventions.AttributeServiceInstanceID: "host-1:9090",
+	}, host1.Resource().Attributes().AsRaw())
+	metrics := host1.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "up", metrics.At(0).Name())
+	assert.Equal(t, "temperature", metrics.At(1).Name())
+	dp := metrics.At(1).Gauge().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"room": "kitchen"}, dp.Attributes().AsRaw())
+	assert.Equal(t, 21.5, dp.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.UnixMilli(1000)), dp.Timestamp())
+
+	host2 := md.ResourceMetrics().At(1)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "api",
+		conventions.AttributeServiceInstanceID: "host-2:9090",
+	}, host2.Resource().Attributes().AsRaw())
+	assert.Equal(t, 0.0, host2.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).DoubleValue())
+
+	assert.Equal(t, 0, md.ResourceMetrics().At(2).Resource().Attributes().Len())
+}
+
+func TestToMetricsNumbers(t *testing.T) {
+	req := toWriteRequest(
+		getTimeSeries(getPromLabels("__name__", "http_requests_total", "code", "200"), getSample(10, 1000), getSample(12, 2000)),
+		getTimeSeries(getPromLabels("__name__", "process_cpu_seconds"), getSample(3.5, 1000)),
+		getTimeSeries(getPromLabels("__name__", "queue_length"), getSample(4, 1000)),
+		getTimeSeries(getPromLabels("__name__", "errors_total"), getSample(2, 1000)),
+		getTimeSeries(getPromLabels("__name__", "stale"), getSample(math.Float64frombits(value.StaleNaN), 1000)),
+	)
+	req.Metadata = []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests", Help: "Requests served.", Unit: "requests"},
+		{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "process_cpu_seconds", Help: "CPU time."},
+		{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "errors_total", Help: "Not a counter."},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 5)
+
+	requests := metrics["http_requests_total"]
+	require.Equal(t, pmetric.MetricTypeSum, requests.Type())
+	assert.Equal(t, "Requests served.", requests.Description())
+	assert.Equal(t, "requests", requests.Unit())
+	assert.True(t, requests.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+	require.Equal(t, 2, requests.Sum().DataPoints().Len())
+	assert.Equal(t, 12.0, requests.Sum().DataPoints().At(1).DoubleValue())
+	assert.Equal(t, map[string]interface{}{"code": "200"}, requests.Sum().DataPoints().At(1).Attributes().AsRaw())
+
+	cpu := metrics["process_cpu_seconds"]
+	require.Equal(t, pmetric.MetricTypeSum, cpu.Type())
+	assert.Equal(t, "CPU time.", cpu.Description())
+
+	require.Equal(t, pmetric.MetricTypeGauge, metrics["queue_length"].Type())
+
+	errs := metrics["errors_total"]
+	require.Equal(t, pmetric.MetricTypeGauge, errs.Type())
+	assert.Equal(t, "Not a counter.", errs.Description())
+
+	stale := metrics["stale"].Gauge().DataPoints().At(0)
+	assert.True(t, stale.Flags().NoRecordedValue())
+}
+
+func TestToMetricsHistogram(t *testing.T) {
+	req := toWriteRequest(
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "path", "/", "le", "0.1"), getSample(1, 1000)),
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "path", "/", "le", "1"), getSample(3, 1000)),
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "path", "/", "le", "+Inf"), getSample(6, 1000)),
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_sum", "path", "/"), getSample(7.5, 1000)),
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_count", "path", "/"), getSample(6, 1000)),
+		getTimeSeries(getPromLabels("__name__", "latency_seconds_bucket", "path", "/login", "le", "+Inf"), getSample(math.Float64frombits(value.StaleNaN), 1000)),
+		getTimeSeries(getPromLabels("__name__", "size_bytes_bucket", "le", "1024"), getSample(2, 1000)),
+		getTimeSeries(getPromLabels("__name__", "size_bytes_bucket", "le", "4096"), getSample(5, 1000)),
+		getTimeSeries(getPromLabels("__name__", "size_bytes_sum"), getSample(8000, 1000)),
+	)
+	req.Metadata = []prompb.MetricMetadata{
+		{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency_seconds", Help: "Request latency.", Unit: "seconds"},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 2)
+
+	latency := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeHistogram, latency.Type())
+	assert.Equal(t, "Request latency.", latency.Description())
+	assert.Equal(t, "seconds", latency.Unit())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, latency.Histogram().AggregationTemporality())
+	require.Equal(t, 2, latency.Histogram().DataPoints().Len())
+
+	dp := latency.Histogram().DataPoints().At(0)
+	assert.Equal(t, map[string]interface{}{"path": "/"}, dp.Attributes().AsRaw())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.UnixMilli(1000)), dp.Timestamp())
+	assert.Equal(t, []float64{0.1, 1}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2, 3}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(6), dp.Count())
+	assert.Equal(t, 7.5, dp.Sum())
+
+	stale := latency.Histogram().DataPoints().At(1)
+	assert.Equal(t, map[string]interface{}{"path": "/login"}, stale.Attributes().AsRaw())
+	assert.True(t, stale.Flags().NoRecordedValue())
+
+	size := metrics["size_bytes"]
+	require.Equal(t, pmetric.MetricTypeHistogram, size.Type())
+	dp = size.Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{1024, 4096}, dp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 3, 0}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 8000.0, dp.Sum())
+}
+
+func TestToMetricsSummary(t *testing.T) {
+	req := toWriteRequest(
+		getTimeSeries(getPromLabels("__name__", "rpc_duration_seconds", "quantile", "0.99"), getSample(0.9, 1000)),
+		getTimeSeries(getPromLabels("__name__", "rpc_duration_seconds", "quantile", "0.5"), getSample(0.2, 1000)),
+		getTimeSeries(getPromLabels("__name__", "rpc_duration_seconds_sum"), getSample(30, 1000)),
+		getTimeSeries(getPromLabels("__name__", "rpc_duration_seconds_count"), getSample(100, 1000)),
+	)
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+
+	summary := metrics["rpc_duration_seconds"]
+	require.Equal(t, pmetric.MetricTypeSummary, summary.Type())
+	dp := summary.Summary().DataPoints().At(0)
+	assert.Equal(t, 0, dp.Attributes().Len())
+	assert.Equal(t, uint64(100), dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.2, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 0.99, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 0.9, dp.QuantileValues().At(1).Value())
+}
+
+func TestToMetricsNativeHistogram(t *testing.T) {
+	expected := pmetric.NewExponentialHistogramDataPoint()
+	expected.SetScale(1)
+	expected.SetCount(12)
+	expected.SetSum(42)
+	expected.SetZeroCount(2)
+	expected.SetTimestamp(pcommon.NewTimestampFromTime(time.UnixMilli(1000)))
+	expected.Positive().SetOffset(-2)
+	expected.Positive().BucketCounts().FromRaw([]uint64{2, 0, 0, 0, 3, 1})
+	expected.Negative().SetOffset(3)
+	expected.Negative().BucketCounts().FromRaw([]uint64{1, 0, 3})
+
+	histogram, err := exponentialToNativeHistogram(expected)
+	require.NoError(t, err)
+
+	floatHistogram := prompb.Histogram{
+		Count:          &prompb.Histogram_CountFloat{CountFloat: 5},
+		ZeroCount:      &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: 1},
+		Sum:            10,
+		Schema:         0,
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+		PositiveCounts: []float64{1, 3},
+		Timestamp:      2000,
+	}
+
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:     getPromLabels("__name__", "latency_seconds", "path", "/"),
+				Histograms: []prompb.Histogram{histogram, floatHistogram},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency_seconds", Help: "Request latency."},
+		},
+	}
+
+	md, err := ToMetrics(req)
+	require.NoError(t, err)
+	metrics := metricsByName(t, md)
+	require.Len(t, metrics, 1)
+
+	latency := metrics["latency_seconds"]
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, latency.Type())
+	assert.Equal(t, "Request latency.", latency.Description())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, latency.ExponentialHistogram().AggregationTemporality())
+	require.Equal(t, 2, latency.ExponentialHistogram().DataPoints().Len())
+
+	expected.Attributes().PutStr("path", "/")
+	assert.Equal(t, expected, latency.ExponentialHistogram().DataPoints().At(0))
+
+	dp := latency.ExponentialHistogram().DataPoints().At(1)
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, 10.0, dp.Sum())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 3}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, 0, dp.Negative().BucketCounts().Len())
+}
+
+func TestToMetricsRoundTrip(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.UnixMilli(1676888496000))
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "api")
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceInstanceID, "host-1:9090")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	requests := metrics.AppendEmpty()
+	requests.SetName("http_requests_total")
+	requests.SetEmptySum().SetIsMonotonic(true)
+	requests.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := requests.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(10)
+	dp.Attributes().PutStr("code", "200")
+
+	latency := metrics.AppendEmpty()
+	latency.SetName("latency_seconds")
+	latency.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := latency.Histogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(6)
+	hdp.SetSum(7.5)
+	hdp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+	hdp.Attributes().PutStr("path", "/")
+
+	tsMap, err := FromMetrics(md, Settings{DisableTargetInfo: true})
+	require.NoError(t, err)
+	req := &prompb.WriteRequest{}
+	for _, series := range tsMap {
+		req.Timeseries = append(req.Timeseries, *series)
+	}
+
+	actual, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, actual.ResourceMetrics().Len())
+	assert.Equal(t, rm.Resource().Attributes().AsRaw(), actual.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+
+	actualMetrics := metricsByName(t, actual)
+	require.Len(t, actualMetrics, 2)
+	assert.Equal(t, requests.Sum(), actualMetrics["http_requests_total"].Sum())
+	assert.Equal(t, latency.Histogram(), actualMetrics["latency_seconds"].Histogram())
+}
+
+func TestToMetricsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *prompb.WriteRequest
+		wantErr string
+	}{
+		{
+			name:    "no metric name",
+			req:     toWriteRequest(getTimeSeries(getPromLabels("job", "api"), getSample(1, 1000))),
+			wantErr: "time series has no metric name",
+		},
+		{
+			name: "invalid bucket bound",
+			req: toWriteRequest(
+				getTimeSeries(getPromLabels("__name__", "latency_bucket", "le", "fast"), getSample(1, 1000)),
+			),
+			wantErr: `invalid "le" label for metric "latency"`,
+		},
+		{
+			name: "conflicting types",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels("__name__", "latency"), getSample(1, 1000)),
+					{
+						Labels:     getPromLabels("__name__", "latency", "path", "/"),
+						Histograms: []prompb.Histogram{{Timestamp: 1000}},
+					},
+				},
+			},
+			wantErr: `metric "latency" has conflicting types Gauge and ExponentialHistogram`,
+		},
+		{
+			name: "spans not matching counts",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 3}},
+							PositiveDeltas: []int64{1, 1},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": positive buckets: spans cover 3 buckets but 2 counts are provided`,
+		},
+		{
+			name: "negative bucket count",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							NegativeSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+							NegativeDeltas: []int64{1, -2},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": negative buckets: bucket 1 has a negative count`,
+		},
+		{
+			name: "negative float bucket count",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}},
+							PositiveCounts: []float64{1, -2},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": positive buckets: bucket 1: count -2 is negative`,
+		},
+		{
+			name: "non-finite float count",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							Count: &prompb.Histogram_CountFloat{CountFloat: math.Inf(1)},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": count: count +Inf is not finite`,
+		},
+		{
+			name: "NaN float zero count",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							ZeroCount: &prompb.Histogram_ZeroCountFloat{ZeroCountFloat: math.NaN()},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": zero count: count NaN is not finite`,
+		},
+		{
+			name: "too many buckets",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels("__name__", "latency"),
+						Histograms: []prompb.Histogram{{
+							PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: math.MaxInt32, Length: 1}},
+							PositiveDeltas: []int64{1, 0},
+						}},
+					},
+				},
+			},
+			wantErr: `invalid native histogram "latency": positive buckets: spans cover more than 160 buckets`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ToMetrics(tt.req)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -19,8 +19,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
 // Config defines the configuration for the processor.
@@ -35,6 +39,27 @@ type Config struct {
 	// Cannot be used with deprecated Metrics config option.
 	Include MatchMetrics `mapstructure:"include"`
 	Exclude MatchMetrics `mapstructure:"exclude"`
+
+	// MetricConditions is a list of OTTL conditions for an ottlmetric context.
+	// If any condition resolves to true, the metric will be converted.
+	// Supports `and`, `or`, and `()`
+	// Cannot be used with `include` or `exclude`.
+	MetricConditions []string `mapstructure:"metric"`
+
+	// DataPointConditions is a list of OTTL conditions for an ottldatapoint context.
+	// If any condition resolves to true, the datapoint will be converted. The datapoints
+	// of a metric that do not match are moved to a copy of the metric left cumulative.
+	// Supports `and`, `or`, and `()`
+	// Cannot be used with `include` or `exclude`.
+	DataPointConditions []string `mapstructure:"datapoint"`
+
+	// StorageID is the ID of a storage extension used to persist the tracked state across restarts.
+	// If not set, the state is only kept in memory and the first point of every series is dropped after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// StorageSnapshotInterval is the interval at which the tracked state is written to the storage extension.
+	// The state is also written on shutdown. Set to 0 to only write it on shutdown.
+	StorageSnapshotInterval time.Duration `mapstructure:"storage_snapshot_interval"`
 }
 
 type MatchMetrics struct {
@@ -56,5 +81,25 @@ func (config *Config) Validate() error {
 		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
 		return fmt.Errorf("metrics must be supplied if match_type is set")
 	}
-	return nil
+	if config.StorageSnapshotInterval < 0 {
+		return fmt.Errorf("storage_snapshot_interval must not be negative")
+	}
+	if (config.MetricConditions != nil || config.DataPointConditions != nil) &&
+		(len(config.Include.Metrics) > 0 || len(config.Exclude.Metrics) > 0) {
+		return fmt.Errorf("cannot use ottl conditions and include/exclude for metrics at the same time")
+	}
+
+	var errors error
+
+	if config.MetricConditions != nil {
+		_, err := filterottl.NewBoolExprForMetric(config.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		errors = multierr.Append(errors, err)
+	}
+
+	if config.DataPointConditions != nil {
+		_, err := filterottl.NewBoolExprForDataPoint(config.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		errors = multierr.Append(errors, err)
+	}
+
+	return errors
 }
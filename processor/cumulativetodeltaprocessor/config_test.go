@@ -30,6 +30,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewIDWithName("file_storage", "cumulativetodelta")
+
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -58,7 +60,8 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
+				MaxStaleness:            10 * time.Second,
+				StorageSnapshotInterval: time.Minute,
 			},
 		},
 		{
@@ -86,9 +89,38 @@ func TestLoadConfig(t *testing.T) {
 						RegexpConfig: nil,
 					},
 				},
-				MaxStaleness: 10 * time.Second,
+				MaxStaleness:            10 * time.Second,
+				StorageSnapshotInterval: time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "storage"),
+			expected: &Config{
+				MaxStaleness:            time.Hour,
+				StorageID:               &storageID,
+				StorageSnapshotInterval: 30 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "conditions"),
+			expected: &Config{
+				MetricConditions: []string{
+					`resource.attributes["service.name"] == "checkout"`,
+				},
+				DataPointConditions: []string{
+					`attributes["state"] == "user"`,
+				},
+				StorageSnapshotInterval: time.Minute,
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "conditions_and_include"),
+			errorMessage: "cannot use ottl conditions and include/exclude for metrics at the same time",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "negative_snapshot_interval"),
+			errorMessage: "storage_snapshot_interval must not be negative",
+		},
 		{
 			id:           component.NewIDWithName(typeStr, "missing_match_type"),
 			errorMessage: "match_type must be set if metrics are supplied",
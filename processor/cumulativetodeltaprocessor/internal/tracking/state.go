@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// stateSnapshotVersion is the version of the serialized snapshot format.
+const stateSnapshotVersion = 1
+
+type stateSnapshot struct {
+	Version int             `json:"version"`
+	States  []snapshotState `json:"states"`
+}
+
+type snapshotState struct {
+	// Key is kept as bytes, the hashed identities are not valid UTF-8 strings.
+	Key       []byte        `json:"key"`
+	PrevPoint snapshotPoint `json:"prev_point"`
+}
+
+// snapshotPoint mirrors ValuePoint, with floats that can hold the non-finite values
+// JSON numbers cannot represent.
+type snapshotPoint struct {
+	ObservedTimestamp pcommon.Timestamp
+	FloatValue        snapshotFloat
+	IntValue          int64
+	HistogramValue    *snapshotHistogram
+}
+
+type snapshotHistogram struct {
+	Count   uint64
+	Sum     snapshotFloat
+	Buckets []uint64
+}
+
+// snapshotFloat is encoded as a JSON number when finite, and as the "NaN", "+Inf"
+// or "-Inf" string otherwise.
+type snapshotFloat float64
+
+func (f snapshotFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return json.Marshal(v)
+}
+
+func (f *snapshotFloat) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		var v float64
+		if err = json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*f = snapshotFloat(v)
+		return nil
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return err
+	}
+	*f = snapshotFloat(v)
+	return nil
+}
+
+func newSnapshotPoint(p ValuePoint) snapshotPoint {
+	sp := snapshotPoint{
+		ObservedTimestamp: p.ObservedTimestamp,
+		FloatValue:        snapshotFloat(p.FloatValue),
+		IntValue:          p.IntValue,
+	}
+	if p.HistogramValue != nil {
+		sp.HistogramValue = &snapshotHistogram{
+			Count:   p.HistogramValue.Count,
+			Sum:     snapshotFloat(p.HistogramValue.Sum),
+			Buckets: append([]uint64(nil), p.HistogramValue.Buckets...),
+		}
+	}
+	return sp
+}
+
+func (sp snapshotPoint) valuePoint() ValuePoint {
+	p := ValuePoint{
+		ObservedTimestamp: sp.ObservedTimestamp,
+		FloatValue:        float64(sp.FloatValue),
+		IntValue:          sp.IntValue,
+	}
+	if sp.HistogramValue != nil {
+		p.HistogramValue = &HistogramPoint{
+			Count:   sp.HistogramValue.Count,
+			Sum:     float64(sp.HistogramValue.Sum),
+			Buckets: sp.HistogramValue.Buckets,
+		}
+	}
+	return p
+}
+
+// MarshalState serializes the last point of every tracked series.
+func (t *MetricTracker) MarshalState() ([]byte, error) {
+	snapshot := stateSnapshot{Version: stateSnapshotVersion}
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+		s.Lock()
+		prevPoint := newSnapshotPoint(s.PrevPoint)
+		s.Unlock()
+		snapshot.States = append(snapshot.States, snapshotState{
+			Key:       []byte(key.(string)),
+			PrevPoint: prevPoint,
+		})
+		return true
+	})
+	return json.Marshal(snapshot)
+}
+
+// UnmarshalState restores the series serialized by MarshalState. Series last observed before
+// staleBefore are skipped, and series that are already tracked are left untouched.
+// It returns the number of restored series.
+func (t *MetricTracker) UnmarshalState(data []byte, staleBefore pcommon.Timestamp) (int, error) {
+	var snapshot stateSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return 0, err
+	}
+	if snapshot.Version != stateSnapshotVersion {
+		return 0, fmt.Errorf("unsupported state snapshot version: %d", snapshot.Version)
+	}
+
+	restored := 0
+	for _, s := range snapshot.States {
+		if s.PrevPoint.ObservedTimestamp < staleBefore {
+			continue
+		}
+		if _, loaded := t.states.LoadOrStore(string(s.Key), &State{PrevPoint: s.PrevPoint.valuePoint()}); !loaded {
+			restored++
+		}
+	}
+	return restored, nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestMetricTracker_MarshalUnmarshalState(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "test")
+	miSum := MetricIdentity{
+		Resource:               resource,
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "sum",
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+	}
+	miHistogram := miSum
+	miHistogram.MetricType = pmetric.MetricTypeHistogram
+	miHistogram.MetricName = "histogram"
+	miStale := miSum
+	miStale.MetricName = "stale"
+
+	src := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	src.Convert(MetricPoint{Identity: miSum, Value: ValuePoint{ObservedTimestamp: 10, IntValue: 100}})
+	src.Convert(MetricPoint{Identity: miHistogram, Value: ValuePoint{
+		ObservedTimestamp: 10,
+		HistogramValue:    &HistogramPoint{Count: 3, Sum: 6, Buckets: []uint64{1, 2}},
+	}})
+	src.Convert(MetricPoint{Identity: miStale, Value: ValuePoint{ObservedTimestamp: 5, IntValue: 100}})
+
+	data, err := src.MarshalState()
+	require.NoError(t, err)
+
+	dst := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	restored, err := dst.UnmarshalState(data, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+
+	out, valid := dst.Convert(MetricPoint{Identity: miSum, Value: ValuePoint{ObservedTimestamp: 20, IntValue: 150}})
+	require.True(t, valid)
+	assert.Equal(t, DeltaValue{StartTimestamp: 10, IntValue: 50}, out)
+
+	out, valid = dst.Convert(MetricPoint{Identity: miHistogram, Value: ValuePoint{
+		ObservedTimestamp: 20,
+		HistogramValue:    &HistogramPoint{Count: 6, Sum: 12, Buckets: []uint64{2, 4}},
+	}})
+	require.True(t, valid)
+	assert.Equal(t, &HistogramPoint{Count: 3, Sum: 6, Buckets: []uint64{1, 2}}, out.HistogramValue)
+
+	// The stale series was not restored, so its first point is dropped again.
+	_, valid = dst.Convert(MetricPoint{Identity: miStale, Value: ValuePoint{ObservedTimestamp: 20, IntValue: 150}})
+	assert.False(t, valid)
+
+	// Restoring again doesn't overwrite the tracked series.
+	restored, err = dst.UnmarshalState(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, restored)
+}
+
+func TestMetricTracker_MarshalUnmarshalStateNonFinite(t *testing.T) {
+	src := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	src.states.Store("nan", &State{PrevPoint: ValuePoint{ObservedTimestamp: 1, FloatValue: math.NaN()}})
+	src.states.Store("inf", &State{PrevPoint: ValuePoint{ObservedTimestamp: 1, FloatValue: math.Inf(1)}})
+	src.states.Store("histogram", &State{PrevPoint: ValuePoint{
+		ObservedTimestamp: 1,
+		HistogramValue:    &HistogramPoint{Count: 1, Sum: math.Inf(-1), Buckets: []uint64{1}},
+	}})
+	src.states.Store("finite", &State{PrevPoint: ValuePoint{ObservedTimestamp: 1, FloatValue: 1.5}})
+
+	data, err := src.MarshalState()
+	require.NoError(t, err)
+
+	dst := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	restored, err := dst.UnmarshalState(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored)
+
+	load := func(key string) ValuePoint {
+		s, ok := dst.states.Load(key)
+		require.True(t, ok)
+		return s.(*State).PrevPoint
+	}
+	assert.True(t, math.IsNaN(load("nan").FloatValue))
+	assert.True(t, math.IsInf(load("inf").FloatValue, 1))
+	assert.True(t, math.IsInf(load("histogram").HistogramValue.Sum, -1))
+	assert.Equal(t, ValuePoint{ObservedTimestamp: 1, FloatValue: 1.5}, load("finite"))
+}
+
+func TestMetricTracker_UnmarshalStateErrors(t *testing.T) {
+	tr := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	_, err := tr.UnmarshalState([]byte("{"), 0)
+	assert.Error(t, err)
+
+	_, err = tr.UnmarshalState([]byte(`{"version":2}`), 0)
+	assert.EqualError(t, err, "unsupported state snapshot version: 2")
+
+	// Keys are restored byte for byte.
+	key := string([]byte{0xff, SEP, 0x00, 'a'})
+	tr.states.Store(key, &State{PrevPoint: ValuePoint{ObservedTimestamp: 1}})
+	data, err := tr.MarshalState()
+	require.NoError(t, err)
+	dst := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	_, err = dst.UnmarshalState(data, 0)
+	require.NoError(t, err)
+	_, ok := dst.states.Load(key)
+	assert.True(t, ok)
+	assert.False(t, bytes.ContainsRune(data, 0xfffd))
+}
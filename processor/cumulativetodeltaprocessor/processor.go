@@ -17,12 +17,23 @@ package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentele
 import (
 	"context"
 	"math"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor/internal/tracking"
 )
 
@@ -37,19 +48,53 @@ var enableHistogramSupportGate = featuregate.GlobalRegistry().MustRegister(
 type cumulativeToDeltaProcessor struct {
 	includeFS               filterset.FilterSet
 	excludeFS               filterset.FilterSet
+	metricExpr              expr.BoolExpr[ottlmetric.TransformContext]
+	dataPointExpr           expr.BoolExpr[ottldatapoint.TransformContext]
 	logger                  *zap.Logger
 	deltaCalculator         *tracking.MetricTracker
+	ctx                     context.Context
 	cancelFunc              context.CancelFunc
 	histogramSupportEnabled bool
+
+	id                      component.ID
+	maxStaleness            time.Duration
+	storageID               *component.ID
+	storageSnapshotInterval time.Duration
+	storageClient           storage.Client
+	snapshotWG              sync.WaitGroup
 }
 
-func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulativeToDeltaProcessor {
+func newCumulativeToDeltaProcessor(config *Config, set processor.CreateSettings) (*cumulativeToDeltaProcessor, error) {
+	var metricExpr expr.BoolExpr[ottlmetric.TransformContext]
+	if config.MetricConditions != nil {
+		var err error
+		metricExpr, err = filterottl.NewBoolExprForMetric(config.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var dataPointExpr expr.BoolExpr[ottldatapoint.TransformContext]
+	if config.DataPointConditions != nil {
+		var err error
+		dataPointExpr, err = filterottl.NewBoolExprForDataPoint(config.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, set.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	p := &cumulativeToDeltaProcessor{
-		logger:                  logger,
-		deltaCalculator:         tracking.NewMetricTracker(ctx, logger, config.MaxStaleness),
+		metricExpr:              metricExpr,
+		dataPointExpr:           dataPointExpr,
+		logger:                  set.Logger,
+		deltaCalculator:         tracking.NewMetricTracker(ctx, set.Logger, config.MaxStaleness),
+		ctx:                     ctx,
 		cancelFunc:              cancel,
 		histogramSupportEnabled: enableHistogramSupportGate.IsEnabled(),
+		id:                      set.ID,
+		maxStaleness:            config.MaxStaleness,
+		storageID:               config.StorageID,
+		storageSnapshotInterval: config.StorageSnapshotInterval,
 	}
 	if len(config.Include.Metrics) > 0 {
 		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
@@ -57,15 +102,17 @@ func newCumulativeToDeltaProcessor(config *Config, logger *zap.Logger) *cumulati
 	if len(config.Exclude.Metrics) > 0 {
 		p.excludeFS, _ = filterset.CreateFilterSet(config.Exclude.Metrics, &config.Exclude.Config)
 	}
-	return p
+	return p, nil
 }
 
 // processMetrics implements the ProcessMetricsFunc type.
-func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (ctdp *cumulativeToDeltaProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
 		rm.ScopeMetrics().RemoveIf(func(ilm pmetric.ScopeMetrics) bool {
+			// unconverted holds the datapoints split from converted metrics because they did not match the datapoint conditions.
+			unconverted := pmetric.NewMetricSlice()
 			ilm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
-				if !ctdp.shouldConvertMetric(m.Name()) {
+				if !ctdp.shouldConvertMetric(ctx, m, ilm, rm.Resource()) {
 					return false
 				}
 				switch m.Type() {
@@ -80,6 +127,10 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 						return false
 					}
 
+					if !ctdp.splitDataPoints(ctx, m, ilm, rm.Resource(), unconverted) {
+						return false
+					}
+
 					baseIdentity := tracking.MetricIdentity{
 						Resource:               rm.Resource(),
 						InstrumentationLibrary: ilm.Scope(),
@@ -105,6 +156,10 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 						return false
 					}
 
+					if !ctdp.splitDataPoints(ctx, m, ilm, rm.Resource(), unconverted) {
+						return false
+					}
+
 					baseIdentity := tracking.MetricIdentity{
 						Resource:               rm.Resource(),
 						InstrumentationLibrary: ilm.Scope(),
@@ -123,6 +178,7 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 					return false
 				}
 			})
+			unconverted.MoveAndAppendTo(ilm.Metrics())
 			return ilm.Metrics().Len() == 0
 		})
 		return rm.ScopeMetrics().Len() == 0
@@ -130,16 +186,112 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 	return md, nil
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shutdown(context.Context) error {
+func (ctdp *cumulativeToDeltaProcessor) shutdown(ctx context.Context) error {
 	ctdp.cancelFunc()
-	return nil
+	ctdp.snapshotWG.Wait()
+	return ctdp.closeStorage(ctx)
 }
 
-func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(metricName string) bool {
+func (ctdp *cumulativeToDeltaProcessor) shouldConvertMetric(ctx context.Context, m pmetric.Metric, ilm pmetric.ScopeMetrics, resource pcommon.Resource) bool {
+	if ctdp.metricExpr != nil {
+		match, err := ctdp.metricExpr.Eval(ctx, ottlmetric.NewTransformContext(m, ilm.Scope(), resource))
+		if err != nil {
+			ctdp.logger.Error("failed evaluating metric conditions, the metric is not converted", zap.String("metric", m.Name()), zap.Error(err))
+			return false
+		}
+		return match
+	}
+	metricName := m.Name()
 	return (ctdp.includeFS == nil || ctdp.includeFS.Matches(metricName)) &&
 		(ctdp.excludeFS == nil || !ctdp.excludeFS.Matches(metricName))
 }
 
+// splitDataPoints evaluates the datapoint conditions against every datapoint of the metric. The datapoints
+// that do not match are moved to a cumulative copy of the metric appended to unconverted.
+// It returns false if no datapoint of the metric is left to convert.
+func (ctdp *cumulativeToDeltaProcessor) splitDataPoints(ctx context.Context, m pmetric.Metric, ilm pmetric.ScopeMetrics, resource pcommon.Resource, unconverted pmetric.MetricSlice) bool {
+	if ctdp.dataPointExpr == nil {
+		return true
+	}
+	matchDataPoint := func(dp interface{}) bool {
+		match, err := ctdp.dataPointExpr.Eval(ctx, ottldatapoint.NewTransformContext(dp, m, ilm.Metrics(), ilm.Scope(), resource))
+		if err != nil {
+			ctdp.logger.Error("failed evaluating datapoint conditions, the datapoint is not converted", zap.String("metric", m.Name()), zap.Error(err))
+			return false
+		}
+		return match
+	}
+
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		matches := make([]bool, dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			matches[i] = matchDataPoint(dps.At(i))
+		}
+		if allEqual(matches, false) {
+			return false
+		}
+		if allEqual(matches, true) {
+			return true
+		}
+		cumulative := newCumulativeCopy(m, unconverted)
+		cumulative.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		cumulative.Sum().SetIsMonotonic(m.Sum().IsMonotonic())
+		i := 0
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			match := matches[i]
+			i++
+			if !match {
+				dp.MoveTo(cumulative.Sum().DataPoints().AppendEmpty())
+			}
+			return !match
+		})
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		matches := make([]bool, dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			matches[i] = matchDataPoint(dps.At(i))
+		}
+		if allEqual(matches, false) {
+			return false
+		}
+		if allEqual(matches, true) {
+			return true
+		}
+		cumulative := newCumulativeCopy(m, unconverted)
+		cumulative.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		i := 0
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			match := matches[i]
+			i++
+			if !match {
+				dp.MoveTo(cumulative.Histogram().DataPoints().AppendEmpty())
+			}
+			return !match
+		})
+	}
+	return true
+}
+
+// newCumulativeCopy appends to metrics a metric with the same name, description and unit as m, and no data.
+func newCumulativeCopy(m pmetric.Metric, metrics pmetric.MetricSlice) pmetric.Metric {
+	cumulative := metrics.AppendEmpty()
+	cumulative.SetName(m.Name())
+	cumulative.SetDescription(m.Description())
+	cumulative.SetUnit(m.Unit())
+	return cumulative
+}
+
+func allEqual(values []bool, value bool) bool {
+	for _, v := range values {
+		if v != value {
+			return false
+		}
+	}
+	return true
+}
+
 func (ctdp *cumulativeToDeltaProcessor) convertDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
 	if dps, ok := in.(pmetric.NumberDataPointSlice); ok {
 		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
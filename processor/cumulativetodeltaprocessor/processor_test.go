@@ -545,6 +545,103 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorConditions(t *testing.T) {
+	// generateMetrics returns a cumulative sum per service, with one datapoint per state.
+	generateMetrics := func(user, system float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		for _, service := range []string{"checkout", "cart"} {
+			rm := md.ResourceMetrics().AppendEmpty()
+			rm.Resource().Attributes().PutStr("service.name", service)
+			m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			m.SetName("cpu.time")
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			for state, value := range map[string]float64{"user": user, "system": system} {
+				dp := sum.DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("state", state)
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+				dp.SetDoubleValue(value)
+			}
+		}
+		return md
+	}
+
+	// sumValues returns the values of the sums of a service by temporality and state.
+	sumValues := func(md pmetric.Metrics, service string) map[string]float64 {
+		values := map[string]float64{}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			if name, _ := rm.Resource().Attributes().Get("service.name"); name.Str() != service {
+				continue
+			}
+			metrics := rm.ScopeMetrics().At(0).Metrics()
+			for j := 0; j < metrics.Len(); j++ {
+				sum := metrics.At(j).Sum()
+				for k := 0; k < sum.DataPoints().Len(); k++ {
+					dp := sum.DataPoints().At(k)
+					state, _ := dp.Attributes().Get("state")
+					values[sum.AggregationTemporality().String()+"/"+state.Str()] = dp.DoubleValue()
+				}
+			}
+		}
+		return values
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		checkout map[string]float64
+		cart     map[string]float64
+	}{
+		{
+			name: "metric_conditions",
+			cfg: &Config{
+				MetricConditions: []string{`resource.attributes["service.name"] == "checkout"`},
+			},
+			checkout: map[string]float64{"Delta/user": 5, "Delta/system": 10},
+			cart:     map[string]float64{"Cumulative/user": 15, "Cumulative/system": 30},
+		},
+		{
+			name: "datapoint_conditions",
+			cfg: &Config{
+				DataPointConditions: []string{`attributes["state"] == "user"`},
+			},
+			checkout: map[string]float64{"Delta/user": 5, "Cumulative/system": 30},
+			cart:     map[string]float64{"Delta/user": 5, "Cumulative/system": 30},
+		},
+		{
+			name: "metric_and_datapoint_conditions",
+			cfg: &Config{
+				MetricConditions:    []string{`resource.attributes["service.name"] == "checkout"`},
+				DataPointConditions: []string{`attributes["state"] == "system"`},
+			},
+			checkout: map[string]float64{"Cumulative/user": 15, "Delta/system": 10},
+			cart:     map[string]float64{"Cumulative/user": 15, "Cumulative/system": 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.cfg.Validate())
+			next := new(consumertest.MetricsSink)
+			p, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), tt.cfg, next)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(context.Background(), nil))
+
+			require.NoError(t, p.ConsumeMetrics(context.Background(), generateMetrics(10, 20)))
+			require.NoError(t, p.ConsumeMetrics(context.Background(), generateMetrics(15, 30)))
+
+			got := next.AllMetrics()
+			require.Len(t, got, 2)
+			assert.Equal(t, tt.checkout, sumValues(got[1], "checkout"))
+			assert.Equal(t, tt.cart, sumValues(got[1], "cart"))
+
+			require.NoError(t, p.Shutdown(context.Background()))
+		})
+	}
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
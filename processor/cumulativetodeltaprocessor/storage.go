@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// stateStorageKey is the key under which the tracked state is stored in the storage client.
+const stateStorageKey = "tracker_state"
+
+func (ctdp *cumulativeToDeltaProcessor) start(ctx context.Context, host component.Host) error {
+	if ctdp.storageID == nil {
+		return nil
+	}
+
+	client, err := getStorageClient(ctx, host, *ctdp.storageID, ctdp.id)
+	if err != nil {
+		return err
+	}
+	ctdp.storageClient = client
+
+	// A state that cannot be restored only costs the first delta of every series,
+	// so it must not prevent the processor from starting.
+	if err = ctdp.loadState(ctx); err != nil {
+		ctdp.logger.Warn("failed to restore state from storage", zap.Error(err))
+	}
+
+	if ctdp.storageSnapshotInterval > 0 {
+		ctdp.snapshotWG.Add(1)
+		go ctdp.snapshotLoop()
+	}
+	return nil
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// loadState restores the tracked state from the storage client, skipping the series that are already stale.
+func (ctdp *cumulativeToDeltaProcessor) loadState(ctx context.Context) error {
+	data, err := ctdp.storageClient.Get(ctx, stateStorageKey)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return nil
+	}
+
+	var staleBefore pcommon.Timestamp
+	if ctdp.maxStaleness > 0 {
+		staleBefore = pcommon.NewTimestampFromTime(time.Now().Add(-ctdp.maxStaleness))
+	}
+	restored, err := ctdp.deltaCalculator.UnmarshalState(data, staleBefore)
+	if err != nil {
+		return err
+	}
+	ctdp.logger.Debug("restored state from storage", zap.Int("series", restored))
+	return nil
+}
+
+// saveState writes the tracked state to the storage client.
+func (ctdp *cumulativeToDeltaProcessor) saveState(ctx context.Context) error {
+	data, err := ctdp.deltaCalculator.MarshalState()
+	if err != nil {
+		return err
+	}
+	return ctdp.storageClient.Set(ctx, stateStorageKey, data)
+}
+
+// snapshotLoop periodically writes the tracked state to the storage client until the processor is shut down.
+func (ctdp *cumulativeToDeltaProcessor) snapshotLoop() {
+	defer ctdp.snapshotWG.Done()
+
+	ticker := time.NewTicker(ctdp.storageSnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ctdp.saveState(ctdp.ctx); err != nil {
+				ctdp.logger.Warn("failed to write state to storage", zap.Error(err))
+			}
+		case <-ctdp.ctx.Done():
+			return
+		}
+	}
+}
+
+// closeStorage writes the final state to the storage client and closes it.
+func (ctdp *cumulativeToDeltaProcessor) closeStorage(ctx context.Context) error {
+	if ctdp.storageClient == nil {
+		return nil
+	}
+	err := ctdp.saveState(ctx)
+	return multierr.Append(err, ctdp.storageClient.Close(ctx))
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cumulativetodeltaprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newSumMetrics(value int64, timestamp time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric_1")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetIntValue(value)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+	return md
+}
+
+func startProcessor(t *testing.T, cfg *Config, host component.Host, next *consumertest.MetricsSink) processor.Metrics {
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), host))
+	return p
+}
+
+func TestStatePersistedAcrossRestarts(t *testing.T) {
+	storageID := storagetest.NewStorageID("state")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("state", t.TempDir())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.MaxStaleness = time.Hour
+
+	next := new(consumertest.MetricsSink)
+	p := startProcessor(t, cfg, host, next)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newSumMetrics(100, time.Now())))
+	// The first point of a series is dropped.
+	assert.Equal(t, 0, next.DataPointCount())
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	next = new(consumertest.MetricsSink)
+	p = startProcessor(t, cfg, host, next)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newSumMetrics(150, time.Now())))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	// The restored state allows computing the delta of the first point after the restart.
+	require.Equal(t, 1, next.DataPointCount())
+	dp := next.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.Equal(t, int64(50), dp.IntValue())
+}
+
+func TestStaleStateNotRestored(t *testing.T) {
+	storageID := storagetest.NewStorageID("state")
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("state", t.TempDir())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.MaxStaleness = time.Minute
+
+	next := new(consumertest.MetricsSink)
+	p := startProcessor(t, cfg, host, next)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newSumMetrics(100, time.Now().Add(-time.Hour))))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	next = new(consumertest.MetricsSink)
+	p = startProcessor(t, cfg, host, next)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newSumMetrics(150, time.Now())))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	assert.Equal(t, 0, next.DataPointCount())
+}
+
+func TestStateSnapshotInterval(t *testing.T) {
+	storageID := storagetest.NewStorageID("state")
+	host := storagetest.NewStorageHost().WithInMemoryStorageExtension("state")
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+	cfg.StorageSnapshotInterval = time.Millisecond
+
+	set := processortest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	p, err := newCumulativeToDeltaProcessor(cfg, set)
+	require.NoError(t, err)
+	require.NoError(t, p.start(context.Background(), host))
+	_, err = p.processMetrics(context.Background(), newSumMetrics(100, time.Now()))
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		data, err := p.storageClient.Get(context.Background(), stateStorageKey)
+		return err == nil && data != nil
+	}, time.Second, time.Millisecond)
+	require.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestStorageExtensionErrors(t *testing.T) {
+	storageID := storagetest.NewNonStorageID("state")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	p, err := NewFactory().CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	err = p.Start(context.Background(), storagetest.NewStorageHost())
+	assert.EqualError(t, err, "storage extension 'non_storage/state' not found")
+
+	err = p.Start(context.Background(), storagetest.NewStorageHost().WithNonStorageExtension("state"))
+	assert.EqualError(t, err, "non-storage extension 'non_storage/state' found")
+}
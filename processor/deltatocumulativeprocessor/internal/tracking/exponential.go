@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// maxExponentialBuckets bounds the number of positive and negative buckets of an accumulated
+	// exponential histogram. The histogram is downscaled when merging a point would exceed it.
+	maxExponentialBuckets = 160
+	// minExponentialScale is the smallest scale an accumulated exponential histogram is downscaled to.
+	minExponentialScale = -10
+)
+
+// mergeExponentialHistogram adds the counts of dp to acc, downscaling both to a common scale.
+// If first is true, acc holds no counts yet and takes the values of dp.
+func mergeExponentialHistogram(acc, dp pmetric.ExponentialHistogramDataPoint, first bool) {
+	scale := acc.Scale()
+	if dp.Scale() < scale {
+		scale = dp.Scale()
+	}
+	for scale > minExponentialScale &&
+		(!fitsBuckets(acc.Positive(), acc.Scale(), dp.Positive(), dp.Scale(), scale) ||
+			!fitsBuckets(acc.Negative(), acc.Scale(), dp.Negative(), dp.Scale(), scale)) {
+		scale--
+	}
+
+	downscaleBuckets(acc.Positive(), acc.Scale()-scale)
+	downscaleBuckets(acc.Negative(), acc.Scale()-scale)
+	acc.SetScale(scale)
+	addBuckets(acc.Positive(), dp.Positive(), dp.Scale()-scale)
+	addBuckets(acc.Negative(), dp.Negative(), dp.Scale()-scale)
+
+	acc.SetCount(acc.Count() + dp.Count())
+	acc.SetZeroCount(acc.ZeroCount() + dp.ZeroCount())
+	if first {
+		if dp.HasSum() {
+			acc.SetSum(dp.Sum())
+		}
+		if dp.HasMin() {
+			acc.SetMin(dp.Min())
+		}
+		if dp.HasMax() {
+			acc.SetMax(dp.Max())
+		}
+		return
+	}
+	if acc.HasSum() && dp.HasSum() {
+		acc.SetSum(acc.Sum() + dp.Sum())
+	} else {
+		acc.RemoveSum()
+	}
+	if acc.HasMin() && dp.HasMin() {
+		acc.SetMin(math.Min(acc.Min(), dp.Min()))
+	} else {
+		acc.RemoveMin()
+	}
+	if acc.HasMax() && dp.HasMax() {
+		acc.SetMax(math.Max(acc.Max(), dp.Max()))
+	} else {
+		acc.RemoveMax()
+	}
+}
+
+// bucketRange returns the first and last bucket indexes of buckets at the given scale once
+// downscaled by change. It returns false if there is no bucket.
+func bucketRange(buckets pmetric.ExponentialHistogramDataPointBuckets, change int32) (int32, int32, bool) {
+	if buckets.BucketCounts().Len() == 0 {
+		return 0, 0, false
+	}
+	low := buckets.Offset()
+	high := low + int32(buckets.BucketCounts().Len()) - 1
+	return low >> change, high >> change, true
+}
+
+// fitsBuckets reports whether the union of a and b, respectively at scales aScale and bScale,
+// holds at most maxExponentialBuckets buckets at the given scale.
+func fitsBuckets(a pmetric.ExponentialHistogramDataPointBuckets, aScale int32, b pmetric.ExponentialHistogramDataPointBuckets, bScale int32, scale int32) bool {
+	aLow, aHigh, aOk := bucketRange(a, aScale-scale)
+	bLow, bHigh, bOk := bucketRange(b, bScale-scale)
+	switch {
+	case aOk && bOk:
+		return int64(max32(aHigh, bHigh))-int64(min32(aLow, bLow)) < maxExponentialBuckets
+	case aOk:
+		return int64(aHigh)-int64(aLow) < maxExponentialBuckets
+	case bOk:
+		return int64(bHigh)-int64(bLow) < maxExponentialBuckets
+	}
+	return true
+}
+
+// downscaleBuckets merges the buckets in place so that they are indexed at a scale reduced by change.
+func downscaleBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, change int32) {
+	if change <= 0 {
+		return
+	}
+	low, high, ok := bucketRange(buckets, change)
+	if !ok {
+		return
+	}
+	counts := make([]uint64, high-low+1)
+	for i := 0; i < buckets.BucketCounts().Len(); i++ {
+		index := (buckets.Offset() + int32(i)) >> change
+		counts[index-low] += buckets.BucketCounts().At(i)
+	}
+	buckets.SetOffset(low)
+	buckets.BucketCounts().FromRaw(counts)
+}
+
+// addBuckets adds the counts of src, downscaled by change, to dst.
+func addBuckets(dst, src pmetric.ExponentialHistogramDataPointBuckets, change int32) {
+	srcLow, srcHigh, ok := bucketRange(src, change)
+	if !ok {
+		return
+	}
+	low, high := srcLow, srcHigh
+	if dstLow, dstHigh, ok := bucketRange(dst, 0); ok {
+		low, high = min32(low, dstLow), max32(high, dstHigh)
+	}
+
+	counts := make([]uint64, high-low+1)
+	for i := 0; i < dst.BucketCounts().Len(); i++ {
+		counts[dst.Offset()+int32(i)-low] += dst.BucketCounts().At(i)
+	}
+	for i := 0; i < src.BucketCounts().Len(); i++ {
+		index := (src.Offset() + int32(i)) >> change
+		counts[index-low] += src.BucketCounts().At(i)
+	}
+	dst.SetOffset(low)
+	dst.BucketCounts().FromRaw(counts)
+}
+
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newBuckets(offset int32, counts ...uint64) pmetric.ExponentialHistogramDataPointBuckets {
+	buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+	buckets.SetOffset(offset)
+	buckets.BucketCounts().FromRaw(counts)
+	return buckets
+}
+
+func TestDownscaleBuckets(t *testing.T) {
+	buckets := newBuckets(-3, 1, 2, 3, 4, 5)
+	downscaleBuckets(buckets, 1)
+	// Indexes -3, -2, -1, 0 and 1 become -2, -1, -1, 0 and 0.
+	assert.Equal(t, int32(-2), buckets.Offset())
+	assert.Equal(t, []uint64{1, 5, 9}, buckets.BucketCounts().AsRaw())
+}
+
+func TestAddBuckets(t *testing.T) {
+	dst := newBuckets(4, 1, 1)
+	addBuckets(dst, newBuckets(0, 2, 0, 3), 1)
+	assert.Equal(t, int32(0), dst.Offset())
+	assert.Equal(t, []uint64{2, 3, 0, 0, 1, 1}, dst.BucketCounts().AsRaw())
+
+	empty := pmetric.NewExponentialHistogramDataPointBuckets()
+	addBuckets(empty, newBuckets(2, 1), 0)
+	assert.Equal(t, int32(2), empty.Offset())
+	assert.Equal(t, []uint64{1}, empty.BucketCounts().AsRaw())
+}
+
+func TestMergeExponentialHistogramLimitsBuckets(t *testing.T) {
+	acc := pmetric.NewExponentialHistogramDataPoint()
+	acc.SetScale(4)
+	newBuckets(0, 1).CopyTo(acc.Positive())
+	acc.SetCount(1)
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(4)
+	newBuckets(1000, 1).CopyTo(dp.Positive())
+	dp.SetCount(1)
+
+	mergeExponentialHistogram(acc, dp, false)
+	// 1000 >> 3 = 125 is the first scale holding both buckets within maxExponentialBuckets.
+	assert.Equal(t, int32(1), acc.Scale())
+	assert.Equal(t, int32(0), acc.Positive().Offset())
+	assert.Equal(t, 126, acc.Positive().BucketCounts().Len())
+	assert.Equal(t, uint64(2), acc.Count())
+}
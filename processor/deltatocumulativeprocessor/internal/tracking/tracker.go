@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// Allocate a minimum of 64 bytes to the builder initially
+const initialBytes = 64
+
+var identityBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, initialBytes))
+	},
+}
+
+// Result is the outcome of accumulating a delta datapoint into its stream.
+type Result int
+
+const (
+	// Accumulated means the datapoint was added to its stream and now holds the cumulative value.
+	Accumulated Result = iota
+	// OutOfOrder means the datapoint ends at or before the end of the last point accumulated into its stream.
+	OutOfOrder
+	// Overlapping means the datapoint starts before the end of the last point accumulated into its
+	// stream, so adding it would count part of the interval twice.
+	Overlapping
+)
+
+func (r Result) String() string {
+	switch r {
+	case Accumulated:
+		return "accumulated"
+	case OutOfOrder:
+		return "out of order"
+	case Overlapping:
+		return "overlapping"
+	}
+	return ""
+}
+
+// stream holds the cumulative value of a stream of delta datapoints.
+type stream struct {
+	// start is the start timestamp of the cumulative stream.
+	start pcommon.Timestamp
+	// last is the timestamp of the last datapoint accumulated into the stream.
+	last pcommon.Timestamp
+	// lastSeen is the time at which the stream was last updated, used to expire stale streams.
+	lastSeen time.Time
+
+	intValue     int64
+	doubleValue  float64
+	histogram    pmetric.HistogramDataPoint
+	expHistogram pmetric.ExponentialHistogramDataPoint
+}
+
+// MetricTracker accumulates delta datapoints into cumulative streams.
+type MetricTracker struct {
+	logger       *zap.Logger
+	maxStaleness time.Duration
+
+	mu      sync.Mutex
+	streams map[string]*stream
+}
+
+// NewMetricTracker creates a MetricTracker. If maxStaleness is positive, streams that have not been
+// updated for longer than maxStaleness are removed until ctx is done.
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
+	t := &MetricTracker{
+		logger:       logger,
+		maxStaleness: maxStaleness,
+		streams:      make(map[string]*stream),
+	}
+	if maxStaleness > 0 {
+		go t.sweeper(ctx)
+	}
+	return t
+}
+
+// AccumulateSum adds the delta value of dp to its stream and rewrites dp with the cumulative value.
+func (t *MetricTracker) AccumulateSum(id MetricIdentity, dp pmetric.NumberDataPoint) Result {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := identityKey(&id)
+	if dp.Flags().NoRecordedValue() {
+		t.remove(key)
+		return Accumulated
+	}
+	s, _, result := t.lookup(key, dp.StartTimestamp(), dp.Timestamp())
+	if result != Accumulated {
+		return result
+	}
+
+	if id.IsFloatVal() {
+		// NaN is used to signal "stale" metrics, it is passed through without being accumulated.
+		if !math.IsNaN(dp.DoubleValue()) {
+			s.doubleValue += dp.DoubleValue()
+			dp.SetDoubleValue(s.doubleValue)
+		}
+	} else {
+		s.intValue += dp.IntValue()
+		dp.SetIntValue(s.intValue)
+	}
+	dp.SetStartTimestamp(s.start)
+	return Accumulated
+}
+
+// AccumulateHistogram adds the delta counts of dp to its stream and rewrites dp with the cumulative counts.
+// If the bucket boundaries of dp differ from those of its stream, the stream is restarted from dp.
+func (t *MetricTracker) AccumulateHistogram(id MetricIdentity, dp pmetric.HistogramDataPoint) Result {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := identityKey(&id)
+	if dp.Flags().NoRecordedValue() {
+		t.remove(key)
+		return Accumulated
+	}
+	s, isNew, result := t.lookup(key, dp.StartTimestamp(), dp.Timestamp())
+	if result != Accumulated {
+		return result
+	}
+
+	if !isNew && !equalBounds(dp.ExplicitBounds(), s.histogram.ExplicitBounds()) {
+		t.logger.Debug("histogram bucket boundaries changed, restarting the cumulative stream", zap.Any("bounds", dp.ExplicitBounds().AsRaw()))
+		s.start = startOf(dp.StartTimestamp(), dp.Timestamp())
+		isNew = true
+	}
+	if isNew {
+		s.histogram = pmetric.NewHistogramDataPoint()
+		s.histogram.ExplicitBounds().FromRaw(dp.ExplicitBounds().AsRaw())
+		s.histogram.BucketCounts().FromRaw(dp.BucketCounts().AsRaw())
+		s.histogram.SetCount(dp.Count())
+		if dp.HasSum() {
+			s.histogram.SetSum(dp.Sum())
+		}
+		if dp.HasMin() {
+			s.histogram.SetMin(dp.Min())
+		}
+		if dp.HasMax() {
+			s.histogram.SetMax(dp.Max())
+		}
+	} else {
+		acc := s.histogram
+		counts := acc.BucketCounts().AsRaw()
+		for i := 0; i < dp.BucketCounts().Len() && i < len(counts); i++ {
+			counts[i] += dp.BucketCounts().At(i)
+		}
+		acc.BucketCounts().FromRaw(counts)
+		acc.SetCount(acc.Count() + dp.Count())
+		if acc.HasSum() && dp.HasSum() {
+			acc.SetSum(acc.Sum() + dp.Sum())
+		} else {
+			acc.RemoveSum()
+		}
+		if acc.HasMin() && dp.HasMin() {
+			acc.SetMin(math.Min(acc.Min(), dp.Min()))
+		} else {
+			acc.RemoveMin()
+		}
+		if acc.HasMax() && dp.HasMax() {
+			acc.SetMax(math.Max(acc.Max(), dp.Max()))
+		} else {
+			acc.RemoveMax()
+		}
+	}
+
+	acc := s.histogram
+	dp.SetStartTimestamp(s.start)
+	dp.SetCount(acc.Count())
+	dp.BucketCounts().FromRaw(acc.BucketCounts().AsRaw())
+	if acc.HasSum() {
+		dp.SetSum(acc.Sum())
+	} else {
+		dp.RemoveSum()
+	}
+	if acc.HasMin() {
+		dp.SetMin(acc.Min())
+	} else {
+		dp.RemoveMin()
+	}
+	if acc.HasMax() {
+		dp.SetMax(acc.Max())
+	} else {
+		dp.RemoveMax()
+	}
+	return Accumulated
+}
+
+// AccumulateExponentialHistogram adds the delta counts of dp to its stream and rewrites dp with the
+// cumulative counts. The stream is downscaled as needed to merge datapoints of different scales.
+func (t *MetricTracker) AccumulateExponentialHistogram(id MetricIdentity, dp pmetric.ExponentialHistogramDataPoint) Result {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := identityKey(&id)
+	if dp.Flags().NoRecordedValue() {
+		t.remove(key)
+		return Accumulated
+	}
+	s, isNew, result := t.lookup(key, dp.StartTimestamp(), dp.Timestamp())
+	if result != Accumulated {
+		return result
+	}
+
+	if isNew {
+		s.expHistogram = pmetric.NewExponentialHistogramDataPoint()
+		s.expHistogram.SetScale(dp.Scale())
+	}
+	mergeExponentialHistogram(s.expHistogram, dp, isNew)
+
+	acc := s.expHistogram
+	dp.SetStartTimestamp(s.start)
+	dp.SetScale(acc.Scale())
+	dp.SetCount(acc.Count())
+	dp.SetZeroCount(acc.ZeroCount())
+	acc.Positive().CopyTo(dp.Positive())
+	acc.Negative().CopyTo(dp.Negative())
+	if acc.HasSum() {
+		dp.SetSum(acc.Sum())
+	} else {
+		dp.RemoveSum()
+	}
+	if acc.HasMin() {
+		dp.SetMin(acc.Min())
+	} else {
+		dp.RemoveMin()
+	}
+	if acc.HasMax() {
+		dp.SetMax(acc.Max())
+	} else {
+		dp.RemoveMax()
+	}
+	return Accumulated
+}
+
+// lookup returns the stream identified by key, creating it if needed, and checks that a datapoint
+// covering (start, timestamp] can be accumulated into it.
+func (t *MetricTracker) lookup(key string, start, timestamp pcommon.Timestamp) (*stream, bool, Result) {
+	s, ok := t.streams[key]
+	if !ok {
+		s = &stream{start: startOf(start, timestamp)}
+		t.streams[key] = s
+	} else {
+		if timestamp <= s.last {
+			return nil, false, OutOfOrder
+		}
+		if start != 0 && start < s.last {
+			return nil, false, Overlapping
+		}
+	}
+	s.last = timestamp
+	s.lastSeen = time.Now()
+	return s, !ok, Accumulated
+}
+
+func (t *MetricTracker) remove(key string) {
+	delete(t.streams, key)
+}
+
+// RemoveStale removes the streams last updated before staleBefore.
+func (t *MetricTracker) RemoveStale(staleBefore time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, s := range t.streams {
+		if s.lastSeen.Before(staleBefore) {
+			t.logger.Debug("removing stale stream", zap.String("key", key))
+			delete(t.streams, key)
+		}
+	}
+}
+
+func (t *MetricTracker) sweeper(ctx context.Context) {
+	ticker := time.NewTicker(t.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			t.RemoveStale(currentTime.Add(-t.maxStaleness))
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+func equalBounds(a, b pcommon.Float64Slice) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.At(i) != b.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func identityKey(id *MetricIdentity) string {
+	b := identityBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	id.Write(b)
+	key := b.String()
+	identityBufferPool.Put(b)
+	return key
+}
+
+// startOf returns the start timestamp of a cumulative stream beginning with a delta point.
+// Points without a start timestamp start the stream at their own timestamp.
+func startOf(start, timestamp pcommon.Timestamp) pcommon.Timestamp {
+	if start == 0 || start > timestamp {
+		return timestamp
+	}
+	return start
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func newSumIdentity() MetricIdentity {
+	return MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeSum,
+		MetricIsMonotonic:      true,
+		MetricName:             "requests",
+		Attributes:             pcommon.NewMap(),
+		MetricValueType:        pmetric.NumberDataPointValueTypeDouble,
+	}
+}
+
+func newSumPoint(start, ts pcommon.Timestamp, value float64) pmetric.NumberDataPoint {
+	dp := pmetric.NewNumberDataPoint()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(value)
+	return dp
+}
+
+func TestMetricTracker_AccumulateSum(t *testing.T) {
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	id := newSumIdentity()
+
+	tests := []struct {
+		name          string
+		point         pmetric.NumberDataPoint
+		wantResult    Result
+		wantStart     pcommon.Timestamp
+		wantCumulated float64
+	}{
+		{
+			name:          "first point without start timestamp",
+			point:         newSumPoint(0, 10, 1),
+			wantResult:    Accumulated,
+			wantStart:     10,
+			wantCumulated: 1,
+		},
+		{
+			name:          "contiguous point",
+			point:         newSumPoint(10, 20, 2),
+			wantResult:    Accumulated,
+			wantStart:     10,
+			wantCumulated: 3,
+		},
+		{
+			name:       "duplicate point",
+			point:      newSumPoint(10, 20, 2),
+			wantResult: OutOfOrder,
+		},
+		{
+			name:       "overlapping point",
+			point:      newSumPoint(15, 30, 2),
+			wantResult: Overlapping,
+		},
+		{
+			name:          "point without start timestamp",
+			point:         newSumPoint(0, 30, 4),
+			wantResult:    Accumulated,
+			wantStart:     10,
+			wantCumulated: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tracker.AccumulateSum(id, tt.point)
+			require.Equal(t, tt.wantResult, result)
+			if result != Accumulated {
+				return
+			}
+			assert.Equal(t, tt.wantStart, tt.point.StartTimestamp())
+			assert.Equal(t, tt.wantCumulated, tt.point.DoubleValue())
+		})
+	}
+}
+
+func TestMetricTracker_NoRecordedValueEndsStream(t *testing.T) {
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	id := newSumIdentity()
+
+	require.Equal(t, Accumulated, tracker.AccumulateSum(id, newSumPoint(1, 10, 1)))
+
+	noValue := newSumPoint(10, 20, 0)
+	noValue.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	require.Equal(t, Accumulated, tracker.AccumulateSum(id, noValue))
+	assert.Equal(t, pcommon.Timestamp(10), noValue.StartTimestamp())
+
+	dp := newSumPoint(20, 30, 5)
+	require.Equal(t, Accumulated, tracker.AccumulateSum(id, dp))
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, 5.0, dp.DoubleValue())
+}
+
+func TestMetricTracker_RemoveStale(t *testing.T) {
+	tracker := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+	id := newSumIdentity()
+
+	require.Equal(t, Accumulated, tracker.AccumulateSum(id, newSumPoint(1, 10, 1)))
+	tracker.RemoveStale(time.Now().Add(-time.Hour))
+	assert.Len(t, tracker.streams, 1)
+
+	tracker.RemoveStale(time.Now().Add(time.Second))
+	assert.Len(t, tracker.streams, 0)
+
+	// The stream restarts with the start timestamp of the next point.
+	dp := newSumPoint(20, 30, 2)
+	require.Equal(t, Accumulated, tracker.AccumulateSum(id, dp))
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, 2.0, dp.DoubleValue())
+}
+
+func TestMetricTracker_Sweeper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tracker := NewMetricTracker(ctx, zap.NewNop(), time.Millisecond)
+
+	require.Equal(t, Accumulated, tracker.AccumulateSum(newSumIdentity(), newSumPoint(1, 10, 1)))
+	assert.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.streams) == 0
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+type testDataPoint struct {
+	attr  string
+	start int64
+	ts    int64
+	value int64
+}
+
+func generateSumMetrics(name string, temporality pmetric.AggregationTemporality, dps ...testDataPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for _, tdp := range dps {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("attr", tdp.attr)
+		dp.SetStartTimestamp(pcommon.Timestamp(tdp.start))
+		dp.SetTimestamp(pcommon.Timestamp(tdp.ts))
+		dp.SetIntValue(tdp.value)
+	}
+	return md
+}
+
+// sumValues returns the values of the datapoints of the first metric keyed by their attribute.
+func sumValues(t *testing.T, md pmetric.Metrics) map[string]testDataPoint {
+	values := map[string]testDataPoint{}
+	if md.ResourceMetrics().Len() == 0 {
+		return values
+	}
+	sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+	for i := 0; i < sum.DataPoints().Len(); i++ {
+		dp := sum.DataPoints().At(i)
+		attr, _ := dp.Attributes().Get("attr")
+		values[attr.Str()] = testDataPoint{attr: attr.Str(), start: int64(dp.StartTimestamp()), ts: int64(dp.Timestamp()), value: dp.IntValue()}
+	}
+	return values
+}
+
+func TestDeltaToCumulativeSums(t *testing.T) {
+	p := newDeltaToCumulativeProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	batches := []struct {
+		name     string
+		in       []testDataPoint
+		expected map[string]testDataPoint
+	}{
+		{
+			name: "first_points",
+			in: []testDataPoint{
+				{attr: "a", start: 1, ts: 10, value: 5},
+				{attr: "b", start: 1, ts: 10, value: 1},
+			},
+			expected: map[string]testDataPoint{
+				"a": {attr: "a", start: 1, ts: 10, value: 5},
+				"b": {attr: "b", start: 1, ts: 10, value: 1},
+			},
+		},
+		{
+			name: "accumulated",
+			in: []testDataPoint{
+				{attr: "a", start: 10, ts: 20, value: 3},
+			},
+			expected: map[string]testDataPoint{
+				"a": {attr: "a", start: 1, ts: 20, value: 8},
+			},
+		},
+		{
+			name: "out_of_order_and_overlapping_dropped",
+			in: []testDataPoint{
+				{attr: "a", start: 10, ts: 20, value: 3},
+				{attr: "a", start: 15, ts: 25, value: 3},
+				{attr: "b", start: 0, ts: 5, value: 1},
+			},
+			expected: map[string]testDataPoint{},
+		},
+		{
+			name: "gap_accumulated",
+			in: []testDataPoint{
+				{attr: "a", start: 30, ts: 40, value: 2},
+				{attr: "b", start: 10, ts: 20, value: 4},
+			},
+			expected: map[string]testDataPoint{
+				"a": {attr: "a", start: 1, ts: 40, value: 10},
+				"b": {attr: "b", start: 1, ts: 20, value: 5},
+			},
+		},
+	}
+
+	for _, batch := range batches {
+		t.Run(batch.name, func(t *testing.T) {
+			out, err := p.processMetrics(context.Background(), generateSumMetrics("requests", pmetric.AggregationTemporalityDelta, batch.in...))
+			require.NoError(t, err)
+			assert.Equal(t, batch.expected, sumValues(t, out))
+		})
+	}
+}
+
+func TestDeltaToCumulativeFilters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Exclude = MatchMetrics{
+		Config:  filterset.Config{MatchType: filterset.Strict},
+		Metrics: []string{"excluded"},
+	}
+	p := newDeltaToCumulativeProcessor(cfg, zap.NewNop())
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	dp := testDataPoint{attr: "a", start: 1, ts: 10, value: 5}
+
+	excluded := generateSumMetrics("excluded", pmetric.AggregationTemporalityDelta, dp)
+	out, err := p.processMetrics(context.Background(), excluded)
+	require.NoError(t, err)
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().AggregationTemporality())
+
+	cumulative := generateSumMetrics("cumulative", pmetric.AggregationTemporalityCumulative, dp)
+	out, err = p.processMetrics(context.Background(), cumulative)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]testDataPoint{"a": dp}, sumValues(t, out))
+}
+
+func TestDeltaToCumulativeHistograms(t *testing.T) {
+	p := newDeltaToCumulativeProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	generate := func(start, ts int64, bounds []float64, counts []uint64, sum, min, max float64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(start))
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(counts)
+		var count uint64
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetMin(min)
+		dp.SetMax(max)
+		return md
+	}
+
+	_, err := p.processMetrics(context.Background(), generate(1, 10, []float64{1, 10}, []uint64{1, 2, 0}, 12, 0.5, 8))
+	require.NoError(t, err)
+	out, err := p.processMetrics(context.Background(), generate(10, 20, []float64{1, 10}, []uint64{0, 1, 1}, 25, 4, 20))
+	require.NoError(t, err)
+
+	hist := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+	dp := hist.DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	assert.Equal(t, []uint64{1, 3, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(5), dp.Count())
+	assert.Equal(t, 37.0, dp.Sum())
+	assert.Equal(t, 0.5, dp.Min())
+	assert.Equal(t, 20.0, dp.Max())
+
+	// Changing the bucket boundaries restarts the stream.
+	out, err = p.processMetrics(context.Background(), generate(20, 30, []float64{5}, []uint64{1, 1}, 7, 2, 5))
+	require.NoError(t, err)
+	dp = out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(20), dp.StartTimestamp())
+	assert.Equal(t, []uint64{1, 1}, dp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(2), dp.Count())
+}
+
+func TestDeltaToCumulativeExponentialHistograms(t *testing.T) {
+	p := newDeltaToCumulativeProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	defer func() { require.NoError(t, p.shutdown(context.Background())) }()
+
+	generate := func(start, ts int64, scale int32, offset int32, counts []uint64, zeroCount uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		hist := m.SetEmptyExponentialHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(start))
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetScale(scale)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(counts)
+		dp.SetZeroCount(zeroCount)
+		count := zeroCount
+		for _, c := range counts {
+			count += c
+		}
+		dp.SetCount(count)
+		return md
+	}
+
+	_, err := p.processMetrics(context.Background(), generate(1, 10, 1, 2, []uint64{1, 1, 1}, 1))
+	require.NoError(t, err)
+	out, err := p.processMetrics(context.Background(), generate(10, 20, 0, 0, []uint64{2}, 0))
+	require.NoError(t, err)
+
+	hist := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, hist.AggregationTemporality())
+	dp := hist.DataPoints().At(0)
+	assert.Equal(t, pcommon.Timestamp(1), dp.StartTimestamp())
+	// Indexes 2, 3 and 4 at scale 1 become 1, 1 and 2 at scale 0.
+	assert.Equal(t, int32(0), dp.Scale())
+	assert.Equal(t, int32(0), dp.Positive().Offset())
+	assert.Equal(t, []uint64{2, 2, 1}, dp.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, uint64(1), dp.ZeroCount())
+	assert.Equal(t, uint64(6), dp.Count())
+}
@@ -0,0 +1,93 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// bucketIdleTimeout is the time after which the bucket of a key that hasn't
+// been seen is discarded. Buckets refill well within this time for any
+// sensible limit, so discarding them doesn't affect rate limiting while
+// keeping the memory bounded when keys come and go.
+const bucketIdleTimeout = 10 * time.Minute
+
+// buckets holds the token buckets of each key for a signal.
+type buckets struct {
+	// limitFor returns the limit of a key, or nil if it isn't rate limited.
+	limitFor func(key string) *Limit
+
+	mu        sync.Mutex
+	byKey     map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newBuckets(limitFor func(key string) *Limit) *buckets {
+	return &buckets{
+		limitFor: limitFor,
+		byKey:    map[string]*bucket{},
+	}
+}
+
+// get returns the token bucket of the key, or nil if the key isn't rate limited.
+func (b *buckets) get(key string, now time.Time) *rate.Limiter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Sub(b.lastSweep) > bucketIdleTimeout {
+		for k, bkt := range b.byKey {
+			if now.Sub(bkt.lastSeen) > bucketIdleTimeout {
+				delete(b.byKey, k)
+			}
+		}
+		b.lastSweep = now
+	}
+
+	if bkt, ok := b.byKey[key]; ok {
+		bkt.lastSeen = now
+		return bkt.limiter
+	}
+
+	limit := b.limitFor(key)
+	if limit == nil {
+		return nil
+	}
+	burst := limit.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(limit.Rate))
+	}
+	bkt := &bucket{
+		limiter:  rate.NewLimiter(rate.Limit(limit.Rate), burst),
+		lastSeen: now,
+	}
+	b.byKey[key] = bkt
+	return bkt.limiter
+}
+
+// size returns the number of buckets being tracked.
+func (b *buckets) size() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.byKey)
+}
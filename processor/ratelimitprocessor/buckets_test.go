@@ -0,0 +1,51 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuckets(t *testing.T) {
+	b := newBuckets(func(key string) *Limit {
+		if key == "unlimited" {
+			return nil
+		}
+		return &Limit{Rate: 2.5}
+	})
+	now := time.Unix(1700000000, 0)
+
+	assert.Nil(t, b.get("unlimited", now))
+
+	limiter := b.get("acme", now)
+	assert.Equal(t, 3, limiter.Burst())
+	assert.Same(t, limiter, b.get("acme", now))
+	// new buckets start full.
+	assert.True(t, limiter.AllowN(now, 3))
+
+	b.get("globex", now.Add(time.Minute))
+	assert.Equal(t, 2, b.size())
+
+	// idle buckets are discarded.
+	b.get("initech", now.Add(bucketIdleTimeout+time.Second))
+	assert.Equal(t, 2, b.size())
+	b.get("initech", now.Add(bucketIdleTimeout+2*time.Minute))
+	assert.Equal(t, 2, b.size())
+	b.get("initech", now.Add(2*bucketIdleTimeout+2*time.Second))
+	assert.Equal(t, 1, b.size())
+}
@@ -0,0 +1,160 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Mode defines what happens to data exceeding the rate limit.
+type Mode string
+
+const (
+	// ModeDrop drops the data exceeding the rate limit.
+	ModeDrop Mode = "drop"
+	// ModeReject rejects the whole request with a retryable error.
+	ModeReject Mode = "reject"
+)
+
+// Unit defines how the rate limited data is measured.
+type Unit string
+
+const (
+	// UnitItems counts spans, data points and log records.
+	UnitItems Unit = "items"
+	// UnitBytes measures the size of the data in the OTLP protobuf encoding.
+	UnitBytes Unit = "bytes"
+)
+
+var (
+	errNoKey              = errors.New("one of `key.from_attribute` or `key.from_context` must be specified")
+	errMultipleKeys       = errors.New("only one of `key.from_attribute` or `key.from_context` can be specified")
+	errNoLimits           = errors.New("at least one limit for `traces`, `metrics` or `logs` must be specified")
+	errInvalidRate        = errors.New("`rate` must be greater than 0")
+	errInvalidBurst       = errors.New("`burst` must not be negative")
+	errInvalidMode        = errors.New("`mode` must be one of drop or reject")
+	errInvalidUnit        = errors.New("`unit` must be one of items or bytes")
+	errInvalidFromContext = errors.New("`key.from_context` must be prefixed with `metadata.` or `auth.`")
+)
+
+// Config defines configuration for the rate limit processor.
+type Config struct {
+	// Key defines how the key, e.g. the tenant, the limits are applied to is determined.
+	Key KeyConfig `mapstructure:"key"`
+
+	// Mode is either drop or reject. Defaults to drop.
+	Mode Mode `mapstructure:"mode"`
+
+	// Unit is either items or bytes. Defaults to items.
+	Unit Unit `mapstructure:"unit"`
+
+	// Limits are the limits applied to each key.
+	Limits `mapstructure:",squash"`
+
+	// Overrides are the limits of specific keys. Signals without an override
+	// use the default limits.
+	Overrides map[string]Limits `mapstructure:"overrides"`
+}
+
+// KeyConfig defines the source of the rate limiting key.
+type KeyConfig struct {
+	// FromAttribute is the resource attribute holding the key.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// FromContext is the client.Info value holding the key, either
+	// `metadata.<key>` for the request metadata or `auth.<attribute>` for
+	// the auth data set by the server authenticator.
+	FromContext string `mapstructure:"from_context"`
+}
+
+// Limits holds the limits of each signal. Signals without a limit aren't rate limited.
+type Limits struct {
+	Traces  *Limit `mapstructure:"traces"`
+	Metrics *Limit `mapstructure:"metrics"`
+	Logs    *Limit `mapstructure:"logs"`
+}
+
+// Limit defines a token bucket.
+type Limit struct {
+	// Rate is the sustained amount per second, in the configured unit.
+	Rate float64 `mapstructure:"rate"`
+
+	// Burst is the size of the bucket, i.e. the largest amount accepted at once.
+	// Defaults to one second worth of the rate.
+	Burst int `mapstructure:"burst"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.Key.FromAttribute == "" && cfg.Key.FromContext == "":
+		return errNoKey
+	case cfg.Key.FromAttribute != "" && cfg.Key.FromContext != "":
+		return errMultipleKeys
+	case cfg.Key.FromContext != "" && !hasContextPrefix(cfg.Key.FromContext):
+		return errInvalidFromContext
+	}
+
+	switch cfg.Mode {
+	case ModeDrop, ModeReject:
+	default:
+		return errInvalidMode
+	}
+
+	switch cfg.Unit {
+	case UnitItems, UnitBytes:
+	default:
+		return errInvalidUnit
+	}
+
+	hasLimits, err := cfg.Limits.validate()
+	if err != nil {
+		return err
+	}
+	for key, limits := range cfg.Overrides {
+		hasOverrides, err := limits.validate()
+		if err != nil {
+			return fmt.Errorf("overrides[%s]: %w", key, err)
+		}
+		hasLimits = hasLimits || hasOverrides
+	}
+	if !hasLimits {
+		return errNoLimits
+	}
+	return nil
+}
+
+func (l Limits) validate() (bool, error) {
+	for _, signal := range []struct {
+		name  string
+		limit *Limit
+	}{{"traces", l.Traces}, {"metrics", l.Metrics}, {"logs", l.Logs}} {
+		if signal.limit == nil {
+			continue
+		}
+		if signal.limit.Rate <= 0 {
+			return false, fmt.Errorf("%s: %w", signal.name, errInvalidRate)
+		}
+		if signal.limit.Burst < 0 {
+			return false, fmt.Errorf("%s: %w", signal.name, errInvalidBurst)
+		}
+	}
+	return l.Traces != nil || l.Metrics != nil || l.Logs != nil, nil
+}
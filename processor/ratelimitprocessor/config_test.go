@@ -0,0 +1,114 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id:          component.NewID(typeStr),
+			expectedErr: errNoKey.Error(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "tenant"),
+			expected: &Config{
+				Key:  KeyConfig{FromContext: "auth.tenant"},
+				Mode: ModeReject,
+				Unit: UnitItems,
+				Limits: Limits{
+					Traces: &Limit{Rate: 1000, Burst: 2000},
+					Logs:   &Limit{Rate: 500},
+				},
+				Overrides: map[string]Limits{
+					"acme": {Traces: &Limit{Rate: 5000, Burst: 10000}},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "bytes"),
+			expected: &Config{
+				Key:  KeyConfig{FromAttribute: "tenant.id"},
+				Mode: ModeDrop,
+				Unit: UnitBytes,
+				Limits: Limits{
+					Metrics: &Limit{Rate: 1048576},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "both-keys"),
+			expectedErr: errMultipleKeys.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid-context"),
+			expectedErr: errInvalidFromContext.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "no-limits"),
+			expectedErr: errNoLimits.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid-rate"),
+			expectedErr: "traces: " + errInvalidRate.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid-override"),
+			expectedErr: "overrides[acme]: logs: " + errInvalidBurst.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid-mode"),
+			expectedErr: errInvalidMode.Error(),
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid-unit"),
+			expectedErr: errInvalidUnit.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
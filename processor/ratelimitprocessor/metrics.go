@@ -0,0 +1,55 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// otherKey is the value of the key attribute of the keys without overrides.
+const otherKey = "other"
+
+var (
+	tagKeyKey, _    = tag.NewKey("key")
+	tagSignalKey, _ = tag.NewKey("signal")
+	tagModeKey, _   = tag.NewKey("mode")
+
+	statThrottledItems = stats.Int64("throttled_items", "Number of spans, data points or log records throttled per key", stats.UnitDimensionless)
+	statThrottledBytes = stats.Int64("throttled_bytes", "Size of the data throttled per key", stats.UnitBytes)
+)
+
+// MetricViews returns the metrics views of the rate limit processor.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagKeyKey, tagSignalKey, tagModeKey}
+	return []*view.View{
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statThrottledItems.Name()),
+			Measure:     statThrottledItems,
+			Description: statThrottledItems.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statThrottledBytes.Name()),
+			Measure:     statThrottledBytes,
+			Description: statThrottledBytes.Description(),
+			TagKeys:     tagKeys,
+			Aggregation: view.Sum(),
+		},
+	}
+}
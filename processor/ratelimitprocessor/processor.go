@@ -0,0 +1,350 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/ratelimitprocessor"
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	metadataPrefix = "metadata."
+	authPrefix     = "auth."
+)
+
+var errExceedsBurst = errors.New("data exceeds the burst of the rate limit")
+
+var (
+	tracesSizer  = &ptrace.ProtoMarshaler{}
+	metricsSizer = &pmetric.ProtoMarshaler{}
+	logsSizer    = &plog.ProtoMarshaler{}
+)
+
+type rateLimitProcessor struct {
+	cfg     *Config
+	signal  string
+	buckets *buckets
+	now     func() time.Time
+}
+
+// newRateLimitProcessor returns a processor applying the limits selected by
+// signalLimit from the configuration.
+func newRateLimitProcessor(cfg *Config, signal string, signalLimit func(Limits) *Limit) *rateLimitProcessor {
+	return &rateLimitProcessor{
+		cfg:    cfg,
+		signal: signal,
+		buckets: newBuckets(func(key string) *Limit {
+			if overrides, ok := cfg.Overrides[key]; ok {
+				if limit := signalLimit(overrides); limit != nil {
+					return limit
+				}
+			}
+			return signalLimit(cfg.Limits)
+		}),
+		now: time.Now,
+	}
+}
+
+func (p *rateLimitProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	keyOf := p.keyFunc(ctx)
+	keys := make([]string, rss.Len())
+	amounts := map[string]int{}
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		keys[i] = keyOf(rs.Resource())
+		amounts[keys[i]] += p.tracesAmount(rs)
+	}
+
+	denied, err := p.limit(ctx, amounts)
+	if err != nil || len(denied) == 0 {
+		return td, err
+	}
+
+	i := 0
+	rss.RemoveIf(func(ptrace.ResourceSpans) bool {
+		drop := denied[keys[i]]
+		i++
+		return drop
+	})
+	if rss.Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+func (p *rateLimitProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	keyOf := p.keyFunc(ctx)
+	keys := make([]string, rms.Len())
+	amounts := map[string]int{}
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		keys[i] = keyOf(rm.Resource())
+		amounts[keys[i]] += p.metricsAmount(rm)
+	}
+
+	denied, err := p.limit(ctx, amounts)
+	if err != nil || len(denied) == 0 {
+		return md, err
+	}
+
+	i := 0
+	rms.RemoveIf(func(pmetric.ResourceMetrics) bool {
+		drop := denied[keys[i]]
+		i++
+		return drop
+	})
+	if rms.Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+func (p *rateLimitProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	keyOf := p.keyFunc(ctx)
+	keys := make([]string, rls.Len())
+	amounts := map[string]int{}
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		keys[i] = keyOf(rl.Resource())
+		amounts[keys[i]] += p.logsAmount(rl)
+	}
+
+	denied, err := p.limit(ctx, amounts)
+	if err != nil || len(denied) == 0 {
+		return ld, err
+	}
+
+	i := 0
+	rls.RemoveIf(func(plog.ResourceLogs) bool {
+		drop := denied[keys[i]]
+		i++
+		return drop
+	})
+	if rls.Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// limit takes the amount of each key from its bucket and returns the keys
+// exceeding their limit, whose data is dropped. In reject mode, nothing is
+// taken and an error is returned instead when any key exceeds its limit.
+func (p *rateLimitProcessor) limit(ctx context.Context, amounts map[string]int) (map[string]bool, error) {
+	now := p.now()
+	var (
+		reserved   []*rate.Reservation
+		denied     map[string]bool
+		retryDelay time.Duration
+		permanent  bool
+	)
+	for key, amount := range amounts {
+		limiter := p.buckets.get(key, now)
+		if limiter == nil || amount == 0 {
+			continue
+		}
+
+		r := limiter.ReserveN(now, amount)
+		switch {
+		case !r.OK():
+			// the amount is larger than the bucket, it can never be accepted.
+			permanent = true
+		case r.DelayFrom(now) > 0:
+			if delay := r.DelayFrom(now); delay > retryDelay {
+				retryDelay = delay
+			}
+			r.CancelAt(now)
+		default:
+			reserved = append(reserved, r)
+			continue
+		}
+
+		if denied == nil {
+			denied = map[string]bool{}
+		}
+		denied[key] = true
+		p.recordThrottled(ctx, key, amount)
+	}
+
+	if len(denied) == 0 || p.cfg.Mode == ModeDrop {
+		return denied, nil
+	}
+
+	for _, r := range reserved {
+		r.CancelAt(now)
+	}
+	if permanent {
+		return nil, consumererror.NewPermanent(errExceedsBurst)
+	}
+	return nil, newThrottledError(retryDelay)
+}
+
+// newThrottledError returns a RESOURCE_EXHAUSTED status telling OTLP clients
+// when to retry.
+func newThrottledError(retryDelay time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func (p *rateLimitProcessor) recordThrottled(ctx context.Context, key string, amount int) {
+	// only the keys with overrides are recorded as-is to bound the cardinality of the metrics.
+	if _, ok := p.cfg.Overrides[key]; !ok {
+		key = otherKey
+	}
+	measure := statThrottledItems
+	if p.cfg.Unit == UnitBytes {
+		measure = statThrottledBytes
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Upsert(tagKeyKey, key), tag.Upsert(tagSignalKey, p.signal), tag.Upsert(tagModeKey, string(p.cfg.Mode))},
+		measure.M(int64(amount)),
+	)
+}
+
+// keyFunc returns a function returning the key of each resource of a request.
+func (p *rateLimitProcessor) keyFunc(ctx context.Context) func(pcommon.Resource) string {
+	if p.cfg.Key.FromContext != "" {
+		key := contextKey(ctx, p.cfg.Key.FromContext)
+		return func(pcommon.Resource) string {
+			return key
+		}
+	}
+	return func(res pcommon.Resource) string {
+		if v, ok := res.Attributes().Get(p.cfg.Key.FromAttribute); ok {
+			return v.AsString()
+		}
+		return ""
+	}
+}
+
+func hasContextPrefix(from string) bool {
+	return strings.HasPrefix(from, metadataPrefix) || strings.HasPrefix(from, authPrefix)
+}
+
+// contextKey returns the value of from in the client.Info of the request.
+// Multiple values are joined with `;`.
+func contextKey(ctx context.Context, from string) string {
+	info := client.FromContext(ctx)
+	if strings.HasPrefix(from, metadataPrefix) {
+		return strings.Join(info.Metadata.Get(strings.TrimPrefix(from, metadataPrefix)), ";")
+	}
+
+	if info.Auth == nil {
+		return ""
+	}
+	switch v := info.Auth.GetAttribute(strings.TrimPrefix(from, authPrefix)).(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ";")
+	default:
+		return ""
+	}
+}
+
+func (p *rateLimitProcessor) tracesAmount(rs ptrace.ResourceSpans) int {
+	if p.cfg.Unit == UnitBytes {
+		// measure the resource on its own by moving it to an empty request and back.
+		td := ptrace.NewTraces()
+		tmp := td.ResourceSpans().AppendEmpty()
+		rs.MoveTo(tmp)
+		size := tracesSizer.TracesSize(td)
+		tmp.MoveTo(rs)
+		return size
+	}
+
+	count := 0
+	for i := 0; i < rs.ScopeSpans().Len(); i++ {
+		count += rs.ScopeSpans().At(i).Spans().Len()
+	}
+	return count
+}
+
+func (p *rateLimitProcessor) metricsAmount(rm pmetric.ResourceMetrics) int {
+	if p.cfg.Unit == UnitBytes {
+		md := pmetric.NewMetrics()
+		tmp := md.ResourceMetrics().AppendEmpty()
+		rm.MoveTo(tmp)
+		size := metricsSizer.MetricsSize(md)
+		tmp.MoveTo(rm)
+		return size
+	}
+
+	count := 0
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		metrics := rm.ScopeMetrics().At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			count += dataPointCount(metrics.At(j))
+		}
+	}
+	return count
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	default:
+		return 0
+	}
+}
+
+func (p *rateLimitProcessor) logsAmount(rl plog.ResourceLogs) int {
+	if p.cfg.Unit == UnitBytes {
+		ld := plog.NewLogs()
+		tmp := ld.ResourceLogs().AppendEmpty()
+		rl.MoveTo(tmp)
+		size := logsSizer.LogsSize(ld)
+		tmp.MoveTo(rl)
+		return size
+	}
+
+	count := 0
+	for i := 0; i < rl.ScopeLogs().Len(); i++ {
+		count += rl.ScopeLogs().At(i).LogRecords().Len()
+	}
+	return count
+}
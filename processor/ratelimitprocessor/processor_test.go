@@ -0,0 +1,319 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimitprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type tenantSpans struct {
+	tenant string
+	spans  int
+}
+
+func generateTraces(resources ...tenantSpans) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, r := range resources {
+		rs := td.ResourceSpans().AppendEmpty()
+		if r.tenant != "" {
+			rs.Resource().Attributes().PutStr("tenant.id", r.tenant)
+		}
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for i := 0; i < r.spans; i++ {
+			spans.AppendEmpty().SetName("span")
+		}
+	}
+	return td
+}
+
+func tenantsOf(td ptrace.Traces) []string {
+	var tenants []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		tenant, _ := td.ResourceSpans().At(i).Resource().Attributes().Get("tenant.id")
+		tenants = append(tenants, tenant.AsString())
+	}
+	return tenants
+}
+
+// newTestProcessor returns a traces processor with a clock that only moves when advanced.
+func newTestProcessor(cfg *Config) (*rateLimitProcessor, func(time.Duration)) {
+	p := newRateLimitProcessor(cfg, "traces", func(l Limits) *Limit { return l.Traces })
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+	return p, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestDropByResourceAttribute(t *testing.T) {
+	p, advance := newTestProcessor(&Config{
+		Key:    KeyConfig{FromAttribute: "tenant.id"},
+		Mode:   ModeDrop,
+		Unit:   UnitItems,
+		Limits: Limits{Traces: &Limit{Rate: 1, Burst: 10}},
+	})
+	ctx := context.Background()
+
+	// each tenant has its own bucket.
+	td, err := p.processTraces(ctx, generateTraces(tenantSpans{"acme", 6}, tenantSpans{"globex", 6}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "globex"}, tenantsOf(td))
+
+	// resources of the same tenant are limited together.
+	td, err = p.processTraces(ctx, generateTraces(tenantSpans{"acme", 2}, tenantSpans{"globex", 2}, tenantSpans{"acme", 3}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"globex"}, tenantsOf(td))
+
+	_, err = p.processTraces(ctx, generateTraces(tenantSpans{"acme", 5}, tenantSpans{"globex", 3}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// the buckets refill over time.
+	advance(5 * time.Second)
+	td, err = p.processTraces(ctx, generateTraces(tenantSpans{"acme", 5}, tenantSpans{"globex", 8}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme"}, tenantsOf(td))
+
+	// data without the attribute shares a single bucket.
+	td, err = p.processTraces(ctx, generateTraces(tenantSpans{"", 10}))
+	require.NoError(t, err)
+	assert.Equal(t, 10, td.SpanCount())
+	_, err = p.processTraces(ctx, generateTraces(tenantSpans{"", 1}))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestRejectByContext(t *testing.T) {
+	p, advance := newTestProcessor(&Config{
+		Key:    KeyConfig{FromContext: "metadata.x-tenant"},
+		Mode:   ModeReject,
+		Unit:   UnitItems,
+		Limits: Limits{Traces: &Limit{Rate: 10, Burst: 10}},
+	})
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}}),
+	})
+
+	_, err := p.processTraces(ctx, generateTraces(tenantSpans{"a", 4}, tenantSpans{"b", 4}))
+	require.NoError(t, err)
+
+	td := generateTraces(tenantSpans{"a", 5})
+	_, err = p.processTraces(ctx, td)
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, 300*time.Millisecond, st.Details()[0].(*errdetails.RetryInfo).RetryDelay.AsDuration())
+	// rejected data is left untouched.
+	assert.Equal(t, 5, td.SpanCount())
+
+	// rejected requests don't take from the bucket.
+	_, err = p.processTraces(ctx, generateTraces(tenantSpans{"a", 2}))
+	require.NoError(t, err)
+
+	// other tenants aren't affected.
+	otherCtx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"globex"}}),
+	})
+	_, err = p.processTraces(otherCtx, generateTraces(tenantSpans{"a", 10}))
+	require.NoError(t, err)
+
+	advance(time.Second)
+	_, err = p.processTraces(ctx, generateTraces(tenantSpans{"a", 10}))
+	require.NoError(t, err)
+}
+
+func TestRejectExceedingBurst(t *testing.T) {
+	p, _ := newTestProcessor(&Config{
+		Key:    KeyConfig{FromAttribute: "tenant.id"},
+		Mode:   ModeReject,
+		Unit:   UnitItems,
+		Limits: Limits{Traces: &Limit{Rate: 10}},
+	})
+
+	// a request larger than the bucket can never be accepted, so isn't retryable.
+	_, err := p.processTraces(context.Background(), generateTraces(tenantSpans{"acme", 5}, tenantSpans{"globex", 11}))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// the accepted tenant's tokens were given back when rejecting the request.
+	_, err = p.processTraces(context.Background(), generateTraces(tenantSpans{"acme", 10}))
+	require.NoError(t, err)
+}
+
+func TestOverrides(t *testing.T) {
+	p, _ := newTestProcessor(&Config{
+		Key:  KeyConfig{FromAttribute: "tenant.id"},
+		Mode: ModeDrop,
+		Unit: UnitItems,
+		Limits: Limits{
+			Logs: &Limit{Rate: 1},
+		},
+		Overrides: map[string]Limits{
+			"acme": {Traces: &Limit{Rate: 5}},
+		},
+	})
+
+	// only acme has a traces limit.
+	td, err := p.processTraces(context.Background(), generateTraces(tenantSpans{"acme", 6}, tenantSpans{"globex", 1000}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"globex"}, tenantsOf(td))
+	assert.Equal(t, 1, p.buckets.size())
+}
+
+func TestMetricsAndLogs(t *testing.T) {
+	cfg := &Config{
+		Key:  KeyConfig{FromContext: "auth.tenant"},
+		Mode: ModeDrop,
+		Unit: UnitItems,
+		Limits: Limits{
+			Metrics: &Limit{Rate: 5},
+			Logs:    &Limit{Rate: 3},
+		},
+	}
+	ctx := client.NewContext(context.Background(), client.Info{Auth: authData{"tenant": "acme"}})
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty().SetEmptyGauge()
+	gauge.DataPoints().AppendEmpty()
+	gauge.DataPoints().AppendEmpty()
+	sum := metrics.AppendEmpty().SetEmptySum()
+	sum.DataPoints().AppendEmpty()
+	sum.DataPoints().AppendEmpty()
+	sum.DataPoints().AppendEmpty()
+
+	mp := newRateLimitProcessor(cfg, "metrics", func(l Limits) *Limit { return l.Metrics })
+	_, err := mp.processMetrics(ctx, md)
+	require.NoError(t, err)
+	_, err = mp.processMetrics(ctx, md)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty()
+	records.AppendEmpty()
+
+	lp := newRateLimitProcessor(cfg, "logs", func(l Limits) *Limit { return l.Logs })
+	_, err = lp.processLogs(ctx, ld)
+	require.NoError(t, err)
+	_, err = lp.processLogs(ctx, ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestBytes(t *testing.T) {
+	single := generateTraces(tenantSpans{"acme", 10})
+	size := tracesSizer.TracesSize(single)
+
+	p, _ := newTestProcessor(&Config{
+		Key:    KeyConfig{FromAttribute: "tenant.id"},
+		Mode:   ModeDrop,
+		Unit:   UnitBytes,
+		Limits: Limits{Traces: &Limit{Rate: float64(size)}},
+	})
+
+	td, err := p.processTraces(context.Background(), generateTraces(tenantSpans{"acme", 10}, tenantSpans{"apex", 10}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "apex"}, tenantsOf(td))
+	// measuring leaves the data intact.
+	assert.Equal(t, 20, td.SpanCount())
+
+	td, err = p.processTraces(context.Background(), generateTraces(tenantSpans{"acme", 1}, tenantSpans{"blip", 10}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blip"}, tenantsOf(td))
+}
+
+func TestThrottledMetrics(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Key.FromAttribute = "tenant.id"
+	cfg.Traces = &Limit{Rate: 1, Burst: 5}
+	cfg.Overrides = map[string]Limits{
+		"throttled-tenant": {Traces: &Limit{Rate: 1, Burst: 5}},
+	}
+
+	sink := new(consumertest.TracesSink)
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	beforeOverride := throttledItems(t, "throttled-tenant")
+	beforeOther := throttledItems(t, otherKey)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), generateTraces(tenantSpans{"throttled-tenant", 5}, tenantSpans{"unlisted-tenant", 5})))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), generateTraces(tenantSpans{"throttled-tenant", 4}, tenantSpans{"unlisted-tenant", 2}, tenantSpans{"globex", 1})))
+	assert.Equal(t, 11, sink.SpanCount())
+	assert.Equal(t, float64(4), throttledItems(t, "throttled-tenant")-beforeOverride)
+	// keys without overrides are folded into a single series.
+	assert.Equal(t, float64(2), throttledItems(t, otherKey)-beforeOther)
+	assert.Zero(t, throttledItems(t, "unlisted-tenant"))
+}
+
+// throttledItems returns the throttled traces of the key in drop mode.
+func throttledItems(t *testing.T, key string) float64 {
+	rows, err := view.RetrieveData("processor/ratelimit/throttled_items")
+	require.NoError(t, err)
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tag := range row.Tags {
+			tags[tag.Key.Name()] = tag.Value
+		}
+		if assert.ObjectsAreEqual(map[string]string{"key": key, "signal": "traces", "mode": "drop"}, tags) {
+			return row.Data.(*view.SumData).Value
+		}
+	}
+	return 0
+}
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestContextKey(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme", "globex"}}),
+		Auth:     authData{"tenant": "acme", "groups": []string{"a", "b"}, "age": 3},
+	})
+
+	assert.Equal(t, "acme;globex", contextKey(ctx, "metadata.x-tenant"))
+	assert.Equal(t, "", contextKey(ctx, "metadata.missing"))
+	assert.Equal(t, "acme", contextKey(ctx, "auth.tenant"))
+	assert.Equal(t, "a;b", contextKey(ctx, "auth.groups"))
+	assert.Equal(t, "", contextKey(ctx, "auth.age"))
+	assert.Equal(t, "", contextKey(context.Background(), "auth.tenant"))
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -146,6 +147,100 @@ func TestResourceProcessorAttributesUpsert(t *testing.T) {
 	}
 }
 
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestResourceProcessorFromContext(t *testing.T) {
+	config := &Config{
+		AttributesActions: []attraction.ActionKeyValue{
+			{Key: "tenant.id", FromContext: "auth.tenant", Action: attraction.UPSERT},
+			{Key: "enduser.id", FromContext: "auth.subject", Action: attraction.INSERT},
+			{Key: "enduser.role", FromContext: "auth.membership", Action: attraction.INSERT},
+			{Key: "client.region", FromContext: "metadata.x-region", Action: attraction.INSERT},
+		},
+	}
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-region": {"eu"}}),
+		Auth: authData{
+			"tenant":     "acme",
+			"subject":    "agent-1",
+			"membership": []string{"ingest", "admin"},
+		},
+	})
+	sourceAttributes := map[string]string{
+		"tenant.id":  "spoofed",
+		"enduser.id": "existing",
+	}
+	wantAttributes := map[string]string{
+		"tenant.id":     "acme",
+		"enduser.id":    "existing",
+		"enduser.role":  "ingest;admin",
+		"client.region": "eu",
+	}
+	factory := NewFactory()
+
+	ttn := new(consumertest.TracesSink)
+	rtp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), config, ttn)
+	require.NoError(t, err)
+	require.NoError(t, rtp.ConsumeTraces(ctx, generateTraceData(sourceAttributes)))
+	require.Len(t, ttn.AllTraces(), 1)
+	assert.NoError(t, ptracetest.CompareTraces(generateTraceData(wantAttributes), ttn.AllTraces()[0]))
+
+	tmn := new(consumertest.MetricsSink)
+	rmp, err := factory.CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), config, tmn)
+	require.NoError(t, err)
+	require.NoError(t, rmp.ConsumeMetrics(ctx, generateMetricData(sourceAttributes)))
+	require.Len(t, tmn.AllMetrics(), 1)
+	assert.NoError(t, pmetrictest.CompareMetrics(generateMetricData(wantAttributes), tmn.AllMetrics()[0]))
+
+	tln := new(consumertest.LogsSink)
+	rlp, err := factory.CreateLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), config, tln)
+	require.NoError(t, err)
+	require.NoError(t, rlp.ConsumeLogs(ctx, generateLogData(sourceAttributes)))
+	require.Len(t, tln.AllLogs(), 1)
+	assert.NoError(t, plogtest.CompareLogs(generateLogData(wantAttributes), tln.AllLogs()[0]))
+
+	// without client info, no attributes are added.
+	ttn.Reset()
+	require.NoError(t, rtp.ConsumeTraces(context.Background(), generateTraceData(nil)))
+	require.Len(t, ttn.AllTraces(), 1)
+	assert.NoError(t, ptracetest.CompareTraces(generateTraceData(nil), ttn.AllTraces()[0]))
+}
+
+func TestResourceProcessorFromContextDeleteFirst(t *testing.T) {
+	config := &Config{
+		AttributesActions: []attraction.ActionKeyValue{
+			{Key: "tenant.id", Action: attraction.DELETE},
+			{Key: "tenant.id", FromContext: "auth.tenant", Action: attraction.INSERT},
+		},
+	}
+	factory := NewFactory()
+	ttn := new(consumertest.TracesSink)
+	rtp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), config, ttn)
+	require.NoError(t, err)
+
+	// the attribute supplied by the client is removed when the request has no tenant.
+	require.NoError(t, rtp.ConsumeTraces(context.Background(), generateTraceData(map[string]string{"tenant.id": "spoofed"})))
+	require.Len(t, ttn.AllTraces(), 1)
+	assert.NoError(t, ptracetest.CompareTraces(generateTraceData(map[string]string{}), ttn.AllTraces()[0]))
+
+	ctx := client.NewContext(context.Background(), client.Info{Auth: authData{"tenant": "acme"}})
+	require.NoError(t, rtp.ConsumeTraces(ctx, generateTraceData(map[string]string{"tenant.id": "spoofed"})))
+	require.Len(t, ttn.AllTraces(), 2)
+	assert.NoError(t, ptracetest.CompareTraces(generateTraceData(map[string]string{"tenant.id": "acme"}), ttn.AllTraces()[1]))
+}
+
 func generateTraceData(attributes map[string]string) ptrace.Traces {
 	td := testdata.GenerateTracesOneSpanNoResource()
 	if attributes == nil {
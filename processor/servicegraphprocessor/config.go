@@ -39,6 +39,12 @@ type Config struct {
 
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
+
+	// VirtualNodePeerAttributes is the list of attributes used to name the virtual node standing for the
+	// uninstrumented peer of a client span that expired without finding its server span.
+	// The first attribute found in the span wins. Only used when the processor.servicegraph.virtualNode
+	// feature gate is enabled. See PeerAttributes in processor.go for the default value.
+	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
 }
 
 type StoreConfig struct {
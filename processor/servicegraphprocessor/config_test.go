@@ -49,6 +49,7 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
+			VirtualNodePeerAttributes: []string{"db.name", "rpc.service"},
 		},
 		cfg.Processors[component.NewID(typeStr)],
 	)
@@ -38,6 +38,12 @@ func NewKey(tid pcommon.TraceID, sid pcommon.SpanID) Key {
 	return Key{tid: tid, sid: sid}
 }
 
+// SpanIDIsEmpty returns true if the key was built without a span ID,
+// which is the case for edges created from root server spans.
+func (k *Key) SpanIDIsEmpty() bool {
+	return k.sid.IsEmpty()
+}
+
 type Store struct {
 	l   *list.List
 	mtx sync.Mutex
@@ -139,7 +145,7 @@ func (s *Store) tryEvictHead() bool {
 	}
 
 	s.onExpire(headEdge)
-	delete(s.m, headEdge.key)
+	delete(s.m, headEdge.Key)
 	s.l.Remove(head)
 
 	return true
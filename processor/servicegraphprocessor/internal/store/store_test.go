@@ -123,7 +123,7 @@ func TestStoreExpire(t *testing.T) {
 
 	onComplete := func(e *Edge) {
 		onCompletedCount++
-		assert.Contains(t, keys, e.key)
+		assert.Contains(t, keys, e.Key)
 	}
 	// New edges are immediately expired
 	s := NewStore(-time.Second, testSize, onComplete, countingCallback(&onExpireCount))
@@ -180,3 +180,11 @@ func countingCallback(counter *int) func(*Edge) {
 		*counter++
 	}
 }
+
+func TestKeySpanIDIsEmpty(t *testing.T) {
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	assert.False(t, key.SpanIDIsEmpty())
+
+	key = NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.NewSpanIDEmpty())
+	assert.True(t, key.SpanIDIsEmpty())
+}
@@ -48,8 +48,16 @@ var (
 		2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 	}
 	// PeerAttributes the list of attributes need to match, the higher the front, the higher the priority.
-	// TODO: Consider making this configurable.
-	PeerAttributes = []string{semconv.AttributeDBName, semconv.AttributeNetSockPeerAddr, semconv.AttributeNetPeerName, semconv.AttributeRPCService, semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget}
+	// It is used when Config.VirtualNodePeerAttributes is empty.
+	//
+	// Deprecated: [v0.73.0] Use Config.VirtualNodePeerAttributes instead.
+	PeerAttributes = []string{
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeDBSystem,
+		semconv.AttributeNetPeerName, semconv.AttributeNetSockPeerAddr, semconv.AttributeRPCService,
+		semconv.AttributeHTTPURL, semconv.AttributeHTTPTarget,
+	}
+	// databaseNameAttributes the list of attributes used to name the database of a database request, the higher the front, the higher the priority.
+	databaseNameAttributes = []string{semconv.AttributeDBName, semconv.AttributeDBSystem}
 )
 
 type metricSeries struct {
@@ -77,6 +85,12 @@ type serviceGraphProcessor struct {
 	reqDurationBounds              []float64
 	reqDurationSecondsBucketCounts map[string][]uint64
 
+	peerAttributes []string
+
+	reqDatabaseDurationSecondsSum          map[string]float64
+	reqDatabaseDurationSecondsCount        map[string]uint64
+	reqDatabaseDurationSecondsBucketCounts map[string][]uint64
+
 	metricMutex sync.RWMutex
 	keyToMetric map[string]metricSeries
 
@@ -91,6 +105,11 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
 	}
 
+	peerAttributes := PeerAttributes
+	if len(pConfig.VirtualNodePeerAttributes) != 0 {
+		peerAttributes = pConfig.VirtualNodePeerAttributes
+	}
+
 	return &serviceGraphProcessor{
 		config:                         pConfig,
 		logger:                         logger,
@@ -101,8 +120,14 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 		reqDurationSecondsCount:        make(map[string]uint64),
 		reqDurationBounds:              bounds,
 		reqDurationSecondsBucketCounts: make(map[string][]uint64),
-		keyToMetric:                    make(map[string]metricSeries),
-		shutdownCh:                     make(chan interface{}),
+		peerAttributes:                 peerAttributes,
+
+		reqDatabaseDurationSecondsSum:          make(map[string]float64),
+		reqDatabaseDurationSecondsCount:        make(map[string]uint64),
+		reqDatabaseDurationSecondsBucketCounts: make(map[string][]uint64),
+
+		keyToMetric: make(map[string]metricSeries),
+		shutdownCh:  make(chan interface{}),
 	}
 }
 
@@ -226,12 +251,12 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 						p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
 
 						if virtualNodeFeatureGate.IsEnabled() {
-							p.upsertPeerAttributes(PeerAttributes, e.Peer, span.Attributes())
+							p.upsertPeerAttributes(p.peerAttributes, e.Peer, span.Attributes())
 						}
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
-						if dbName, ok := findAttributeValue(semconv.AttributeDBName, rAttributes, span.Attributes()); ok {
+						if dbName, ok := findFirstAttributeValue(databaseNameAttributes, rAttributes, span.Attributes()); ok {
 							e.ConnectionType = store.Database
 							e.ServerService = dbName
 							e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
@@ -316,22 +341,28 @@ func (p *serviceGraphProcessor) onExpire(e *store.Edge) {
 
 	stats.Record(context.Background(), statExpiredEdges.M(1))
 
-	if virtualNodeFeatureGate.IsEnabled() {
-		// speculate virtual node before edge get expired.
-		// TODO: We could add some logic to check if the server span is an orphan.
-		// https://github.com/open-telemetry/opentelemetry-collector-contrib/pull/17350#discussion_r1099949579
-		if len(e.ClientService) == 0 {
-			e.ClientService = "user"
-		}
+	if !virtualNodeFeatureGate.IsEnabled() {
+		return
+	}
 
-		if len(e.ServerService) == 0 {
-			e.ServerService = p.getPeerHost(PeerAttributes, e.Peer)
+	// speculate virtual node before edge get expired.
+	switch {
+	case len(e.ClientService) == 0:
+		// Only a root server span has no caller, other orphan server spans
+		// are missing their client span and cannot be attributed to a virtual node.
+		if !e.Key.SpanIDIsEmpty() {
+			return
 		}
+		e.ClientService = "user"
+	case len(e.ServerService) == 0:
+		// The client called an uninstrumented service, only the client side of the request is known.
+		e.ServerService = p.getPeerHost(p.peerAttributes, e.Peer)
+		e.ServerLatencySec = e.ClientLatencySec
+	}
 
-		e.ConnectionType = store.VirtualNode
+	e.ConnectionType = store.VirtualNode
 
-		p.onComplete(e)
-	}
+	p.onComplete(e)
 }
 
 func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
@@ -349,6 +380,9 @@ func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
 		p.updateErrorMetrics(metricKey)
 	}
 	p.updateDurationMetrics(metricKey, duration)
+	if e.ConnectionType == store.Database {
+		p.updateDatabaseDurationMetrics(metricKey, e.ClientLatencySec)
+	}
 }
 
 func (p *serviceGraphProcessor) updateSeries(key string, dimensions pcommon.Map) {
@@ -385,6 +419,16 @@ func (p *serviceGraphProcessor) updateDurationMetrics(key string, duration float
 	p.reqDurationSecondsBucketCounts[key][index]++
 }
 
+func (p *serviceGraphProcessor) updateDatabaseDurationMetrics(key string, duration float64) {
+	index := sort.SearchFloat64s(p.reqDurationBounds, duration) // Search bucket index
+	if _, ok := p.reqDatabaseDurationSecondsBucketCounts[key]; !ok {
+		p.reqDatabaseDurationSecondsBucketCounts[key] = make([]uint64, len(p.reqDurationBounds)+1)
+	}
+	p.reqDatabaseDurationSecondsSum[key] += duration
+	p.reqDatabaseDurationSecondsCount[key]++
+	p.reqDatabaseDurationSecondsBucketCounts[key][index]++
+}
+
 func buildDimensions(e *store.Edge) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.PutStr("client", e.ClientService)
@@ -462,9 +506,24 @@ func (p *serviceGraphProcessor) collectCountMetrics(ilm pmetric.ScopeMetrics) er
 }
 
 func (p *serviceGraphProcessor) collectLatencyMetrics(ilm pmetric.ScopeMetrics) error {
-	for key := range p.reqDurationSecondsCount {
+	if err := p.collectDurationMetrics(ilm, "traces_service_graph_request_duration_seconds",
+		p.reqDurationSecondsCount, p.reqDurationSecondsSum, p.reqDurationSecondsBucketCounts); err != nil {
+		return err
+	}
+	return p.collectDurationMetrics(ilm, "traces_service_graph_database_request_duration_seconds",
+		p.reqDatabaseDurationSecondsCount, p.reqDatabaseDurationSecondsSum, p.reqDatabaseDurationSecondsBucketCounts)
+}
+
+func (p *serviceGraphProcessor) collectDurationMetrics(
+	ilm pmetric.ScopeMetrics,
+	name string,
+	counts map[string]uint64,
+	sums map[string]float64,
+	bucketCounts map[string][]uint64,
+) error {
+	for key := range counts {
 		mDuration := ilm.Metrics().AppendEmpty()
-		mDuration.SetName("traces_service_graph_request_duration_seconds")
+		mDuration.SetName(name)
 		// TODO: Support other aggregation temporalities
 		mDuration.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 
@@ -474,9 +533,9 @@ func (p *serviceGraphProcessor) collectLatencyMetrics(ilm pmetric.ScopeMetrics)
 		dpDuration.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
 		dpDuration.SetTimestamp(timestamp)
 		dpDuration.ExplicitBounds().FromRaw(p.reqDurationBounds)
-		dpDuration.BucketCounts().FromRaw(p.reqDurationSecondsBucketCounts[key])
-		dpDuration.SetCount(p.reqDurationSecondsCount[key])
-		dpDuration.SetSum(p.reqDurationSecondsSum[key])
+		dpDuration.BucketCounts().FromRaw(bucketCounts[key])
+		dpDuration.SetCount(counts[key])
+		dpDuration.SetSum(sums[key])
 
 		// TODO: Support exemplars
 
@@ -567,6 +626,9 @@ func (p *serviceGraphProcessor) cleanCache() {
 		delete(p.reqDurationSecondsCount, key)
 		delete(p.reqDurationSecondsSum, key)
 		delete(p.reqDurationSecondsBucketCounts, key)
+		delete(p.reqDatabaseDurationSecondsCount, key)
+		delete(p.reqDatabaseDurationSecondsSum, key)
+		delete(p.reqDatabaseDurationSecondsBucketCounts, key)
 	}
 	p.seriesMutex.Unlock()
 }
@@ -38,6 +38,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -499,3 +501,170 @@ func TestStaleSeriesCleanup(t *testing.T) {
 	// Shutdown the processor
 	assert.NoError(t, p.Shutdown(context.Background()))
 }
+
+func TestVirtualNodeEdges(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(virtualNodeFeatureGate.ID(), false))
+	}()
+
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 6, 6, time.UTC)
+
+	for _, tc := range []struct {
+		name               string
+		serviceName        string
+		kind               ptrace.SpanKind
+		parentSpanID       pcommon.SpanID
+		attributes         map[string]string
+		wantClient         string
+		wantServer         string
+		wantConnectionType string
+	}{
+		{
+			name:               "client span to uninstrumented peer service",
+			serviceName:        "some-client-service",
+			kind:               ptrace.SpanKindClient,
+			attributes:         map[string]string{semconv.AttributePeerService: "payments", semconv.AttributeNetPeerName: "payments.local"},
+			wantClient:         "some-client-service",
+			wantServer:         "payments",
+			wantConnectionType: "virtual_node",
+		},
+		{
+			name:               "client span to uninstrumented host",
+			serviceName:        "some-client-service",
+			kind:               ptrace.SpanKindClient,
+			attributes:         map[string]string{semconv.AttributeNetPeerName: "api.example.com"},
+			wantClient:         "some-client-service",
+			wantServer:         "api.example.com",
+			wantConnectionType: "virtual_node",
+		},
+		{
+			name:               "client span without peer attributes",
+			serviceName:        "some-client-service",
+			kind:               ptrace.SpanKindClient,
+			wantClient:         "some-client-service",
+			wantServer:         "unknown",
+			wantConnectionType: "virtual_node",
+		},
+		{
+			name:               "root server span",
+			serviceName:        "some-server-service",
+			kind:               ptrace.SpanKindServer,
+			wantClient:         "user",
+			wantServer:         "some-server-service",
+			wantConnectionType: "virtual_node",
+		},
+		{
+			name:         "orphan server span",
+			serviceName:  "some-server-service",
+			kind:         ptrace.SpanKindServer,
+			parentSpanID: pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			p := newProcessor(zaptest.NewLogger(t), cfg)
+			p.store = store.NewStore(time.Nanosecond, 10, p.onComplete, p.onExpire)
+
+			traces := ptrace.NewTraces()
+			rs := traces.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, tc.serviceName)
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+			span.SetSpanID(pcommon.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+			span.SetParentSpanID(tc.parentSpanID)
+			span.SetKind(tc.kind)
+			span.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+			span.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+			for k, v := range tc.attributes {
+				span.Attributes().PutStr(k, v)
+			}
+
+			require.NoError(t, p.aggregateMetrics(context.Background(), traces))
+			time.Sleep(time.Millisecond)
+			p.store.Expire()
+
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			if tc.wantConnectionType == "" {
+				assert.Equal(t, 0, md.MetricCount())
+				return
+			}
+
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 2, ms.Len())
+
+			count := ms.At(0).Sum().DataPoints().At(0)
+			assert.Equal(t, int64(1), count.IntValue())
+			verifyAttr(t, count.Attributes(), "client", tc.wantClient)
+			verifyAttr(t, count.Attributes(), "server", tc.wantServer)
+			verifyAttr(t, count.Attributes(), "connection_type", tc.wantConnectionType)
+
+			duration := ms.At(1).Histogram().DataPoints().At(0)
+			assert.Equal(t, float64(1000), duration.Sum())
+		})
+	}
+}
+
+func TestDatabaseEdges(t *testing.T) {
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tEnd := time.Date(2022, 1, 2, 3, 4, 5, 500_000_006, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		attributes map[string]string
+		wantServer string
+	}{
+		{
+			name:       "database name",
+			attributes: map[string]string{semconv.AttributeDBName: "customers", semconv.AttributeDBSystem: "postgresql"},
+			wantServer: "customers",
+		},
+		{
+			name:       "database system",
+			attributes: map[string]string{semconv.AttributeDBSystem: "redis"},
+			wantServer: "redis",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			p := newProcessor(zaptest.NewLogger(t), cfg)
+			p.store = store.NewStore(time.Hour, 10, p.onComplete, p.onExpire)
+
+			traces := ptrace.NewTraces()
+			rs := traces.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-service")
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+			span.SetSpanID(pcommon.SpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1}))
+			span.SetKind(ptrace.SpanKindClient)
+			span.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+			span.SetEndTimestamp(pcommon.NewTimestampFromTime(tEnd))
+			for k, v := range tc.attributes {
+				span.Attributes().PutStr(k, v)
+			}
+
+			// Database requests don't wait for a server span.
+			require.NoError(t, p.aggregateMetrics(context.Background(), traces))
+
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 3, ms.Len())
+			assert.Equal(t, "traces_service_graph_request_total", ms.At(0).Name())
+			assert.Equal(t, "traces_service_graph_request_duration_seconds", ms.At(1).Name())
+
+			mDatabase := ms.At(2)
+			assert.Equal(t, "traces_service_graph_database_request_duration_seconds", mDatabase.Name())
+			dp := mDatabase.Histogram().DataPoints().At(0)
+			assert.Equal(t, uint64(1), dp.Count())
+			assert.Equal(t, float64(500), dp.Sum())
+			verifyAttr(t, dp.Attributes(), "client", "some-service")
+			verifyAttr(t, dp.Attributes(), "server", tc.wantServer)
+			verifyAttr(t, dp.Attributes(), "connection_type", "database")
+		})
+	}
+}
@@ -28,6 +28,16 @@ func findAttributeValue(key string, attributes ...pcommon.Map) (string, bool) {
 	return "", false
 }
 
+// findFirstAttributeValue returns the value of the first key found in the attributes, the higher the front, the higher the priority.
+func findFirstAttributeValue(keys []string, attributes ...pcommon.Map) (string, bool) {
+	for _, key := range keys {
+		if v, ok := findAttributeValue(key, attributes...); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
 func findServiceName(attributes pcommon.Map) (string, bool) {
 	return findAttributeValue(semconv.AttributeServiceName, attributes)
 }
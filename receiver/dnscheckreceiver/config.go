@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver/internal/metadata"
+)
+
+const (
+	defaultQueryType = "A"
+	defaultTransport = "udp"
+	defaultTimeout   = 5 * time.Second
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errMissingQueries    = errors.New(`no queries configured`)
+	errMissingName       = errors.New(`"name" not specified in config`)
+	errMissingServer     = errors.New(`"server" not specified in config`)
+	errInvalidServer     = errors.New(`"server" must be in the form of <hostname>[:<port>]`)
+	errInvalidQueryType  = errors.New(`"type" must be one of A, AAAA, CNAME, SRV or TXT`)
+	errInvalidTransport  = errors.New(`"transport" must be one of udp, tcp or tls`)
+	errConfigNotDNSCheck = errors.New("config was not a DNS check receiver config")
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	Queries                                 []*queryConfig `mapstructure:"queries"`
+}
+
+// queryConfig defines a DNS query and the resolver it is sent to.
+type queryConfig struct {
+	// Name is the domain name to query.
+	Name string `mapstructure:"name"`
+	// Type is the record type to query, A if not set.
+	Type string `mapstructure:"type"`
+	// Server is the resolver to query, as host:port. The port defaults to 53, or 853 for the tls transport.
+	Server string `mapstructure:"server"`
+	// Transport is one of udp, tcp or tls (DNS over TLS), udp if not set.
+	Transport string `mapstructure:"transport"`
+	// TLSSetting configures the tls transport.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+	// Timeout bounds the query, 5s if not set.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// ExpectedAnswers must all be present in the answer section of the response.
+	ExpectedAnswers []string `mapstructure:"expected_answers"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.Queries) == 0 {
+		err = multierr.Append(err, errMissingQueries)
+	}
+
+	for _, query := range cfg.Queries {
+		err = multierr.Append(err, query.Validate())
+	}
+
+	return err
+}
+
+// Validate validates the name, type, server and transport of the query.
+func (cfg *queryConfig) Validate() error {
+	var err error
+
+	if cfg.Name == "" {
+		err = multierr.Append(err, errMissingName)
+	}
+
+	if _, ok := metadata.MapAttributeDNSQueryType[cfg.queryType()]; !ok {
+		err = multierr.Append(err, errInvalidQueryType)
+	}
+
+	if _, ok := metadata.MapAttributeDNSTransport[cfg.transport()]; !ok {
+		err = multierr.Append(err, errInvalidTransport)
+	}
+
+	if cfg.Server == "" {
+		err = multierr.Append(err, errMissingServer)
+	} else if _, _, splitErr := net.SplitHostPort(cfg.address()); splitErr != nil {
+		err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidServer.Error(), splitErr))
+	}
+
+	return err
+}
+
+func (cfg *queryConfig) queryType() string {
+	if cfg.Type == "" {
+		return defaultQueryType
+	}
+	return strings.ToUpper(cfg.Type)
+}
+
+func (cfg *queryConfig) transport() string {
+	if cfg.Transport == "" {
+		return defaultTransport
+	}
+	return strings.ToLower(cfg.Transport)
+}
+
+// address returns the server address, with the default port of the transport if it has none.
+func (cfg *queryConfig) address() string {
+	if _, _, err := net.SplitHostPort(cfg.Server); err == nil {
+		return cfg.Server
+	}
+	port := "53"
+	if cfg.transport() == "tls" {
+		port = "853"
+	}
+	return net.JoinHostPort(strings.Trim(cfg.Server, "[]"), port)
+}
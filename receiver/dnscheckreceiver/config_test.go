@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver"
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.uber.org/multierr"
+)
+
+// check that OTel Collector patterns are implemented
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+	if err := componenttest.CheckConfigStruct(&Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewID(typeStr).String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.CollectionInterval = 30 * time.Second
+	expected.Queries = []*queryConfig{
+		{Name: "example.com", Server: "8.8.8.8"},
+		{
+			Name:            "_sip._tcp.example.com",
+			Type:            "SRV",
+			Server:          "10.0.0.2:5353",
+			Transport:       "tcp",
+			Timeout:         2 * time.Second,
+			ExpectedAnswers: []string{"10 5 5060 sip.example.com"},
+		},
+		{
+			Name:      "example.com",
+			Type:      "AAAA",
+			Server:    "dns.example.net",
+			Transport: "tls",
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "/etc/ssl/certs/ca.pem"},
+			},
+		},
+	}
+	require.Equal(t, expected, cfg)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			desc:        "no queries",
+			cfg:         &Config{},
+			expectedErr: errMissingQueries,
+		},
+		{
+			desc: "missing name and server",
+			cfg: &Config{
+				Queries: []*queryConfig{{}},
+			},
+			expectedErr: multierr.Combine(errMissingName, errMissingServer),
+		},
+		{
+			desc: "invalid server",
+			cfg: &Config{
+				Queries: []*queryConfig{{Name: "example.com", Server: "[::1]:53]"}},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`"server" must be in the form of <hostname>[:<port>]: address [::1]:53]:53: too many colons in address`),
+			),
+		},
+		{
+			desc: "invalid type",
+			cfg: &Config{
+				Queries: []*queryConfig{{Name: "example.com", Server: "10.0.0.1", Type: "MX"}},
+			},
+			expectedErr: errInvalidQueryType,
+		},
+		{
+			desc: "invalid transport",
+			cfg: &Config{
+				Queries: []*queryConfig{{Name: "example.com", Server: "10.0.0.1", Transport: "https"}},
+			},
+			expectedErr: errInvalidTransport,
+		},
+		{
+			desc: "valid config",
+			cfg: &Config{
+				Queries: []*queryConfig{
+					{Name: "example.com", Server: "10.0.0.1"},
+					{Name: "example.com", Server: "[2001:db8::1]:53", Type: "aaaa", Transport: "TCP"},
+					{Name: "example.com", Server: "2001:db8::1", Type: "TXT", Transport: "tls"},
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if tc.expectedErr != nil {
+				require.EqualError(t, actualErr, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+			}
+		})
+	}
+}
+
+func TestAddress(t *testing.T) {
+	testCases := []struct {
+		cfg      queryConfig
+		expected string
+	}{
+		{cfg: queryConfig{Server: "10.0.0.1"}, expected: "10.0.0.1:53"},
+		{cfg: queryConfig{Server: "10.0.0.1:5353"}, expected: "10.0.0.1:5353"},
+		{cfg: queryConfig{Server: "dns.example.net", Transport: "tls"}, expected: "dns.example.net:853"},
+		{cfg: queryConfig{Server: "2001:db8::1"}, expected: "[2001:db8::1]:53"},
+		{cfg: queryConfig{Server: "[2001:db8::1]"}, expected: "[2001:db8::1]:53"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.cfg.Server, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.cfg.address())
+		})
+	}
+}
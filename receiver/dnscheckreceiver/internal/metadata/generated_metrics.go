@@ -0,0 +1,583 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsSettings provides settings for dnscheckreceiver metrics.
+type MetricsSettings struct {
+	DnscheckAnswers      MetricSettings `mapstructure:"dnscheck.answers"`
+	DnscheckAnswersMatch MetricSettings `mapstructure:"dnscheck.answers.match"`
+	DnscheckDuration     MetricSettings `mapstructure:"dnscheck.duration"`
+	DnscheckError        MetricSettings `mapstructure:"dnscheck.error"`
+	DnscheckRcode        MetricSettings `mapstructure:"dnscheck.rcode"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		DnscheckAnswers: MetricSettings{
+			Enabled: true,
+		},
+		DnscheckAnswersMatch: MetricSettings{
+			Enabled: true,
+		},
+		DnscheckDuration: MetricSettings{
+			Enabled: true,
+		},
+		DnscheckError: MetricSettings{
+			Enabled: true,
+		},
+		DnscheckRcode: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeSettings provides common settings for a particular metric.
+type ResourceAttributeSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesSettings provides settings for dnscheckreceiver metrics.
+type ResourceAttributesSettings struct {
+}
+
+func DefaultResourceAttributesSettings() ResourceAttributesSettings {
+	return ResourceAttributesSettings{}
+}
+
+// AttributeDNSQueryType specifies the a value dns.query.type attribute.
+type AttributeDNSQueryType int
+
+const (
+	_ AttributeDNSQueryType = iota
+	AttributeDNSQueryTypeA
+	AttributeDNSQueryTypeAAAA
+	AttributeDNSQueryTypeCNAME
+	AttributeDNSQueryTypeSRV
+	AttributeDNSQueryTypeTXT
+)
+
+// String returns the string representation of the AttributeDNSQueryType.
+func (av AttributeDNSQueryType) String() string {
+	switch av {
+	case AttributeDNSQueryTypeA:
+		return "A"
+	case AttributeDNSQueryTypeAAAA:
+		return "AAAA"
+	case AttributeDNSQueryTypeCNAME:
+		return "CNAME"
+	case AttributeDNSQueryTypeSRV:
+		return "SRV"
+	case AttributeDNSQueryTypeTXT:
+		return "TXT"
+	}
+	return ""
+}
+
+// MapAttributeDNSQueryType is a helper map of string to AttributeDNSQueryType attribute value.
+var MapAttributeDNSQueryType = map[string]AttributeDNSQueryType{
+	"A":     AttributeDNSQueryTypeA,
+	"AAAA":  AttributeDNSQueryTypeAAAA,
+	"CNAME": AttributeDNSQueryTypeCNAME,
+	"SRV":   AttributeDNSQueryTypeSRV,
+	"TXT":   AttributeDNSQueryTypeTXT,
+}
+
+// AttributeDNSTransport specifies the a value dns.transport attribute.
+type AttributeDNSTransport int
+
+const (
+	_ AttributeDNSTransport = iota
+	AttributeDNSTransportUdp
+	AttributeDNSTransportTcp
+	AttributeDNSTransportTls
+)
+
+// String returns the string representation of the AttributeDNSTransport.
+func (av AttributeDNSTransport) String() string {
+	switch av {
+	case AttributeDNSTransportUdp:
+		return "udp"
+	case AttributeDNSTransportTcp:
+		return "tcp"
+	case AttributeDNSTransportTls:
+		return "tls"
+	}
+	return ""
+}
+
+// MapAttributeDNSTransport is a helper map of string to AttributeDNSTransport attribute value.
+var MapAttributeDNSTransport = map[string]AttributeDNSTransport{
+	"udp": AttributeDNSTransportUdp,
+	"tcp": AttributeDNSTransportTcp,
+	"tls": AttributeDNSTransportTls,
+}
+
+type metricDnscheckAnswers struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills dnscheck.answers metric with initial data.
+func (m *metricDnscheckAnswers) init() {
+	m.data.SetName("dnscheck.answers")
+	m.data.SetDescription("Number of records of the queried type in the answer section of the response.")
+	m.data.SetUnit("{record}")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricDnscheckAnswers) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue string, dnsServerAttributeValue string, dnsTransportAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("dns.query.name", dnsQueryNameAttributeValue)
+	dp.Attributes().PutStr("dns.query.type", dnsQueryTypeAttributeValue)
+	dp.Attributes().PutStr("dns.server", dnsServerAttributeValue)
+	dp.Attributes().PutStr("dns.transport", dnsTransportAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricDnscheckAnswers) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricDnscheckAnswers) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricDnscheckAnswers(settings MetricSettings) metricDnscheckAnswers {
+	m := metricDnscheckAnswers{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricDnscheckAnswersMatch struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills dnscheck.answers.match metric with initial data.
+func (m *metricDnscheckAnswersMatch) init() {
+	m.data.SetName("dnscheck.answers.match")
+	m.data.SetDescription("1 if every expected answer was found in the response, otherwise 0. Only reported for queries with expected answers.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricDnscheckAnswersMatch) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue string, dnsServerAttributeValue string, dnsTransportAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("dns.query.name", dnsQueryNameAttributeValue)
+	dp.Attributes().PutStr("dns.query.type", dnsQueryTypeAttributeValue)
+	dp.Attributes().PutStr("dns.server", dnsServerAttributeValue)
+	dp.Attributes().PutStr("dns.transport", dnsTransportAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricDnscheckAnswersMatch) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricDnscheckAnswersMatch) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricDnscheckAnswersMatch(settings MetricSettings) metricDnscheckAnswersMatch {
+	m := metricDnscheckAnswersMatch{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricDnscheckDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills dnscheck.duration metric with initial data.
+func (m *metricDnscheckDuration) init() {
+	m.data.SetName("dnscheck.duration")
+	m.data.SetDescription("Measures the round trip time of the DNS query.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricDnscheckDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue string, dnsServerAttributeValue string, dnsTransportAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("dns.query.name", dnsQueryNameAttributeValue)
+	dp.Attributes().PutStr("dns.query.type", dnsQueryTypeAttributeValue)
+	dp.Attributes().PutStr("dns.server", dnsServerAttributeValue)
+	dp.Attributes().PutStr("dns.transport", dnsTransportAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricDnscheckDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricDnscheckDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricDnscheckDuration(settings MetricSettings) metricDnscheckDuration {
+	m := metricDnscheckDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricDnscheckError struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills dnscheck.error metric with initial data.
+func (m *metricDnscheckError) init() {
+	m.data.SetName("dnscheck.error")
+	m.data.SetDescription("Records errors occurring during DNS check.")
+	m.data.SetUnit("{error}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricDnscheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue string, dnsServerAttributeValue string, dnsTransportAttributeValue string, errorMessageAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("dns.query.name", dnsQueryNameAttributeValue)
+	dp.Attributes().PutStr("dns.query.type", dnsQueryTypeAttributeValue)
+	dp.Attributes().PutStr("dns.server", dnsServerAttributeValue)
+	dp.Attributes().PutStr("dns.transport", dnsTransportAttributeValue)
+	dp.Attributes().PutStr("error.message", errorMessageAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricDnscheckError) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricDnscheckError) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricDnscheckError(settings MetricSettings) metricDnscheckError {
+	m := metricDnscheckError{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricDnscheckRcode struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills dnscheck.rcode metric with initial data.
+func (m *metricDnscheckRcode) init() {
+	m.data.SetName("dnscheck.rcode")
+	m.data.SetDescription("Response code returned by the resolver, 0 (NOERROR) on success.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricDnscheckRcode) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue string, dnsServerAttributeValue string, dnsTransportAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("dns.query.name", dnsQueryNameAttributeValue)
+	dp.Attributes().PutStr("dns.query.type", dnsQueryTypeAttributeValue)
+	dp.Attributes().PutStr("dns.server", dnsServerAttributeValue)
+	dp.Attributes().PutStr("dns.transport", dnsTransportAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricDnscheckRcode) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricDnscheckRcode) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricDnscheckRcode(settings MetricSettings) metricDnscheckRcode {
+	m := metricDnscheckRcode{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilderConfig is a structural subset of an otherwise 1-1 copy of metadata.yaml
+type MetricsBuilderConfig struct {
+	Metrics            MetricsSettings            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesSettings `mapstructure:"resource_attributes"`
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                  pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity            int                 // maximum observed number of metrics per resource.
+	resourceCapacity           int                 // maximum observed number of resource attributes.
+	metricsBuffer              pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                  component.BuildInfo // contains version information
+	resourceAttributesSettings ResourceAttributesSettings
+	metricDnscheckAnswers      metricDnscheckAnswers
+	metricDnscheckAnswersMatch metricDnscheckAnswersMatch
+	metricDnscheckDuration     metricDnscheckDuration
+	metricDnscheckError        metricDnscheckError
+	metricDnscheckRcode        metricDnscheckRcode
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsSettings(),
+		ResourceAttributes: DefaultResourceAttributesSettings(),
+	}
+}
+
+func NewMetricsBuilderConfig(ms MetricsSettings, ras ResourceAttributesSettings) MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            ms,
+		ResourceAttributes: ras,
+	}
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                  pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:              pmetric.NewMetrics(),
+		buildInfo:                  settings.BuildInfo,
+		resourceAttributesSettings: mbc.ResourceAttributes,
+		metricDnscheckAnswers:      newMetricDnscheckAnswers(mbc.Metrics.DnscheckAnswers),
+		metricDnscheckAnswersMatch: newMetricDnscheckAnswersMatch(mbc.Metrics.DnscheckAnswersMatch),
+		metricDnscheckDuration:     newMetricDnscheckDuration(mbc.Metrics.DnscheckDuration),
+		metricDnscheckError:        newMetricDnscheckError(mbc.Metrics.DnscheckError),
+		metricDnscheckRcode:        newMetricDnscheckRcode(mbc.Metrics.DnscheckRcode),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(ResourceAttributesSettings, pmetric.ResourceMetrics)
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(ras ResourceAttributesSettings, rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/dnscheckreceiver")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricDnscheckAnswers.emit(ils.Metrics())
+	mb.metricDnscheckAnswersMatch.emit(ils.Metrics())
+	mb.metricDnscheckDuration.emit(ils.Metrics())
+	mb.metricDnscheckError.emit(ils.Metrics())
+	mb.metricDnscheckRcode.emit(ils.Metrics())
+
+	for _, op := range rmo {
+		op(mb.resourceAttributesSettings, rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordDnscheckAnswersDataPoint adds a data point to dnscheck.answers metric.
+func (mb *MetricsBuilder) RecordDnscheckAnswersDataPoint(ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue AttributeDNSQueryType, dnsServerAttributeValue string, dnsTransportAttributeValue AttributeDNSTransport) {
+	mb.metricDnscheckAnswers.recordDataPoint(mb.startTime, ts, val, dnsQueryNameAttributeValue, dnsQueryTypeAttributeValue.String(), dnsServerAttributeValue, dnsTransportAttributeValue.String())
+}
+
+// RecordDnscheckAnswersMatchDataPoint adds a data point to dnscheck.answers.match metric.
+func (mb *MetricsBuilder) RecordDnscheckAnswersMatchDataPoint(ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue AttributeDNSQueryType, dnsServerAttributeValue string, dnsTransportAttributeValue AttributeDNSTransport) {
+	mb.metricDnscheckAnswersMatch.recordDataPoint(mb.startTime, ts, val, dnsQueryNameAttributeValue, dnsQueryTypeAttributeValue.String(), dnsServerAttributeValue, dnsTransportAttributeValue.String())
+}
+
+// RecordDnscheckDurationDataPoint adds a data point to dnscheck.duration metric.
+func (mb *MetricsBuilder) RecordDnscheckDurationDataPoint(ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue AttributeDNSQueryType, dnsServerAttributeValue string, dnsTransportAttributeValue AttributeDNSTransport) {
+	mb.metricDnscheckDuration.recordDataPoint(mb.startTime, ts, val, dnsQueryNameAttributeValue, dnsQueryTypeAttributeValue.String(), dnsServerAttributeValue, dnsTransportAttributeValue.String())
+}
+
+// RecordDnscheckErrorDataPoint adds a data point to dnscheck.error metric.
+func (mb *MetricsBuilder) RecordDnscheckErrorDataPoint(ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue AttributeDNSQueryType, dnsServerAttributeValue string, dnsTransportAttributeValue AttributeDNSTransport, errorMessageAttributeValue string) {
+	mb.metricDnscheckError.recordDataPoint(mb.startTime, ts, val, dnsQueryNameAttributeValue, dnsQueryTypeAttributeValue.String(), dnsServerAttributeValue, dnsTransportAttributeValue.String(), errorMessageAttributeValue)
+}
+
+// RecordDnscheckRcodeDataPoint adds a data point to dnscheck.rcode metric.
+func (mb *MetricsBuilder) RecordDnscheckRcodeDataPoint(ts pcommon.Timestamp, val int64, dnsQueryNameAttributeValue string, dnsQueryTypeAttributeValue AttributeDNSQueryType, dnsServerAttributeValue string, dnsTransportAttributeValue AttributeDNSTransport) {
+	mb.metricDnscheckRcode.recordDataPoint(mb.startTime, ts, val, dnsQueryNameAttributeValue, dnsQueryTypeAttributeValue.String(), dnsServerAttributeValue, dnsTransportAttributeValue.String())
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
@@ -0,0 +1,245 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type testConfigCollection int
+
+const (
+	testSetDefault testConfigCollection = iota
+	testSetAll
+	testSetNone
+)
+
+func TestMetricsBuilder(t *testing.T) {
+	tests := []struct {
+		name      string
+		configSet testConfigCollection
+	}{
+		{
+			name:      "default",
+			configSet: testSetDefault,
+		},
+		{
+			name:      "all_set",
+			configSet: testSetAll,
+		},
+		{
+			name:      "none_set",
+			configSet: testSetNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := pcommon.Timestamp(1_000_000_000)
+			ts := pcommon.Timestamp(1_000_001_000)
+			observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+			settings := receivertest.NewNopCreateSettings()
+			settings.Logger = zap.New(observedZapCore)
+			mb := NewMetricsBuilder(loadConfig(t, test.name), settings, WithStartTime(start))
+
+			expectedWarnings := 0
+			assert.Equal(t, expectedWarnings, observedLogs.Len())
+
+			defaultMetricsCount := 0
+			allMetricsCount := 0
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordDnscheckAnswersDataPoint(ts, 1, "attr-val", AttributeDNSQueryType(1), "attr-val", AttributeDNSTransport(1))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordDnscheckAnswersMatchDataPoint(ts, 1, "attr-val", AttributeDNSQueryType(1), "attr-val", AttributeDNSTransport(1))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordDnscheckDurationDataPoint(ts, 1, "attr-val", AttributeDNSQueryType(1), "attr-val", AttributeDNSTransport(1))
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordDnscheckErrorDataPoint(ts, 1, "attr-val", AttributeDNSQueryType(1), "attr-val", AttributeDNSTransport(1), "attr-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordDnscheckRcodeDataPoint(ts, 1, "attr-val", AttributeDNSQueryType(1), "attr-val", AttributeDNSTransport(1))
+
+			metrics := mb.Emit()
+
+			if test.configSet == testSetNone {
+				assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+				return
+			}
+
+			assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+			rm := metrics.ResourceMetrics().At(0)
+			attrCount := 0
+			enabledAttrCount := 0
+			assert.Equal(t, enabledAttrCount, rm.Resource().Attributes().Len())
+			assert.Equal(t, attrCount, 0)
+
+			assert.Equal(t, 1, rm.ScopeMetrics().Len())
+			ms := rm.ScopeMetrics().At(0).Metrics()
+			if test.configSet == testSetDefault {
+				assert.Equal(t, defaultMetricsCount, ms.Len())
+			}
+			if test.configSet == testSetAll {
+				assert.Equal(t, allMetricsCount, ms.Len())
+			}
+			validatedMetrics := make(map[string]bool)
+			for i := 0; i < ms.Len(); i++ {
+				switch ms.At(i).Name() {
+				case "dnscheck.answers":
+					assert.False(t, validatedMetrics["dnscheck.answers"], "Found a duplicate in the metrics slice: dnscheck.answers")
+					validatedMetrics["dnscheck.answers"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Number of records of the queried type in the answer section of the response.", ms.At(i).Description())
+					assert.Equal(t, "{record}", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("dns.query.name")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.query.type")
+					assert.True(t, ok)
+					assert.Equal(t, "A", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.server")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "udp", attrVal.Str())
+				case "dnscheck.answers.match":
+					assert.False(t, validatedMetrics["dnscheck.answers.match"], "Found a duplicate in the metrics slice: dnscheck.answers.match")
+					validatedMetrics["dnscheck.answers.match"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if every expected answer was found in the response, otherwise 0. Only reported for queries with expected answers.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("dns.query.name")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.query.type")
+					assert.True(t, ok)
+					assert.Equal(t, "A", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.server")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "udp", attrVal.Str())
+				case "dnscheck.duration":
+					assert.False(t, validatedMetrics["dnscheck.duration"], "Found a duplicate in the metrics slice: dnscheck.duration")
+					validatedMetrics["dnscheck.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Measures the round trip time of the DNS query.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("dns.query.name")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.query.type")
+					assert.True(t, ok)
+					assert.Equal(t, "A", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.server")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "udp", attrVal.Str())
+				case "dnscheck.error":
+					assert.False(t, validatedMetrics["dnscheck.error"], "Found a duplicate in the metrics slice: dnscheck.error")
+					validatedMetrics["dnscheck.error"] = true
+					assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+					assert.Equal(t, "Records errors occurring during DNS check.", ms.At(i).Description())
+					assert.Equal(t, "{error}", ms.At(i).Unit())
+					assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+					assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+					dp := ms.At(i).Sum().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("dns.query.name")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.query.type")
+					assert.True(t, ok)
+					assert.Equal(t, "A", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.server")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "udp", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("error.message")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+				case "dnscheck.rcode":
+					assert.False(t, validatedMetrics["dnscheck.rcode"], "Found a duplicate in the metrics slice: dnscheck.rcode")
+					validatedMetrics["dnscheck.rcode"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Response code returned by the resolver, 0 (NOERROR) on success.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("dns.query.name")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.query.type")
+					assert.True(t, ok)
+					assert.Equal(t, "A", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.server")
+					assert.True(t, ok)
+					assert.EqualValues(t, "attr-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("dns.transport")
+					assert.True(t, ok)
+					assert.Equal(t, "udp", attrVal.Str())
+				}
+			}
+		})
+	}
+}
+
+func loadConfig(t *testing.T, name string) MetricsBuilderConfig {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	sub, err := cm.Sub(name)
+	require.NoError(t, err)
+	cfg := DefaultMetricsBuilderConfig()
+	require.NoError(t, component.UnmarshalConfig(sub, &cfg))
+	return cfg
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver/internal/metadata"
+)
+
+// clientNetworks maps the configured transports to the networks of the DNS client.
+var clientNetworks = map[string]string{"udp": "udp", "tcp": "tcp", "tls": "tcp-tls"}
+
+type dnscheckScraper struct {
+	queries  []*dnsQuery
+	cfg      *Config
+	settings component.TelemetrySettings
+	mb       *metadata.MetricsBuilder
+	mu       sync.Mutex
+}
+
+// dnsQuery holds the client and resolved settings created for a configured query.
+type dnsQuery struct {
+	name      string
+	qtype     uint16
+	queryType metadata.AttributeDNSQueryType
+	server    string
+	transport metadata.AttributeDNSTransport
+	client    *dns.Client
+	expected  []string
+}
+
+// start starts the scraper by creating a DNS client for each query
+func (s *dnscheckScraper) start(_ context.Context, _ component.Host) error {
+	queries := make([]*dnsQuery, 0, len(s.cfg.Queries))
+	for _, cfg := range s.cfg.Queries {
+		timeout := cfg.Timeout
+		if timeout == 0 {
+			timeout = defaultTimeout
+		}
+		client := &dns.Client{Net: clientNetworks[cfg.transport()], Timeout: timeout}
+		if cfg.transport() == "tls" {
+			tlsCfg, err := cfg.TLSSetting.LoadTLSConfig()
+			if err != nil {
+				return err
+			}
+			client.TLSConfig = tlsCfg
+		}
+
+		query := &dnsQuery{
+			name:      dns.Fqdn(cfg.Name),
+			qtype:     dns.StringToType[cfg.queryType()],
+			queryType: metadata.MapAttributeDNSQueryType[cfg.queryType()],
+			server:    cfg.address(),
+			transport: metadata.MapAttributeDNSTransport[cfg.transport()],
+			client:    client,
+		}
+		for _, answer := range cfg.ExpectedAnswers {
+			query.expected = append(query.expected, normalizeAnswer(query.qtype, answer))
+		}
+		queries = append(queries, query)
+	}
+	s.queries = queries
+	return nil
+}
+
+// scrape runs every query concurrently and produces metrics based on the responses
+func (s *dnscheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var wg sync.WaitGroup
+	wg.Add(len(s.queries))
+	for _, query := range s.queries {
+		go func(query *dnsQuery) {
+			defer wg.Done()
+			s.check(ctx, query)
+		}(query)
+	}
+	wg.Wait()
+
+	return s.mb.Emit(), nil
+}
+
+func (s *dnscheckScraper) check(ctx context.Context, query *dnsQuery) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(query.name, query.qtype)
+
+	resp, rtt, err := query.client.ExchangeContext(ctx, msg, query.server)
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.mb.RecordDnscheckErrorDataPoint(now, int64(1), query.name, query.queryType, query.server, query.transport, err.Error())
+		return
+	}
+
+	answers := answerValues(resp, query.qtype)
+	s.mb.RecordDnscheckDurationDataPoint(now, rtt.Milliseconds(), query.name, query.queryType, query.server, query.transport)
+	s.mb.RecordDnscheckRcodeDataPoint(now, int64(resp.Rcode), query.name, query.queryType, query.server, query.transport)
+	s.mb.RecordDnscheckAnswersDataPoint(now, int64(len(answers)), query.name, query.queryType, query.server, query.transport)
+
+	if len(query.expected) > 0 {
+		matched := int64(1)
+		for _, expected := range query.expected {
+			if !answers[expected] {
+				matched = 0
+				break
+			}
+		}
+		s.mb.RecordDnscheckAnswersMatchDataPoint(now, matched, query.name, query.queryType, query.server, query.transport)
+	}
+}
+
+// answerValues returns the normalized values of the answers of the queried type.
+func answerValues(resp *dns.Msg, qtype uint16) map[string]bool {
+	values := map[string]bool{}
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		var value string
+		switch record := rr.(type) {
+		case *dns.A:
+			value = record.A.String()
+		case *dns.AAAA:
+			value = record.AAAA.String()
+		case *dns.CNAME:
+			value = record.Target
+		case *dns.SRV:
+			value = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, record.Target)
+		case *dns.TXT:
+			value = strings.Join(record.Txt, "")
+		}
+		values[normalizeAnswer(qtype, value)] = true
+	}
+	return values
+}
+
+// normalizeAnswer makes expected and actual answers comparable: IP addresses
+// are formatted canonically and domain names are lower cased without the
+// trailing dot. TXT answers are compared as is.
+func normalizeAnswer(qtype uint16, answer string) string {
+	switch qtype {
+	case dns.TypeA, dns.TypeAAAA:
+		if ip := net.ParseIP(strings.TrimSpace(answer)); ip != nil {
+			return ip.String()
+		}
+		return answer
+	case dns.TypeCNAME, dns.TypeSRV:
+		return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(answer), "."))
+	}
+	return answer
+}
+
+func newScraper(cfg *Config, settings receiver.CreateSettings) *dnscheckScraper {
+	return &dnscheckScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/dnscheckreceiver"
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// zone holds the records served by the test server.
+var zone = map[string][]string{
+	"example.com. A":             {"example.com. 300 IN A 192.0.2.1", "example.com. 300 IN A 192.0.2.2"},
+	"example.com. AAAA":          {"example.com. 300 IN AAAA 2001:db8::1"},
+	"example.com. TXT":           {`example.com. 300 IN TXT "v=spf1 " "-all"`},
+	"www.example.com. A":         {"www.example.com. 300 IN CNAME example.com.", "example.com. 300 IN A 192.0.2.1"},
+	"www.example.com. CNAME":     {"www.example.com. 300 IN CNAME Example.com."},
+	"_sip._tcp.example.com. SRV": {"_sip._tcp.example.com. 300 IN SRV 10 5 5060 sip.example.com."},
+}
+
+func handleQuery(w dns.ResponseWriter, req *dns.Msg) {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+
+	q := req.Question[0]
+	switch q.Name {
+	case "servfail.example.com.":
+		resp.Rcode = dns.RcodeServerFailure
+	case "slow.example.com.":
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	records, ok := zone[q.Name+" "+dns.TypeToString[q.Qtype]]
+	if !ok && resp.Rcode == dns.RcodeSuccess {
+		resp.Rcode = dns.RcodeNameError
+	}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			panic(err)
+		}
+		resp.Answer = append(resp.Answer, rr)
+	}
+	_ = w.WriteMsg(resp)
+}
+
+// startServer starts an in-process DNS server for the transport and returns its address.
+func startServer(t *testing.T, transport string, tlsCfg *tls.Config) string {
+	server := &dns.Server{Handler: dns.HandlerFunc(handleQuery)}
+	var addr string
+	switch transport {
+	case "udp":
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		server.PacketConn = pc
+		addr = pc.LocalAddr().String()
+	case "tcp":
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		server.Listener = l
+		addr = l.Addr().String()
+	case "tls":
+		l, err := tls.Listen("tcp", "127.0.0.1:0", tlsCfg)
+		require.NoError(t, err)
+		server.Listener = l
+		server.Net = "tcp-tls"
+		addr = l.Addr().String()
+	}
+
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return addr
+}
+
+// generateTLSConfig creates a server TLS config with a self-signed certificate for 127.0.0.1 and writes the certificate to caFile.
+func generateTLSConfig(t *testing.T, caFile string) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "dns.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+type checkResult struct {
+	duration map[string]int64
+	rcode    map[string]int64
+	answers  map[string]int64
+	match    map[string]int64
+	errors   map[string]string
+}
+
+// collect indexes the data points by "<name> <type> <transport>".
+func collect(t *testing.T, md pmetric.Metrics) checkResult {
+	result := checkResult{
+		duration: map[string]int64{},
+		rcode:    map[string]int64{},
+		answers:  map[string]int64{},
+		match:    map[string]int64{},
+		errors:   map[string]string{},
+	}
+	key := func(dp pmetric.NumberDataPoint) string {
+		name, _ := dp.Attributes().Get("dns.query.name")
+		qtype, _ := dp.Attributes().Get("dns.query.type")
+		transport, _ := dp.Attributes().Get("dns.transport")
+		_, ok := dp.Attributes().Get("dns.server")
+		require.True(t, ok)
+		return name.Str() + " " + qtype.Str() + " " + transport.Str()
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				var target map[string]int64
+				var dps pmetric.NumberDataPointSlice
+				switch m.Name() {
+				case "dnscheck.duration":
+					target, dps = result.duration, m.Gauge().DataPoints()
+				case "dnscheck.rcode":
+					target, dps = result.rcode, m.Gauge().DataPoints()
+				case "dnscheck.answers":
+					target, dps = result.answers, m.Gauge().DataPoints()
+				case "dnscheck.answers.match":
+					target, dps = result.match, m.Gauge().DataPoints()
+				case "dnscheck.error":
+					dps = m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						msg, _ := dps.At(l).Attributes().Get("error.message")
+						result.errors[key(dps.At(l))] = msg.Str()
+					}
+					continue
+				}
+				for l := 0; l < dps.Len(); l++ {
+					target[key(dps.At(l))] = dps.At(l).IntValue()
+				}
+			}
+		}
+	}
+	return result
+}
+
+func scrape(t *testing.T, queries ...*queryConfig) checkResult {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = queries
+	require.NoError(t, cfg.Validate())
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	return collect(t, md)
+}
+
+func TestScrapeRecordTypes(t *testing.T) {
+	server := startServer(t, "udp", nil)
+
+	result := scrape(t,
+		&queryConfig{Name: "example.com", Server: server, ExpectedAnswers: []string{"192.0.2.2", "192.0.2.1"}},
+		&queryConfig{Name: "example.com", Type: "AAAA", Server: server, ExpectedAnswers: []string{"2001:0db8::0001"}},
+		&queryConfig{Name: "www.example.com", Type: "CNAME", Server: server, ExpectedAnswers: []string{"example.com"}},
+		&queryConfig{Name: "_sip._tcp.example.com", Type: "SRV", Server: server, ExpectedAnswers: []string{"10 5 5060 sip.example.com."}},
+		&queryConfig{Name: "example.com", Type: "TXT", Server: server, ExpectedAnswers: []string{"v=spf1 -all"}},
+		// A CNAME in the answer of an A query is not counted as an answer.
+		&queryConfig{Name: "www.example.com", Server: server},
+	)
+
+	require.Empty(t, result.errors)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":             0,
+		"example.com. AAAA udp":          0,
+		"www.example.com. CNAME udp":     0,
+		"_sip._tcp.example.com. SRV udp": 0,
+		"example.com. TXT udp":           0,
+		"www.example.com. A udp":         0,
+	}, result.rcode)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":             2,
+		"example.com. AAAA udp":          1,
+		"www.example.com. CNAME udp":     1,
+		"_sip._tcp.example.com. SRV udp": 1,
+		"example.com. TXT udp":           1,
+		"www.example.com. A udp":         1,
+	}, result.answers)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":             1,
+		"example.com. AAAA udp":          1,
+		"www.example.com. CNAME udp":     1,
+		"_sip._tcp.example.com. SRV udp": 1,
+		"example.com. TXT udp":           1,
+	}, result.match)
+	require.Len(t, result.duration, 6)
+}
+
+func TestScrapeMismatchAndRcodes(t *testing.T) {
+	server := startServer(t, "udp", nil)
+
+	result := scrape(t,
+		&queryConfig{Name: "example.com", Server: server, ExpectedAnswers: []string{"192.0.2.1", "192.0.2.3"}},
+		&queryConfig{Name: "missing.example.com", Server: server, ExpectedAnswers: []string{"192.0.2.1"}},
+		&queryConfig{Name: "servfail.example.com", Server: server},
+	)
+
+	require.Empty(t, result.errors)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":          int64(dns.RcodeSuccess),
+		"missing.example.com. A udp":  int64(dns.RcodeNameError),
+		"servfail.example.com. A udp": int64(dns.RcodeServerFailure),
+	}, result.rcode)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":          2,
+		"missing.example.com. A udp":  0,
+		"servfail.example.com. A udp": 0,
+	}, result.answers)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp":         0,
+		"missing.example.com. A udp": 0,
+	}, result.match)
+}
+
+func TestScrapeTransports(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	tlsCfg := generateTLSConfig(t, caFile)
+
+	result := scrape(t,
+		&queryConfig{Name: "example.com", Server: startServer(t, "udp", nil), Transport: "udp"},
+		&queryConfig{Name: "example.com", Server: startServer(t, "tcp", nil), Transport: "tcp"},
+		&queryConfig{
+			Name:      "example.com",
+			Server:    startServer(t, "tls", tlsCfg),
+			Transport: "tls",
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: caFile},
+			},
+		},
+	)
+
+	require.Empty(t, result.errors)
+	require.Equal(t, map[string]int64{
+		"example.com. A udp": 2,
+		"example.com. A tcp": 2,
+		"example.com. A tls": 2,
+	}, result.answers)
+}
+
+func TestScrapeErrors(t *testing.T) {
+	server := startServer(t, "udp", nil)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	// The server certificate is not trusted without the CA file.
+	tlsServer := startServer(t, "tls", generateTLSConfig(t, caFile))
+
+	result := scrape(t,
+		&queryConfig{Name: "slow.example.com", Server: server, Timeout: 100 * time.Millisecond},
+		&queryConfig{Name: "example.com", Server: tlsServer, Transport: "tls"},
+	)
+
+	require.Empty(t, result.rcode)
+	require.Empty(t, result.duration)
+	require.Len(t, result.errors, 2)
+	require.Contains(t, result.errors["slow.example.com. A udp"], "timeout")
+	require.Contains(t, result.errors["example.com. A tls"], "certificate")
+}
+
+func TestScraperStartInvalidTLS(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Queries = []*queryConfig{
+		{
+			Name:      "example.com",
+			Server:    "127.0.0.1",
+			Transport: "tls",
+			TLSSetting: configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{CAFile: "/non/existent"},
+			},
+		},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.Error(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+}
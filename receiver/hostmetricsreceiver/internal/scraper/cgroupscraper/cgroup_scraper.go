@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	cpuMetricsLen    = 4
+	memoryMetricsLen = 3
+	ioMetricsLen     = 2
+	cgroupMetricsLen = cpuMetricsLen + memoryMetricsLen + ioMetricsLen
+
+	// cgroupMountPath is where the unified cgroup v2 hierarchy is mounted.
+	cgroupMountPath = "/sys/fs/cgroup"
+)
+
+// scraper for cgroup Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newCgroupScraper creates a cgroup Scraper
+func newCgroupScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	scraper := &scraper{
+		settings: settings,
+		config:   cfg,
+		bootTime: host.BootTime,
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(filepath.Join(s.mountPath(), "cgroup.controllers")); err != nil {
+		return fmt.Errorf("cgroup v2 hierarchy not found at %s: %w", s.mountPath(), err)
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) mountPath() string {
+	return filepath.Join(s.config.RootPath, cgroupMountPath)
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	mountPath := s.mountPath()
+	err := filepath.WalkDir(mountPath, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// cgroups may be removed while the hierarchy is being walked.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			// d is nil when the mount path itself cannot be read.
+			if d == nil || !d.IsDir() {
+				return err
+			}
+			// Skip the cgroups below an unreadable directory instead of failing the whole scrape.
+			s.settings.Logger.Debug("Skipping unreadable cgroup directory", zap.String("path", dir), zap.Error(err))
+			errs.AddPartial(cgroupMetricsLen, fmt.Errorf("failed to read cgroup directory %s: %w", dir, err))
+			return fs.SkipDir
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(mountPath, dir)
+		if err != nil {
+			return err
+		}
+		cgroupPath := "/" + filepath.ToSlash(rel)
+		if rel == "." {
+			cgroupPath = "/"
+		}
+		if !s.included(cgroupPath) {
+			return nil
+		}
+
+		s.scrapeCgroup(now, dir, &errs)
+		s.mb.EmitForResource(metadata.WithCgroupPath(cgroupPath))
+		return nil
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), err
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) included(cgroupPath string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(cgroupPath)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(cgroupPath))
+}
+
+func (s *scraper) scrapeCgroup(now pcommon.Timestamp, dir string, errs *scrapererror.ScrapeErrors) {
+	if err := s.scrapeCPU(now, dir); err != nil {
+		errs.AddPartial(cpuMetricsLen, err)
+	}
+	if err := s.scrapeMemory(now, dir); err != nil {
+		errs.AddPartial(memoryMetricsLen, err)
+	}
+	if err := s.scrapeIO(now, dir); err != nil {
+		errs.AddPartial(ioMetricsLen, err)
+	}
+}
+
+func (s *scraper) scrapeCPU(now pcommon.Timestamp, dir string) error {
+	stat, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+
+	if v, ok := stat["user_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, usecToSeconds(v), metadata.AttributeStateUser)
+	}
+	if v, ok := stat["system_usec"]; ok {
+		s.mb.RecordCgroupCPUTimeDataPoint(now, usecToSeconds(v), metadata.AttributeStateSystem)
+	}
+	// Throttling statistics are only present when the cpu controller is enabled.
+	if v, ok := stat["nr_periods"]; ok {
+		s.mb.RecordCgroupCPUPeriodsDataPoint(now, int64(v))
+	}
+	if v, ok := stat["nr_throttled"]; ok {
+		s.mb.RecordCgroupCPUThrottledPeriodsDataPoint(now, int64(v))
+	}
+	if v, ok := stat["throttled_usec"]; ok {
+		s.mb.RecordCgroupCPUThrottledTimeDataPoint(now, usecToSeconds(v))
+	}
+	return nil
+}
+
+func (s *scraper) scrapeMemory(now pcommon.Timestamp, dir string) error {
+	var errs error
+
+	current, _, err := readSingleValue(filepath.Join(dir, "memory.current"))
+	if err == nil {
+		s.mb.RecordCgroupMemoryUsageDataPoint(now, int64(current))
+	} else if err = ignoreNotExist(err); err != nil {
+		errs = err
+	}
+
+	limit, unlimited, err := readSingleValue(filepath.Join(dir, "memory.max"))
+	if err == nil {
+		if !unlimited {
+			s.mb.RecordCgroupMemoryLimitDataPoint(now, int64(limit))
+		}
+	} else if err = ignoreNotExist(err); err != nil && errs == nil {
+		errs = err
+	}
+
+	events, err := readFlatKeyed(filepath.Join(dir, "memory.events"))
+	if err == nil {
+		for name, v := range events {
+			if eventType, ok := metadata.MapAttributeMemoryEventType[name]; ok {
+				s.mb.RecordCgroupMemoryEventsDataPoint(now, int64(v), eventType)
+			}
+		}
+	} else if err = ignoreNotExist(err); err != nil && errs == nil {
+		errs = err
+	}
+
+	return errs
+}
+
+func (s *scraper) scrapeIO(now pcommon.Timestamp, dir string) error {
+	stats, err := readIOStat(filepath.Join(dir, "io.stat"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+
+	for device, stat := range stats {
+		s.mb.RecordCgroupIoBytesDataPoint(now, int64(stat["rbytes"]), device, metadata.AttributeDirectionRead)
+		s.mb.RecordCgroupIoBytesDataPoint(now, int64(stat["wbytes"]), device, metadata.AttributeDirectionWrite)
+		s.mb.RecordCgroupIoOperationsDataPoint(now, int64(stat["rios"]), device, metadata.AttributeDirectionRead)
+		s.mb.RecordCgroupIoOperationsDataPoint(now, int64(stat["wios"]), device, metadata.AttributeDirectionWrite)
+	}
+	return nil
+}
+
+// ignoreNotExist drops errors caused by missing interface files, which are
+// expected for controllers that are not enabled on a cgroup.
+func ignoreNotExist(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func usecToSeconds(usec uint64) float64 {
+	return float64(usec) / 1e6
+}
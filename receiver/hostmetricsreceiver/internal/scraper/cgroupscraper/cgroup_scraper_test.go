@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const bootTime = 100
+
+func newTestScraper(t *testing.T, rootPath string, include, exclude MatchConfig) *scraper {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: rootPath},
+		Include:              include,
+		Exclude:              exclude,
+	}
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// dataPoints indexes the scraped data points by cgroup path, metric name and
+// the string form of the data point attributes.
+func dataPoints(md pmetric.Metrics) map[string]map[string]map[string]pmetric.NumberDataPoint {
+	result := map[string]map[string]map[string]pmetric.NumberDataPoint{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, _ := rm.Resource().Attributes().Get("cgroup.path")
+		byName := map[string]map[string]pmetric.NumberDataPoint{}
+		result[path.Str()] = byName
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			m := metrics.At(j)
+			byAttrs := map[string]pmetric.NumberDataPoint{}
+			byName[m.Name()] = byAttrs
+			dps := m.Sum().DataPoints()
+			for k := 0; k < dps.Len(); k++ {
+				byAttrs[attributesKey(dps.At(k).Attributes())] = dps.At(k)
+			}
+		}
+	}
+	return result
+}
+
+func attributesKey(attrs pcommon.Map) string {
+	var keys []string
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		keys = append(keys, k+"="+v.AsString()+";")
+		return true
+	})
+	sort.Strings(keys)
+	return strings.Join(keys, "")
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, filepath.Join("testdata", "valid"), MatchConfig{}, MatchConfig{})
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	got := dataPoints(md)
+	require.Len(t, got, 4)
+	assert.Contains(t, got, "/")
+	assert.Contains(t, got, "/system.slice")
+	assert.Contains(t, got, "/system.slice/docker-abc.scope")
+	assert.Contains(t, got, "/user.slice")
+
+	// The root cgroup has no memory interface files.
+	root := got["/"]
+	assert.Equal(t, 6.0, root["cgroup.cpu.time"]["state=user;"].DoubleValue())
+	assert.Equal(t, 3.0, root["cgroup.cpu.time"]["state=system;"].DoubleValue())
+	assert.NotContains(t, root, "cgroup.cpu.periods")
+	assert.NotContains(t, root, "cgroup.memory.usage")
+	assert.Equal(t, int64(1048576), root["cgroup.io.bytes"]["device=8:0;direction=read;"].IntValue())
+	assert.Equal(t, int64(200), root["cgroup.io.operations"]["device=8:0;direction=write;"].IntValue())
+
+	container := got["/system.slice/docker-abc.scope"]
+	assert.Equal(t, 1.25, container["cgroup.cpu.time"]["state=user;"].DoubleValue())
+	assert.Equal(t, int64(120), container["cgroup.cpu.periods"][""].IntValue())
+	assert.Equal(t, int64(30), container["cgroup.cpu.throttled.periods"][""].IntValue())
+	assert.Equal(t, 1.5, container["cgroup.cpu.throttled.time"][""].DoubleValue())
+	assert.Equal(t, int64(268435456), container["cgroup.memory.usage"][""].IntValue())
+	assert.Equal(t, int64(536870912), container["cgroup.memory.limit"][""].IntValue())
+	assert.Equal(t, int64(5), container["cgroup.memory.events"]["type=max;"].IntValue())
+	assert.Equal(t, int64(1), container["cgroup.memory.events"]["type=oom_kill;"].IntValue())
+	assert.Equal(t, int64(40), container["cgroup.io.operations"]["device=8:0;direction=write;"].IntValue())
+
+	// An unlimited cgroup does not report a memory limit.
+	slice := got["/system.slice"]
+	assert.NotContains(t, slice, "cgroup.memory.limit")
+	assert.Len(t, slice["cgroup.io.bytes"], 4)
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), slice["cgroup.memory.usage"][""].StartTimestamp())
+
+	// Controllers that are not enabled are skipped.
+	user := got["/user.slice"]
+	assert.Equal(t, int64(134217728), user["cgroup.memory.usage"][""].IntValue())
+	assert.NotContains(t, user, "cgroup.io.bytes")
+	assert.NotContains(t, user, "cgroup.memory.events")
+}
+
+func TestScrapeWithFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		include MatchConfig
+		exclude MatchConfig
+		want    []string
+	}{
+		{
+			name: "include",
+			include: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Paths:  []string{"^/system\\.slice"},
+			},
+			want: []string{"/system.slice", "/system.slice/docker-abc.scope"},
+		},
+		{
+			name: "exclude",
+			exclude: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Paths:  []string{"/", "/user.slice"},
+			},
+			want: []string{"/system.slice", "/system.slice/docker-abc.scope"},
+		},
+		{
+			name: "include and exclude",
+			include: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Paths:  []string{".*\\.slice.*"},
+			},
+			exclude: MatchConfig{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Paths:  []string{"\\.scope$"},
+			},
+			want: []string{"/system.slice", "/user.slice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestScraper(t, filepath.Join("testdata", "valid"), tt.include, tt.exclude)
+			md, err := s.scrape(context.Background())
+			require.NoError(t, err)
+
+			var paths []string
+			for path := range dataPoints(md) {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tt.want, paths)
+		})
+	}
+}
+
+func TestScrapePartialError(t *testing.T) {
+	s := newTestScraper(t, filepath.Join("testdata", "invalid"), MatchConfig{}, MatchConfig{})
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, cpuMetricsLen, partialErr.Failed)
+
+	// The other metrics of the cgroup with an invalid file are still reported.
+	assert.Contains(t, dataPoints(md), "/init.scope")
+}
+
+func TestScrapeUnreadableDirectory(t *testing.T) {
+	if os.Getuid() == 0 {
+		t.Skip("directory permissions are not enforced for root")
+	}
+	rootPath := t.TempDir()
+	mountPath := filepath.Join(rootPath, cgroupMountPath)
+	unreadable := filepath.Join(mountPath, "unreadable.slice")
+	require.NoError(t, os.MkdirAll(filepath.Join(unreadable, "child.scope"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(mountPath, "cgroup.controllers"), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(mountPath, "cpu.stat"), []byte("user_usec 100\nsystem_usec 50\n"), 0600))
+	require.NoError(t, os.Chmod(unreadable, 0))
+	t.Cleanup(func() { require.NoError(t, os.Chmod(unreadable, 0700)) })
+
+	s := newTestScraper(t, rootPath, MatchConfig{}, MatchConfig{})
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "failed to read cgroup directory")
+
+	// The readable cgroups are still reported.
+	points := dataPoints(md)
+	assert.Contains(t, points, "/")
+	assert.NotContains(t, points, "/unreadable.slice/child.scope")
+}
+
+func TestStartWithoutCgroupV2(t *testing.T) {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: t.TempDir()},
+	}
+	s, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	assert.ErrorContains(t, s.start(context.Background(), componenttest.NewNopHost()), "cgroup v2 hierarchy not found")
+}
+
+func TestNewScraperInvalidFilter(t *testing.T) {
+	cfg := &Config{
+		Include: MatchConfig{
+			Config: filterset.Config{MatchType: filterset.Regexp},
+			Paths:  []string{"["},
+		},
+	}
+	_, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "error creating cgroup include filters")
+}
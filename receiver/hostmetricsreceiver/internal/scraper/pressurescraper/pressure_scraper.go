@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	// metricsLen is the number of metrics reported from each pressure file.
+	metricsLen = 2
+
+	// pressurePath is where the kernel exposes PSI files.
+	pressurePath = "/proc/pressure"
+)
+
+var resources = []metadata.AttributeResource{
+	metadata.AttributeResourceCpu,
+	metadata.AttributeResourceMemory,
+	metadata.AttributeResourceIo,
+}
+
+// scraper for Pressure Metrics
+type scraper struct {
+	settings receiver.CreateSettings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// pressureStat is a single line of a PSI file.
+type pressureStat struct {
+	avg10  float64
+	avg60  float64
+	avg300 float64
+	// total stall time in microseconds.
+	total uint64
+}
+
+// newPressureScraper creates a set of Pressure related metrics
+func newPressureScraper(_ context.Context, settings receiver.CreateSettings, cfg *Config) *scraper {
+	return &scraper{settings: settings, config: cfg, bootTime: host.BootTime}
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(s.pressurePath()); err != nil {
+		return fmt.Errorf("pressure stall information not available at %s: %w", s.pressurePath(), err)
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.MetricsBuilderConfig, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) pressurePath() string {
+	return filepath.Join(s.config.RootPath, pressurePath)
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, resource := range resources {
+		stats, err := readPressureFile(filepath.Join(s.pressurePath(), resource.String()))
+		if err != nil {
+			errs.AddPartial(metricsLen, err)
+			continue
+		}
+
+		for _, stallType := range []metadata.AttributeStallType{metadata.AttributeStallTypeSome, metadata.AttributeStallTypeFull} {
+			stat, ok := stats[stallType.String()]
+			if !ok {
+				continue
+			}
+			s.mb.RecordSystemPressureStallAverageDataPoint(now, stat.avg10, resource, stallType, metadata.AttributeWindow10s)
+			s.mb.RecordSystemPressureStallAverageDataPoint(now, stat.avg60, resource, stallType, metadata.AttributeWindow60s)
+			s.mb.RecordSystemPressureStallAverageDataPoint(now, stat.avg300, resource, stallType, metadata.AttributeWindow300s)
+			s.mb.RecordSystemPressureStallTimeDataPoint(now, float64(stat.total)/1e6, resource, stallType)
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+// readPressureFile parses a PSI file made of lines such as
+// "some avg10=0.12 avg60=0.05 avg300=0.01 total=123456", keyed by their first field.
+func readPressureFile(path string) (map[string]pressureStat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := make(map[string]pressureStat)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		var stat pressureStat
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid field in %s: %q", path, field)
+			}
+			switch key {
+			case "avg10":
+				stat.avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stat.avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stat.avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				stat.total, err = strconv.ParseUint(value, 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s in %s: %w", key, path, err)
+			}
+		}
+		result[fields[0]] = stat
+	}
+	return result, scanner.Err()
+}
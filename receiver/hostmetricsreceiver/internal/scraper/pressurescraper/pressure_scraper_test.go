@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const bootTime = 100
+
+func newTestScraper(t *testing.T, rootPath string) *scraper {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: rootPath},
+	}
+	s := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+// values indexes data point values by their resource, type and window attributes.
+func values(t *testing.T, m pmetric.Metric) map[string]float64 {
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	default:
+		t.Fatalf("unexpected metric type %v", m.Type())
+	}
+
+	result := map[string]float64{}
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		key := ""
+		for _, name := range []string{"resource", "type", "window"} {
+			if v, ok := attrs.Get(name); ok {
+				key += "/" + v.Str()
+			}
+		}
+		result[key] = dps.At(i).DoubleValue()
+	}
+	return result
+}
+
+func TestScrape(t *testing.T) {
+	s := newTestScraper(t, filepath.Join("testdata", "full"))
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	average := metrics.At(0)
+	assert.Equal(t, "system.pressure.stall.average", average.Name())
+	averages := values(t, average)
+	assert.Len(t, averages, 18)
+	assert.Equal(t, 1.5, averages["/cpu/some/10s"])
+	assert.Equal(t, 0.25, averages["/cpu/some/300s"])
+	assert.Equal(t, 0.1, averages["/memory/full/60s"])
+	assert.Equal(t, 12.34, averages["/io/some/10s"])
+
+	stallTime := metrics.At(1)
+	assert.Equal(t, "system.pressure.stall.time", stallTime.Name())
+	assert.True(t, stallTime.Sum().IsMonotonic())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), stallTime.Sum().DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, map[string]float64{
+		"/cpu/some":    12.345678,
+		"/cpu/full":    0,
+		"/memory/some": 0.5,
+		"/memory/full": 0.25,
+		"/io/some":     98.765432,
+		"/io/full":     80,
+	}, values(t, stallTime))
+}
+
+func TestScrapePartialError(t *testing.T) {
+	s := newTestScraper(t, filepath.Join("testdata", "partial"))
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	var partialErr scrapererror.PartialScrapeError
+	require.ErrorAs(t, err, &partialErr)
+	// Both the invalid memory file and the missing io file failed.
+	assert.Equal(t, 2*metricsLen, partialErr.Failed)
+
+	// Kernels that predate the cpu "full" line only report "some".
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, map[string]float64{"/cpu/some": 1}, values(t, metrics.At(1)))
+}
+
+func TestStartWithoutPressure(t *testing.T) {
+	cfg := &Config{
+		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		ScraperConfig:        internal.ScraperConfig{RootPath: t.TempDir()},
+	}
+	s := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	s.bootTime = func() (uint64, error) { return bootTime, nil }
+	assert.ErrorContains(t, s.start(context.Background(), componenttest.NewNopHost()), "pressure stall information not available")
+}
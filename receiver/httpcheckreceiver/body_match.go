@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// bodyMatcher checks a response body against a single configured expression.
+type bodyMatcher struct {
+	matchType  metadata.AttributeMatchType
+	expression string
+	match      func(body []byte) bool
+}
+
+func newBodyMatcher(cfg bodyMatchConfig) (*bodyMatcher, error) {
+	switch {
+	case cfg.Contains != "":
+		substr := []byte(cfg.Contains)
+		return &bodyMatcher{
+			matchType:  metadata.AttributeMatchTypeContains,
+			expression: cfg.Contains,
+			match:      func(body []byte) bool { return bytes.Contains(body, substr) },
+		}, nil
+	case cfg.Regex != "":
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, err
+		}
+		return &bodyMatcher{
+			matchType:  metadata.AttributeMatchTypeRegex,
+			expression: cfg.Regex,
+			match:      re.Match,
+		}, nil
+	case cfg.JSONPath != "":
+		path, err := parseJSONPath(cfg.JSONPath)
+		if err != nil {
+			return nil, err
+		}
+		value := cfg.Value
+		return &bodyMatcher{
+			matchType:  metadata.AttributeMatchTypeJSONPath,
+			expression: cfg.JSONPath,
+			match:      func(body []byte) bool { return matchJSONPath(body, path, value) },
+		}, nil
+	}
+	return nil, errInvalidBodyMatcher
+}
+
+// jsonPathSegment is either an object key or, when key is empty, an array index.
+type jsonPathSegment struct {
+	key   string
+	index int
+}
+
+// parseJSONPath parses the subset of JSONPath supported by body matchers:
+// dotted object keys and bracketed array indices, optionally prefixed by "$.",
+// for example "$.data.items[0].status".
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if rest == "" {
+		return nil, fmt.Errorf("invalid json_path %q: empty path", path)
+	}
+
+	var segments []jsonPathSegment
+	for _, part := range strings.Split(rest, ".") {
+		key, indices, hasIndices := strings.Cut(part, "[")
+		if key == "" && !hasIndices {
+			return nil, fmt.Errorf("invalid json_path %q: empty key", path)
+		}
+		if key != "" {
+			segments = append(segments, jsonPathSegment{key: key})
+		}
+		if !hasIndices {
+			continue
+		}
+		if !strings.HasSuffix(indices, "]") {
+			return nil, fmt.Errorf("invalid json_path %q: unterminated index", path)
+		}
+		for _, idx := range strings.Split(strings.TrimSuffix(indices, "]"), "][") {
+			i, err := strconv.Atoi(idx)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid json_path %q: invalid index %q", path, idx)
+			}
+			segments = append(segments, jsonPathSegment{index: i})
+		}
+	}
+	return segments, nil
+}
+
+// matchJSONPath reports whether body is a JSON document holding a value at path
+// and, if expected is set, whether that value's string form equals expected.
+func matchJSONPath(body []byte, path []jsonPathSegment, expected string) bool {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var current interface{}
+	if err := decoder.Decode(&current); err != nil {
+		return false
+	}
+
+	for _, segment := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if segment.key == "" {
+				return false
+			}
+			value, ok := node[segment.key]
+			if !ok {
+				return false
+			}
+			current = value
+		case []interface{}:
+			if segment.key != "" || segment.index >= len(node) {
+				return false
+			}
+			current = node[segment.index]
+		default:
+			return false
+		}
+	}
+
+	if expected == "" {
+		return true
+	}
+	switch value := current.(type) {
+	case string:
+		return value == expected
+	case json.Number:
+		return value.String() == expected
+	case bool:
+		return strconv.FormatBool(value) == expected
+	case nil:
+		return expected == "null"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchJSONPath(t *testing.T) {
+	body := []byte(`{"a":{"b":[{"c":"x"},{"c":null}],"n":1.50,"t":false},"m":[[1,2],[3]]}`)
+
+	testCases := []struct {
+		path     string
+		value    string
+		expected bool
+	}{
+		{path: "$.a.b[0].c", expected: true},
+		{path: "$.a.b[0].c", value: "x", expected: true},
+		{path: "$.a.b[0].c", value: "y", expected: false},
+		{path: "a.b[1].c", value: "null", expected: true},
+		{path: "a.n", value: "1.50", expected: true},
+		{path: "a.t", value: "false", expected: true},
+		{path: "$.m[0][1]", value: "2", expected: true},
+		{path: "$.m[1][1]", expected: false},
+		{path: "$.a[0]", expected: false},
+		{path: "$.m.a", expected: false},
+		{path: "$.missing", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path+"="+tc.value, func(t *testing.T) {
+			path, err := parseJSONPath(tc.path)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, matchJSONPath(body, path, tc.value))
+		})
+	}
+
+	path, err := parseJSONPath("$.a")
+	require.NoError(t, err)
+	require.False(t, matchJSONPath([]byte(`not json`), path, ""))
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	for _, path := range []string{"", "$", "$.", "$.a..b", "$.a[", "$.a[-1]", "$.a[x]"} {
+		_, err := parseJSONPath(path)
+		require.Error(t, err, path)
+	}
+}
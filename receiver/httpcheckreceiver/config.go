@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -28,28 +29,128 @@ import (
 
 // Predefined error responses for configuration validation failures
 var (
-	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errInvalidEndpoint    = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errMissingTargets     = errors.New(`no targets configured`)
+	errTargetsAndEndpoint = errors.New(`"endpoint" and "method" cannot be used with "targets"`)
+	errInvalidBodyMatcher = errors.New(`exactly one of "contains", "regex" or "json_path" must be set`)
 )
 
-const defaultEndpoint = "http://localhost:80"
+const defaultMethod = "GET"
 
 // Config defines the configuration for the various elements of the receiver agent.
 type Config struct {
 	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
-	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
-	Method                                  string `mapstructure:"method"`
+	Targets                                 []*targetConfig `mapstructure:"targets"`
+
+	// Deprecated: [v0.73.0] use Targets instead. When set, the endpoint is checked as a single target.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	// Deprecated: [v0.73.0] use the method of each of the Targets instead.
+	Method string `mapstructure:"method"`
+}
+
+// usesDeprecatedEndpoint returns whether the top-level endpoint settings are used instead of Targets.
+func (cfg *Config) usesDeprecatedEndpoint() bool {
+	return cfg.Endpoint != "" || cfg.Method != ""
+}
+
+// targets returns the configured targets, or a single target made of the deprecated
+// top-level endpoint settings.
+func (cfg *Config) targets() []*targetConfig {
+	if len(cfg.Targets) != 0 || !cfg.usesDeprecatedEndpoint() {
+		return cfg.Targets
+	}
+	return []*targetConfig{{
+		HTTPClientSettings: cfg.HTTPClientSettings,
+		Method:             cfg.Method,
+	}}
+}
+
+// targetConfig defines an endpoint to check and how to check it.
+type targetConfig struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	// Method is the HTTP method used to call the endpoint, GET if not set.
+	Method string `mapstructure:"method"`
+	// BodyMatches are checked against the response body, each producing a httpcheck.body.match data point.
+	BodyMatches []bodyMatchConfig `mapstructure:"body_matches"`
+}
+
+// bodyMatchConfig defines a check on the response body. Exactly one of
+// Contains, Regex or JSONPath must be set.
+type bodyMatchConfig struct {
+	// Contains matches if the body contains the substring.
+	Contains string `mapstructure:"contains"`
+	// Regex matches if the body matches the regular expression.
+	Regex string `mapstructure:"regex"`
+	// JSONPath matches if the body is a JSON document with a value at the path,
+	// equal to Value when Value is set.
+	JSONPath string `mapstructure:"json_path"`
+	Value    string `mapstructure:"value"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
 func (cfg *Config) Validate() error {
 	var err error
 
+	switch {
+	case len(cfg.Targets) != 0 && cfg.usesDeprecatedEndpoint():
+		err = multierr.Append(err, errTargetsAndEndpoint)
+	case len(cfg.targets()) == 0:
+		err = multierr.Append(err, errMissingTargets)
+	}
+
+	for _, target := range cfg.targets() {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	return err
+}
+
+// Validate validates the target endpoint and body matchers.
+func (cfg *targetConfig) Validate() error {
+	var err error
+
+	if cfg.Endpoint == "" {
+		err = multierr.Append(err, errInvalidEndpoint)
+	}
+
 	_, parseErr := url.Parse(cfg.Endpoint)
 	if parseErr != nil {
 		wrappedErr := fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr)
 		err = multierr.Append(err, wrappedErr)
 	}
 
+	for _, match := range cfg.BodyMatches {
+		err = multierr.Append(err, match.Validate())
+	}
+
 	return err
 }
+
+// Validate checks that exactly one matcher is set and that it compiles.
+func (cfg *bodyMatchConfig) Validate() error {
+	set := 0
+	for _, expr := range []string{cfg.Contains, cfg.Regex, cfg.JSONPath} {
+		if expr != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errInvalidBodyMatcher
+	}
+
+	if cfg.Regex != "" {
+		if _, err := regexp.Compile(cfg.Regex); err != nil {
+			return fmt.Errorf("invalid body match regex %q: %w", cfg.Regex, err)
+		}
+	}
+	if cfg.JSONPath != "" {
+		if _, err := parseJSONPath(cfg.JSONPath); err != nil {
+			return err
+		}
+	}
+	if cfg.Value != "" && cfg.JSONPath == "" {
+		return errors.New(`"value" can only be used with "json_path"`)
+	}
+	return nil
+}
@@ -31,21 +31,150 @@ func TestValidate(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			desc: "invalid endpoint",
+			desc:        "missing targets",
+			cfg:         &Config{},
+			expectedErr: errMissingTargets,
+		},
+		{
+			desc: "deprecated endpoint",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:80",
+				},
+				Method: "HEAD",
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "deprecated endpoint with targets",
 			cfg: &Config{
 				HTTPClientSettings: confighttp.HTTPClientSettings{
-					Endpoint: "invalid://endpoint:  12efg",
+					Endpoint: "http://localhost:80",
+				},
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:81",
+						},
+					},
+				},
+			},
+			expectedErr: errTargetsAndEndpoint,
+		},
+		{
+			desc: "invalid endpoint",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "invalid://endpoint:  12efg",
+						},
+					},
 				},
 			},
 			expectedErr: multierr.Combine(
 				fmt.Errorf("%s: %w", errInvalidEndpoint, errors.New(`parse "invalid://endpoint:  12efg": invalid port ":  12efg" after host`)),
 			),
 		},
+		{
+			desc: "missing endpoint",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{},
+				},
+			},
+			expectedErr: errInvalidEndpoint,
+		},
+		{
+			desc: "body matcher without expression",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+						BodyMatches: []bodyMatchConfig{{}},
+					},
+				},
+			},
+			expectedErr: errInvalidBodyMatcher,
+		},
+		{
+			desc: "body matcher with several expressions",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+						BodyMatches: []bodyMatchConfig{{Contains: "ok", Regex: "ok"}},
+					},
+				},
+			},
+			expectedErr: errInvalidBodyMatcher,
+		},
+		{
+			desc: "invalid body match regex",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+						BodyMatches: []bodyMatchConfig{{Regex: "ok("}},
+					},
+				},
+			},
+			expectedErr: errors.New("invalid body match regex \"ok(\": error parsing regexp: missing closing ): `ok(`"),
+		},
+		{
+			desc: "invalid json path",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+						BodyMatches: []bodyMatchConfig{{JSONPath: "$.items[a]"}},
+					},
+				},
+			},
+			expectedErr: errors.New(`invalid json_path "$.items[a]": invalid index "a"`),
+		},
+		{
+			desc: "value without json path",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+						BodyMatches: []bodyMatchConfig{{Contains: "ok", Value: "ok"}},
+					},
+				},
+			},
+			expectedErr: errors.New(`"value" can only be used with "json_path"`),
+		},
 		{
 			desc: "valid config",
 			cfg: &Config{
-				HTTPClientSettings: confighttp.HTTPClientSettings{
-					Endpoint: defaultEndpoint,
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://localhost:80",
+						},
+					},
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "https://localhost:443/health",
+						},
+						Method: "HEAD",
+						BodyMatches: []bodyMatchConfig{
+							{Contains: "ok"},
+							{Regex: "^ok$"},
+							{JSONPath: "$.status", Value: "ok"},
+						},
+					},
 				},
 			},
 			expectedErr: nil,
@@ -20,10 +20,10 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
@@ -48,12 +48,7 @@ func createDefaultConfig() component.Config {
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
 			CollectionInterval: 10 * time.Second,
 		},
-		HTTPClientSettings: confighttp.HTTPClientSettings{
-			Endpoint: defaultEndpoint,
-			Timeout:  10 * time.Second,
-		},
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
-		Method:               "GET",
 	}
 }
 
@@ -63,6 +58,11 @@ func createMetricsReceiver(ctx context.Context, params receiver.CreateSettings,
 		return nil, errConfigNotHTTPCheck
 	}
 
+	if cfg.usesDeprecatedEndpoint() {
+		params.Logger.Warn("the top-level \"endpoint\" and \"method\" settings are deprecated, use \"targets\" instead",
+			zap.String("endpoint", cfg.Endpoint))
+	}
+
 	httpcheckScraper := newScraper(cfg, params)
 	scraper, err := scraperhelper.NewScraper(typeStr, httpcheckScraper.scrape, scraperhelper.WithStart(httpcheckScraper.start))
 	if err != nil {
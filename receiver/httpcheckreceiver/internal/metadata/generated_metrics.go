@@ -33,22 +33,34 @@ func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsSettings provides settings for httpcheckreceiver metrics.
 type MetricsSettings struct {
-	HttpcheckDuration MetricSettings `mapstructure:"httpcheck.duration"`
-	HttpcheckError    MetricSettings `mapstructure:"httpcheck.error"`
-	HttpcheckStatus   MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckBodyMatch        MetricSettings `mapstructure:"httpcheck.body.match"`
+	HttpcheckDuration         MetricSettings `mapstructure:"httpcheck.duration"`
+	HttpcheckError            MetricSettings `mapstructure:"httpcheck.error"`
+	HttpcheckPhaseDuration    MetricSettings `mapstructure:"httpcheck.phase.duration"`
+	HttpcheckStatus           MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckTLSCertRemaining MetricSettings `mapstructure:"httpcheck.tls.cert_remaining"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		HttpcheckBodyMatch: MetricSettings{
+			Enabled: true,
+		},
 		HttpcheckDuration: MetricSettings{
 			Enabled: true,
 		},
 		HttpcheckError: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckPhaseDuration: MetricSettings{
+			Enabled: false,
+		},
 		HttpcheckStatus: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckTLSCertRemaining: MetricSettings{
+			Enabled: true,
+		},
 	}
 }
 
@@ -65,6 +77,126 @@ func DefaultResourceAttributesSettings() ResourceAttributesSettings {
 	return ResourceAttributesSettings{}
 }
 
+// AttributeMatchType specifies the a value match.type attribute.
+type AttributeMatchType int
+
+const (
+	_ AttributeMatchType = iota
+	AttributeMatchTypeContains
+	AttributeMatchTypeRegex
+	AttributeMatchTypeJSONPath
+)
+
+// String returns the string representation of the AttributeMatchType.
+func (av AttributeMatchType) String() string {
+	switch av {
+	case AttributeMatchTypeContains:
+		return "contains"
+	case AttributeMatchTypeRegex:
+		return "regex"
+	case AttributeMatchTypeJSONPath:
+		return "json_path"
+	}
+	return ""
+}
+
+// MapAttributeMatchType is a helper map of string to AttributeMatchType attribute value.
+var MapAttributeMatchType = map[string]AttributeMatchType{
+	"contains":  AttributeMatchTypeContains,
+	"regex":     AttributeMatchTypeRegex,
+	"json_path": AttributeMatchTypeJSONPath,
+}
+
+// AttributePhase specifies the a value phase attribute.
+type AttributePhase int
+
+const (
+	_ AttributePhase = iota
+	AttributePhaseDns
+	AttributePhaseConnect
+	AttributePhaseTls
+	AttributePhaseTtfb
+)
+
+// String returns the string representation of the AttributePhase.
+func (av AttributePhase) String() string {
+	switch av {
+	case AttributePhaseDns:
+		return "dns"
+	case AttributePhaseConnect:
+		return "connect"
+	case AttributePhaseTls:
+		return "tls"
+	case AttributePhaseTtfb:
+		return "ttfb"
+	}
+	return ""
+}
+
+// MapAttributePhase is a helper map of string to AttributePhase attribute value.
+var MapAttributePhase = map[string]AttributePhase{
+	"dns":     AttributePhaseDns,
+	"connect": AttributePhaseConnect,
+	"tls":     AttributePhaseTls,
+	"ttfb":    AttributePhaseTtfb,
+}
+
+type metricHttpcheckBodyMatch struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.body.match metric with initial data.
+func (m *metricHttpcheckBodyMatch) init() {
+	m.data.SetName("httpcheck.body.match")
+	m.data.SetDescription("1 if the response body matched the configured matcher, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckBodyMatch) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, matchTypeAttributeValue string, matchExpressionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
+	dp.Attributes().PutStr("match.type", matchTypeAttributeValue)
+	dp.Attributes().PutStr("match.expression", matchExpressionAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckBodyMatch) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckBodyMatch) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckBodyMatch(settings MetricSettings) metricHttpcheckBodyMatch {
+	m := metricHttpcheckBodyMatch{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckDuration struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -80,7 +212,7 @@ func (m *metricHttpcheckDuration) init() {
 	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricHttpcheckDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+func (m *metricHttpcheckDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string) {
 	if !m.settings.Enabled {
 		return
 	}
@@ -89,6 +221,7 @@ func (m *metricHttpcheckDuration) recordDataPoint(start pcommon.Timestamp, ts pc
 	dp.SetTimestamp(ts)
 	dp.SetIntValue(val)
 	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
 }
 
 // updateCapacity saves max length of data point slices that will be used for the slice capacity.
@@ -133,7 +266,7 @@ func (m *metricHttpcheckError) init() {
 	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
 }
 
-func (m *metricHttpcheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, errorMessageAttributeValue string) {
+func (m *metricHttpcheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, errorMessageAttributeValue string) {
 	if !m.settings.Enabled {
 		return
 	}
@@ -142,6 +275,7 @@ func (m *metricHttpcheckError) recordDataPoint(start pcommon.Timestamp, ts pcomm
 	dp.SetTimestamp(ts)
 	dp.SetIntValue(val)
 	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
 	dp.Attributes().PutStr("error.message", errorMessageAttributeValue)
 }
 
@@ -170,6 +304,59 @@ func newMetricHttpcheckError(settings MetricSettings) metricHttpcheckError {
 	return m
 }
 
+type metricHttpcheckPhaseDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.phase.duration metric with initial data.
+func (m *metricHttpcheckPhaseDuration) init() {
+	m.data.SetName("httpcheck.phase.duration")
+	m.data.SetDescription("Measures the duration of each phase of the HTTP check. The ttfb phase is measured from the start of the request.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckPhaseDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, phaseAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
+	dp.Attributes().PutStr("phase", phaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckPhaseDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckPhaseDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckPhaseDuration(settings MetricSettings) metricHttpcheckPhaseDuration {
+	m := metricHttpcheckPhaseDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -226,6 +413,58 @@ func newMetricHttpcheckStatus(settings MetricSettings) metricHttpcheckStatus {
 	return m
 }
 
+type metricHttpcheckTLSCertRemaining struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.cert_remaining metric with initial data.
+func (m *metricHttpcheckTLSCertRemaining) init() {
+	m.data.SetName("httpcheck.tls.cert_remaining")
+	m.data.SetDescription("Time remaining until the certificate presented by the endpoint expires. Negative once it has expired.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertRemaining) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.method", httpMethodAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertRemaining) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertRemaining) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertRemaining(settings MetricSettings) metricHttpcheckTLSCertRemaining {
+	m := metricHttpcheckTLSCertRemaining{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilderConfig is a structural subset of an otherwise 1-1 copy of metadata.yaml
 type MetricsBuilderConfig struct {
 	Metrics            MetricsSettings            `mapstructure:"metrics"`
@@ -235,15 +474,18 @@ type MetricsBuilderConfig struct {
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user settings.
 type MetricsBuilder struct {
-	startTime                  pcommon.Timestamp   // start time that will be applied to all recorded data points.
-	metricsCapacity            int                 // maximum observed number of metrics per resource.
-	resourceCapacity           int                 // maximum observed number of resource attributes.
-	metricsBuffer              pmetric.Metrics     // accumulates metrics data before emitting.
-	buildInfo                  component.BuildInfo // contains version information
-	resourceAttributesSettings ResourceAttributesSettings
-	metricHttpcheckDuration    metricHttpcheckDuration
-	metricHttpcheckError       metricHttpcheckError
-	metricHttpcheckStatus      metricHttpcheckStatus
+	startTime                       pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                 int                 // maximum observed number of metrics per resource.
+	resourceCapacity                int                 // maximum observed number of resource attributes.
+	metricsBuffer                   pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                       component.BuildInfo // contains version information
+	resourceAttributesSettings      ResourceAttributesSettings
+	metricHttpcheckBodyMatch        metricHttpcheckBodyMatch
+	metricHttpcheckDuration         metricHttpcheckDuration
+	metricHttpcheckError            metricHttpcheckError
+	metricHttpcheckPhaseDuration    metricHttpcheckPhaseDuration
+	metricHttpcheckStatus           metricHttpcheckStatus
+	metricHttpcheckTLSCertRemaining metricHttpcheckTLSCertRemaining
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -272,13 +514,16 @@ func NewMetricsBuilderConfig(ms MetricsSettings, ras ResourceAttributesSettings)
 
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		startTime:                  pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:              pmetric.NewMetrics(),
-		buildInfo:                  settings.BuildInfo,
-		resourceAttributesSettings: mbc.ResourceAttributes,
-		metricHttpcheckDuration:    newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
-		metricHttpcheckError:       newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
-		metricHttpcheckStatus:      newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		startTime:                       pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                   pmetric.NewMetrics(),
+		buildInfo:                       settings.BuildInfo,
+		resourceAttributesSettings:      mbc.ResourceAttributes,
+		metricHttpcheckBodyMatch:        newMetricHttpcheckBodyMatch(mbc.Metrics.HttpcheckBodyMatch),
+		metricHttpcheckDuration:         newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
+		metricHttpcheckError:            newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
+		metricHttpcheckPhaseDuration:    newMetricHttpcheckPhaseDuration(mbc.Metrics.HttpcheckPhaseDuration),
+		metricHttpcheckStatus:           newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		metricHttpcheckTLSCertRemaining: newMetricHttpcheckTLSCertRemaining(mbc.Metrics.HttpcheckTLSCertRemaining),
 	}
 	for _, op := range options {
 		op(mb)
@@ -331,9 +576,12 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/httpcheckreceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricHttpcheckBodyMatch.emit(ils.Metrics())
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckPhaseDuration.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertRemaining.emit(ils.Metrics())
 
 	for _, op := range rmo {
 		op(mb.resourceAttributesSettings, rm)
@@ -354,14 +602,24 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordHttpcheckBodyMatchDataPoint adds a data point to httpcheck.body.match metric.
+func (mb *MetricsBuilder) RecordHttpcheckBodyMatchDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, matchTypeAttributeValue AttributeMatchType, matchExpressionAttributeValue string) {
+	mb.metricHttpcheckBodyMatch.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue, matchTypeAttributeValue.String(), matchExpressionAttributeValue)
+}
+
 // RecordHttpcheckDurationDataPoint adds a data point to httpcheck.duration metric.
-func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
-	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string) {
+	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue)
 }
 
 // RecordHttpcheckErrorDataPoint adds a data point to httpcheck.error metric.
-func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, errorMessageAttributeValue string) {
-	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorMessageAttributeValue)
+func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, errorMessageAttributeValue string) {
+	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue, errorMessageAttributeValue)
+}
+
+// RecordHttpcheckPhaseDurationDataPoint adds a data point to httpcheck.phase.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckPhaseDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string, phaseAttributeValue AttributePhase) {
+	mb.metricHttpcheckPhaseDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue, phaseAttributeValue.String())
 }
 
 // RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
@@ -369,6 +627,11 @@ func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, v
 	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpStatusCodeAttributeValue, httpMethodAttributeValue, httpStatusClassAttributeValue)
 }
 
+// RecordHttpcheckTLSCertRemainingDataPoint adds a data point to httpcheck.tls.cert_remaining metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertRemainingDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpMethodAttributeValue string) {
+	mb.metricHttpcheckTLSCertRemaining.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpMethodAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
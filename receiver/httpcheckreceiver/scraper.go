@@ -16,70 +16,233 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptrace"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
+const (
+	defaultTimeout = 10 * time.Second
+	// maxBodySize limits how much of a response body is read for body matching.
+	maxBodySize = 1 << 20
+)
+
 var (
 	errClientNotInit    = errors.New("client not initialized")
 	httpResponseClasses = map[string]int{"1xx": 1, "2xx": 2, "3xx": 3, "4xx": 4, "5xx": 5}
 )
 
 type httpcheckScraper struct {
-	client   *http.Client
+	targets  []*httpcheckTarget
 	cfg      *Config
 	settings component.TelemetrySettings
 	mb       *metadata.MetricsBuilder
+	mu       sync.Mutex
+}
+
+// httpcheckTarget holds the client and matchers created for a configured target.
+type httpcheckTarget struct {
+	cfg      *targetConfig
+	client   *http.Client
+	matchers []*bodyMatcher
 }
 
-// start starts the scraper by creating a new HTTP Client on the scraper
-func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err error) {
-	h.client, err = h.cfg.ToClient(host, h.settings)
-	return
+// start starts the scraper by creating a new HTTP Client for each target
+func (h *httpcheckScraper) start(_ context.Context, host component.Host) error {
+	targetConfigs := h.cfg.targets()
+	targets := make([]*httpcheckTarget, 0, len(targetConfigs))
+	for _, cfg := range targetConfigs {
+		clientSettings := cfg.HTTPClientSettings
+		if clientSettings.Timeout == 0 {
+			clientSettings.Timeout = defaultTimeout
+		}
+		client, err := clientSettings.ToClient(host, h.settings)
+		if err != nil {
+			return err
+		}
+
+		target := &httpcheckTarget{cfg: cfg, client: client}
+		for _, matchCfg := range cfg.BodyMatches {
+			matcher, err := newBodyMatcher(matchCfg)
+			if err != nil {
+				return err
+			}
+			target.matchers = append(target.matchers, matcher)
+		}
+		targets = append(targets, target)
+	}
+	h.targets = targets
+	return nil
 }
 
-// scrape connects to the endpoint and produces metrics based on the response
+// scrape connects to each target concurrently and produces metrics based on the responses
 func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	if h.client == nil {
+	if len(h.targets) == 0 {
 		return pmetric.NewMetrics(), errClientNotInit
 	}
 
+	var wg sync.WaitGroup
+	wg.Add(len(h.targets))
+	for _, target := range h.targets {
+		go func(target *httpcheckTarget) {
+			defer wg.Done()
+			h.check(ctx, target)
+		}(target)
+	}
+	wg.Wait()
+
+	return h.mb.Emit(), nil
+}
+
+// check performs a single request against the target and records its metrics.
+func (h *httpcheckScraper) check(ctx context.Context, target *httpcheckTarget) {
 	now := pcommon.NewTimestampFromTime(time.Now())
+	endpoint := target.cfg.Endpoint
 
-	req, err := http.NewRequestWithContext(ctx, h.cfg.Method, h.cfg.Endpoint, http.NoBody)
+	method := target.cfg.Method
+	if method == "" {
+		method = defaultMethod
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, http.NoBody)
 	if err != nil {
-		return pmetric.Metrics{}, err
+		h.settings.Logger.Error("failed to create request", zap.String("endpoint", endpoint), zap.Error(err))
+		return
 	}
+	// Always open a new connection so that every check measures DNS, connect and TLS.
+	req.Close = true
+
+	timing := &requestTiming{}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timing.clientTrace()))
 
 	start := time.Now()
-	resp, err := h.client.Do(req)
-	h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Endpoint)
+	resp, err := target.client.Do(req)
+	duration := time.Since(start)
+
+	var body []byte
+	if err == nil {
+		body = readBody(resp.Body, len(target.matchers) > 0)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), endpoint, method)
+	timing.record(h.mb, now, endpoint, method, start)
 
 	statusCode := 0
 	if err != nil {
-		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), h.cfg.Endpoint, err.Error())
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), endpoint, method, err.Error())
 	} else {
 		statusCode = resp.StatusCode
+		if cert := peerCertificateExpiry(resp.TLS); !cert.IsZero() {
+			h.mb.RecordHttpcheckTLSCertRemainingDataPoint(now, int64(time.Until(cert).Seconds()), endpoint, method)
+		}
 	}
 
 	for class, intVal := range httpResponseClasses {
 		if statusCode/100 == intVal {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), endpoint, int64(statusCode), method, class)
 		} else {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), endpoint, int64(statusCode), method, class)
 		}
+	}
 
+	for _, matcher := range target.matchers {
+		matched := int64(0)
+		if err == nil && matcher.match(body) {
+			matched = 1
+		}
+		h.mb.RecordHttpcheckBodyMatchDataPoint(now, matched, endpoint, method, matcher.matchType, matcher.expression)
 	}
+}
 
-	return h.mb.Emit(), nil
+// readBody drains and closes the response body, returning its content when keep is set.
+func readBody(rc io.ReadCloser, keep bool) []byte {
+	defer rc.Close()
+	var body []byte
+	if keep {
+		body, _ = io.ReadAll(io.LimitReader(rc, maxBodySize))
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	return body
+}
+
+// peerCertificateExpiry returns the expiry of the leaf certificate presented by the server, if any.
+func peerCertificateExpiry(state *tls.ConnectionState) time.Time {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return time.Time{}
+	}
+	return state.PeerCertificates[0].NotAfter
+}
+
+// requestTiming collects the timestamps of the phases of a request reported by httptrace.
+type requestTiming struct {
+	mu           sync.Mutex
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+}
+
+func (t *requestTiming) clientTrace() *httptrace.ClientTrace {
+	set := func(field *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		*field = time.Now()
+	}
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { set(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { set(&t.dnsDone) },
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			// Several connection attempts may be made, measure from the first one.
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+		},
+		ConnectDone:          func(string, string, error) { set(&t.connectDone) },
+		TLSHandshakeStart:    func() { set(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { set(&t.tlsDone) },
+		GotFirstResponseByte: func() { set(&t.firstByte) },
+	}
+}
+
+// record records the duration of every phase the request went through.
+func (t *requestTiming) record(mb *metadata.MetricsBuilder, now pcommon.Timestamp, endpoint string, method string, start time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, phase := range []struct {
+		attr       metadata.AttributePhase
+		start, end time.Time
+	}{
+		{metadata.AttributePhaseDns, t.dnsStart, t.dnsDone},
+		{metadata.AttributePhaseConnect, t.connectStart, t.connectDone},
+		{metadata.AttributePhaseTls, t.tlsStart, t.tlsDone},
+		{metadata.AttributePhaseTtfb, start, t.firstByte},
+	} {
+		if phase.start.IsZero() || phase.end.IsZero() {
+			continue
+		}
+		mb.RecordHttpcheckPhaseDurationDataPoint(now, phase.end.Sub(phase.start).Milliseconds(), endpoint, method, phase.attr)
+	}
 }
 
 func newScraper(conf *Config, settings receiver.CreateSettings) *httpcheckScraper {
@@ -20,6 +20,7 @@ import (
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -52,11 +53,15 @@ func TestScraperStart(t *testing.T) {
 			desc: "Bad Config",
 			scraper: &httpcheckScraper{
 				cfg: &Config{
-					HTTPClientSettings: confighttp.HTTPClientSettings{
-						Endpoint: defaultEndpoint,
-						TLSSetting: configtls.TLSClientSetting{
-							TLSSetting: configtls.TLSSetting{
-								CAFile: "/non/existent",
+					Targets: []*targetConfig{
+						{
+							HTTPClientSettings: confighttp.HTTPClientSettings{
+								Endpoint: "http://localhost:80",
+								TLSSetting: configtls.TLSClientSetting{
+									TLSSetting: configtls.TLSSetting{
+										CAFile: "/non/existent",
+									},
+								},
 							},
 						},
 					},
@@ -69,9 +74,13 @@ func TestScraperStart(t *testing.T) {
 			desc: "Valid Config",
 			scraper: &httpcheckScraper{
 				cfg: &Config{
-					HTTPClientSettings: confighttp.HTTPClientSettings{
-						TLSSetting: configtls.TLSClientSetting{},
-						Endpoint:   defaultEndpoint,
+					Targets: []*targetConfig{
+						{
+							HTTPClientSettings: confighttp.HTTPClientSettings{
+								TLSSetting: configtls.TLSClientSetting{},
+								Endpoint:   "http://localhost:80",
+							},
+						},
 					},
 				},
 				settings: componenttest.NewNopTelemetrySettings(),
@@ -160,13 +169,15 @@ func TestScaperScrape(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			cfg := createDefaultConfig().(*Config)
+			target := &targetConfig{}
 			if len(tc.endpoint) > 0 {
-				cfg.Endpoint = tc.endpoint
+				target.Endpoint = tc.endpoint
 			} else {
 				ms := newMockServer(t, tc.expectedResponse)
 				defer ms.Close()
-				cfg.Endpoint = ms.URL
+				target.Endpoint = ms.URL
 			}
+			cfg.Targets = []*targetConfig{target}
 			scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
 			require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
 
@@ -191,3 +202,233 @@ func TestNilClient(t *testing.T) {
 	require.NoError(t, pmetrictest.CompareMetrics(pmetric.NewMetrics(), actualMetrics))
 
 }
+
+func TestScraperScrapeMultipleTargets(t *testing.T) {
+	okServer := newMockServer(t, http.StatusOK)
+	defer okServer.Close()
+	var method string
+	errServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer errServer.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: okServer.URL}},
+		{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: errServer.URL}, Method: http.MethodHead},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.MethodHead, method)
+
+	statusCodes := map[string]int64{}
+	durations := 0
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		url, _ := dp.Attributes().Get("http.url")
+		switch name {
+		case "httpcheck.status":
+			code, _ := dp.Attributes().Get("http.status_code")
+			statusCodes[url.Str()] = code.Int()
+		case "httpcheck.duration":
+			durations++
+		}
+	})
+	require.Equal(t, map[string]int64{okServer.URL: 200, errServer.URL: 503}, statusCodes)
+	require.Equal(t, 2, durations)
+}
+
+func TestScraperScrapeSameEndpointMethods(t *testing.T) {
+	server := newMockServer(t, http.StatusOK)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL}},
+		{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL}, Method: http.MethodHead},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	var methods []string
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		if name == "httpcheck.duration" {
+			method, _ := dp.Attributes().Get("http.method")
+			methods = append(methods, method.Str())
+		}
+	})
+	require.ElementsMatch(t, []string{http.MethodGet, http.MethodHead}, methods)
+}
+
+func TestScraperScrapeDeprecatedEndpoint(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		method = req.Method
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Method = http.MethodPost
+	require.NoError(t, cfg.Validate())
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, method)
+
+	durations := 0
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		if name == "httpcheck.duration" {
+			url, _ := dp.Attributes().Get("http.url")
+			require.Equal(t, server.URL, url.Str())
+			durations++
+		}
+	})
+	require.Equal(t, 1, durations)
+}
+
+func TestScraperScrapeBodyMatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, err := rw.Write([]byte(`{"status":"ok","version":"1.2.3","checks":[{"name":"db","healthy":true}],"replicas":3}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL},
+			BodyMatches: []bodyMatchConfig{
+				{Contains: `"status":"ok"`},
+				{Contains: "degraded"},
+				{Regex: `"version":"1\.\d+\.\d+"`},
+				{JSONPath: "$.checks[0].healthy", Value: "true"},
+				{JSONPath: "replicas", Value: "3"},
+				{JSONPath: "$.checks[1]"},
+			},
+		},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	matches := map[string]int64{}
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		if name != "httpcheck.body.match" {
+			return
+		}
+		matchType, _ := dp.Attributes().Get("match.type")
+		expression, _ := dp.Attributes().Get("match.expression")
+		matches[matchType.Str()+" "+expression.Str()] = dp.IntValue()
+	})
+	require.Equal(t, map[string]int64{
+		`contains "status":"ok"`:        1,
+		`contains degraded`:             0,
+		`regex "version":"1\.\d+\.\d+"`: 1,
+		`json_path $.checks[0].healthy`: 1,
+		`json_path replicas`:            1,
+		`json_path $.checks[1]`:         0,
+	}, matches)
+}
+
+func TestScraperScrapeBodyMatchesOnError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: "http://invalid-endpoint"},
+			BodyMatches:        []bodyMatchConfig{{Contains: "ok"}},
+		},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	var matches []int64
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		if name == "httpcheck.body.match" {
+			matches = append(matches, dp.IntValue())
+		}
+	})
+	require.Equal(t, []int64{0}, matches)
+}
+
+func TestScraperScrapeTLSAndPhases(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsBuilderConfig.Metrics.HttpcheckPhaseDuration.Enabled = true
+	cfg.Targets = []*targetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: server.URL,
+				TLSSetting: configtls.TLSClientSetting{
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	expectedRemaining := int64(time.Until(server.Certificate().NotAfter).Seconds())
+	var certRemaining []int64
+	var phases []string
+	forEachDataPoint(actualMetrics, func(name string, dp pmetric.NumberDataPoint) {
+		switch name {
+		case "httpcheck.tls.cert_remaining":
+			certRemaining = append(certRemaining, dp.IntValue())
+		case "httpcheck.phase.duration":
+			phase, _ := dp.Attributes().Get("phase")
+			phases = append(phases, phase.Str())
+		}
+	})
+	require.Len(t, certRemaining, 1)
+	require.InDelta(t, expectedRemaining, certRemaining[0], 60)
+	// The server listens on an IP address, so no DNS lookup takes place.
+	require.ElementsMatch(t, []string{"connect", "tls", "ttfb"}, phases)
+}
+
+func forEachDataPoint(md pmetric.Metrics, fn func(name string, dp pmetric.NumberDataPoint)) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				var dps pmetric.NumberDataPointSlice
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps = m.Gauge().DataPoints()
+				case pmetric.MetricTypeSum:
+					dps = m.Sum().DataPoints()
+				default:
+					continue
+				}
+				for l := 0; l < dps.Len(); l++ {
+					fn(m.Name(), dps.At(l))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config defines configuration for the Loki receiver.
+type Config struct {
+	confighttp.HTTPServerSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxMessageSize is the maximum size in bytes of a push request once decompressed.
+	// Larger requests are rejected.
+	MaxMessageSize int64 `mapstructure:"max_message_size"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	if cfg.MaxMessageSize <= 0 {
+		return errors.New("\"max_message_size\" must be positive")
+	}
+	return nil
+}
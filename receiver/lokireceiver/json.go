@@ -0,0 +1,124 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/loki/pkg/push"
+)
+
+// jsonPushRequest is the JSON representation of a push request, as documented in
+// https://grafana.com/docs/loki/latest/reference/api/#push-log-entries-to-loki.
+type jsonPushRequest struct {
+	Streams []jsonStream `json:"streams"`
+}
+
+type jsonStream struct {
+	Stream map[string]string `json:"stream"`
+	Values []jsonEntry       `json:"values"`
+}
+
+// jsonEntry is an entry encoded as a `[<unix epoch in nanoseconds>, <line>, <structured metadata>]`
+// array, where the structured metadata is optional.
+type jsonEntry push.Entry
+
+func (e *jsonEntry) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 2 && len(fields) != 3 {
+		return fmt.Errorf("entry must have 2 or 3 elements, got %d", len(fields))
+	}
+
+	var ts string
+	if err := json.Unmarshal(fields[0], &ts); err != nil {
+		return fmt.Errorf("invalid entry timestamp: %w", err)
+	}
+	nanos, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid entry timestamp: %w", err)
+	}
+	e.Timestamp = time.Unix(0, nanos)
+
+	if err = json.Unmarshal(fields[1], &e.Line); err != nil {
+		return fmt.Errorf("invalid entry line: %w", err)
+	}
+
+	if len(fields) == 3 {
+		var metadata map[string]string
+		if err = json.Unmarshal(fields[2], &metadata); err != nil {
+			return fmt.Errorf("invalid entry structured metadata: %w", err)
+		}
+		for name, value := range metadata {
+			e.StructuredMetadata = append(e.StructuredMetadata, push.LabelAdapter{Name: name, Value: value})
+		}
+		sort.Slice(e.StructuredMetadata, func(i, j int) bool {
+			return e.StructuredMetadata[i].Name < e.StructuredMetadata[j].Name
+		})
+	}
+
+	return nil
+}
+
+// decodeJSONPushRequest decodes a JSON push request into its protobuf representation.
+func decodeJSONPushRequest(r io.Reader) (*push.PushRequest, error) {
+	var req jsonPushRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	pushReq := &push.PushRequest{Streams: make([]push.Stream, 0, len(req.Streams))}
+	for _, stream := range req.Streams {
+		entries := make([]push.Entry, len(stream.Values))
+		for i, entry := range stream.Values {
+			entries[i] = push.Entry(entry)
+		}
+		pushReq.Streams = append(pushReq.Streams, push.Stream{
+			Labels:  labelsString(stream.Stream),
+			Entries: entries,
+		})
+	}
+	return pushReq, nil
+}
+
+// labelsString formats labels as a stream selector, e.g. `{host="node-1", job="varlogs"}`.
+func labelsString(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
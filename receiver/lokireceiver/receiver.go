@@ -0,0 +1,217 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/lokireceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+const (
+	pushPath = "/loki/api/v1/push"
+
+	tenantHeader = "X-Scope-OrgID"
+	// tenantAttribute is the resource attribute holding the tenant of the push request.
+	tenantAttribute = "loki.tenant.id"
+
+	jsonContentType = "application/json"
+	jsonFormat      = "json"
+	protobufFormat  = "protobuf"
+)
+
+var (
+	errNilNextConsumer = errors.New("nil next consumer")
+	errMessageTooLarge = errors.New("push request too large")
+)
+
+type lokiReceiver struct {
+	config       *Config
+	settings     receiver.CreateSettings
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+func newLogsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Logs) (*lokiReceiver, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lokiReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+func (r *lokiReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(pushPath, r.handlePush)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, router)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *lokiReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *lokiReceiver) handlePush(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, r.config.MaxMessageSize)
+	pushReq, format, err := decodePushRequest(req, r.config.MaxMessageSize)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) || errors.Is(err, errMessageTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("failed to decode push request: %v", err), status)
+		return
+	}
+
+	logs, err := loki.PushRequestToLogs(pushReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	count := logs.LogRecordCount()
+	if count == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if tenant := req.Header.Get(tenantHeader); tenant != "" {
+		logs.ResourceLogs().At(0).Resource().Attributes().PutStr(tenantAttribute, tenant)
+	}
+
+	ctx := r.obsrecv.StartLogsOp(req.Context())
+	err = r.nextConsumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, format, count, err)
+	if err != nil {
+		r.settings.Logger.Debug("failed to pass logs to next consumer", zap.Error(err))
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodePushRequest decodes the body of the request, either as JSON or as snappy-compressed
+// protobuf, like Loki does. Requests compressed with gzip or deflate have already been
+// decompressed by the HTTP server. The snappy-decoded size is checked against maxSize
+// before decoding.
+func decodePushRequest(req *http.Request, maxSize int64) (*push.PushRequest, string, error) {
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" {
+		return nil, "", fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if contentType == jsonContentType {
+		pushReq, err := decodeJSONPushRequest(req.Body)
+		return pushReq, jsonFormat, err
+	}
+
+	compressed, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, protobufFormat, err
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, protobufFormat, err
+	}
+	if int64(size) > maxSize {
+		return nil, protobufFormat, fmt.Errorf("%w: decoded size %d exceeds %d bytes", errMessageTooLarge, size, maxSize)
+	}
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, protobufFormat, err
+	}
+	pushReq := &push.PushRequest{}
+	if err = pushReq.Unmarshal(buf); err != nil {
+		return nil, protobufFormat, err
+	}
+	return pushReq, protobufFormat, nil
+}
@@ -0,0 +1,335 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokireceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Logs) string {
+	return startReceiverWithConfig(t, createDefaultConfig().(*Config), nextConsumer)
+}
+
+func startReceiverWithConfig(t *testing.T, cfg *Config, nextConsumer consumer.Logs) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r, err := newLogsReceiver(cfg, receivertest.NewNopCreateSettings(), nextConsumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	return "http://" + cfg.Endpoint + pushPath
+}
+
+func post(t *testing.T, url string, body []byte, headers map[string]string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func encodeProtobuf(t *testing.T, req *push.PushRequest) []byte {
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func TestPushProtobufRoundTrip(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host_name", "guarana")
+	rl.Resource().Attributes().PutStr("tenant_id", "acme")
+	rl.Resource().Attributes().PutStr("loki.resource.labels", "host_name")
+	rl.Resource().Attributes().PutStr("loki.tenant", "tenant_id")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 1676888496000000000)))
+	lr.Body().SetStr("hello")
+
+	requests := loki.LogsToLokiRequests(ld)
+	require.Len(t, requests, 1)
+	request, ok := requests["acme"]
+	require.True(t, ok)
+
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, sink)
+
+	resp := post(t, url, encodeProtobuf(t, request.PushRequest), map[string]string{
+		"Content-Type": "application/x-protobuf",
+		tenantHeader:   "acme",
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllLogs(), 1)
+	logs := sink.AllLogs()[0]
+	require.Equal(t, 1, logs.LogRecordCount())
+
+	tenant, ok := logs.ResourceLogs().At(0).Resource().Attributes().Get(tenantAttribute)
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, lr.Timestamp(), record.Timestamp())
+	assert.Equal(t, `{"body":"hello"}`, record.Body().Str())
+	assert.Equal(t, map[string]interface{}{
+		"exporter":  "OTLP",
+		"host_name": "guarana",
+		"tenant_id": "acme",
+	}, record.Attributes().AsRaw())
+}
+
+func TestPushJSON(t *testing.T) {
+	body := []byte(`{"streams": [{"stream": {"job": "varlogs", "host": "node-1"}, "values": [` +
+		`["1676888496000000000", "first line"],` +
+		`["1676888497000000000", "second line", {"trace_id": "0102", "user": "jane"}]]}]}`)
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, err := gw.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	testCases := []struct {
+		desc    string
+		body    []byte
+		headers map[string]string
+	}{
+		{
+			desc:    "plain",
+			body:    body,
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			desc:    "with charset",
+			body:    body,
+			headers: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		},
+		{
+			desc: "gzip",
+			body: gzipped.Bytes(),
+			headers: map[string]string{
+				"Content-Type":     "application/json",
+				"Content-Encoding": "gzip",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			sink := new(consumertest.LogsSink)
+			url := startReceiver(t, sink)
+
+			resp := post(t, url, tC.body, tC.headers)
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+			require.Len(t, sink.AllLogs(), 1)
+			logs := sink.AllLogs()[0]
+			assert.Equal(t, 0, logs.ResourceLogs().At(0).Resource().Attributes().Len())
+			records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			require.Equal(t, 2, records.Len())
+
+			assert.Equal(t, "first line", records.At(0).Body().Str())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(0, 1676888496000000000)), records.At(0).Timestamp())
+			assert.Equal(t, map[string]interface{}{"job": "varlogs", "host": "node-1"}, records.At(0).Attributes().AsRaw())
+
+			assert.Equal(t, "second line", records.At(1).Body().Str())
+			assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(0, 1676888497000000000)), records.At(1).Timestamp())
+			assert.Equal(t, map[string]interface{}{
+				"job":      "varlogs",
+				"host":     "node-1",
+				"trace_id": "0102",
+				"user":     "jane",
+			}, records.At(1).Attributes().AsRaw())
+		})
+	}
+}
+
+func TestPushEmpty(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, sink)
+
+	resp := post(t, url, []byte(`{"streams": []}`), map[string]string{"Content-Type": "application/json"})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestPushInvalidRequests(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	url := startReceiver(t, sink)
+
+	testCases := []struct {
+		desc    string
+		body    []byte
+		headers map[string]string
+	}{
+		{
+			desc: "invalid snappy",
+			body: []byte("not snappy"),
+		},
+		{
+			desc: "invalid protobuf",
+			body: snappy.Encode(nil, []byte("not protobuf")),
+		},
+		{
+			desc:    "invalid JSON",
+			body:    []byte(`{"streams": [`),
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			desc:    "invalid JSON entry",
+			body:    []byte(`{"streams": [{"stream": {"job": "varlogs"}, "values": [["1676888496000000000"]]}]}`),
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			desc:    "invalid JSON timestamp",
+			body:    []byte(`{"streams": [{"stream": {"job": "varlogs"}, "values": [["yesterday", "line"]]}]}`),
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			desc: "unsupported content encoding",
+			body: []byte(`{"streams": []}`),
+			headers: map[string]string{
+				"Content-Type":     "application/json",
+				"Content-Encoding": "br",
+			},
+		},
+		{
+			desc: "invalid labels",
+			body: encodeProtobuf(t, &push.PushRequest{
+				Streams: []push.Stream{
+					{
+						Labels:  `job="varlogs"`,
+						Entries: []push.Entry{{Timestamp: time.Unix(0, 1), Line: "line"}},
+					},
+				},
+			}),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp := post(t, url, tC.body, tC.headers)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+	assert.Empty(t, sink.AllLogs())
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestPushTooLarge(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxMessageSize = 1024
+	url := startReceiverWithConfig(t, cfg, sink)
+
+	largeJSON := []byte(`{"streams": [{"stream": {"job": "varlogs"}, "values": [["1676888496000000000", "` +
+		strings.Repeat("a", 2048) + `"]]}]}`)
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, err := gw.Write(largeJSON)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	testCases := []struct {
+		desc    string
+		body    []byte
+		headers map[string]string
+	}{
+		{
+			desc: "snappy decoded length",
+			// A snappy block starts with the varint encoded length of the decoded data.
+			body: append(binary.AppendUvarint(nil, 1<<30), "truncated"...),
+		},
+		{
+			desc:    "body",
+			body:    largeJSON,
+			headers: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			desc: "decompressed body",
+			body: gzipped.Bytes(),
+			headers: map[string]string{
+				"Content-Type":     "application/json",
+				"Content-Encoding": "gzip",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp := post(t, url, tC.body, tC.headers)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		})
+	}
+	assert.Empty(t, sink.AllLogs())
+}
+
+func TestPushConsumerError(t *testing.T) {
+	body := []byte(`{"streams": [{"stream": {"job": "varlogs"}, "values": [["1676888496000000000", "line"]]}]}`)
+
+	testCases := []struct {
+		desc   string
+		err    error
+		status int
+	}{
+		{
+			desc:   "retryable",
+			err:    errors.New("downstream unavailable"),
+			status: http.StatusInternalServerError,
+		},
+		{
+			desc:   "permanent",
+			err:    consumererror.NewPermanent(errors.New("invalid data")),
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tC.err))
+			resp := post(t, url, body, map[string]string{"Content-Type": "application/json"})
+			assert.Equal(t, tC.status, resp.StatusCode)
+		})
+	}
+}
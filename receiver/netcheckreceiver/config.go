@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver/internal/metadata"
+)
+
+const (
+	protocolTCP  = "tcp"
+	protocolICMP = "icmp"
+
+	defaultTimeout  = 5 * time.Second
+	defaultCount    = 3
+	defaultInterval = time.Second
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errMissingTargets     = errors.New(`no targets configured`)
+	errMissingEndpoint    = errors.New(`"endpoint" not specified in config`)
+	errInvalidTCPEndpoint = errors.New(`"endpoint" must be in the form of <hostname>:<port> for tcp targets`)
+	errInvalidICMPTarget  = errors.New(`"endpoint" must be a hostname or IP address without port for icmp targets`)
+	errInvalidProtocol    = fmt.Errorf(`"protocol" must be one of %q or %q`, protocolTCP, protocolICMP)
+	errTCPOnlySettings    = errors.New(`"send" and "expect" can only be used with tcp targets`)
+	errNegativeCount      = errors.New(`"count" must not be negative`)
+	errConfigNotNetCheck  = errors.New("config was not a network check receiver config")
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	Targets                                 []*targetConfig `mapstructure:"targets"`
+}
+
+// targetConfig defines a target and how to check its reachability.
+type targetConfig struct {
+	// Endpoint is host:port for tcp targets and host for icmp targets.
+	Endpoint string `mapstructure:"endpoint"`
+	// Protocol is either tcp or icmp, tcp if not set.
+	Protocol string `mapstructure:"protocol"`
+	// Timeout bounds a tcp check, or the wait for each echo reply of an icmp check.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Send is written to tcp connections once they are established.
+	Send string `mapstructure:"send"`
+	// Expect must be received on tcp connections for the target to be up.
+	Expect string `mapstructure:"expect"`
+
+	// Count is the number of echo requests sent to icmp targets.
+	Count int `mapstructure:"count"`
+	// Interval is the time between echo requests.
+	Interval time.Duration `mapstructure:"interval"`
+	// Privileged uses raw sockets for icmp checks instead of unprivileged
+	// datagram sockets, which require the net.ipv4.ping_group_range sysctl to
+	// include the group of the collector.
+	Privileged bool `mapstructure:"privileged"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.Targets) == 0 {
+		err = multierr.Append(err, errMissingTargets)
+	}
+
+	for _, target := range cfg.Targets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	return err
+}
+
+// Validate validates the endpoint and the protocol specific settings of the target.
+func (cfg *targetConfig) Validate() error {
+	var err error
+
+	switch cfg.protocol() {
+	case protocolTCP:
+		if cfg.Endpoint == "" {
+			err = multierr.Append(err, errMissingEndpoint)
+		} else if _, _, splitErr := net.SplitHostPort(cfg.Endpoint); splitErr != nil {
+			err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidTCPEndpoint.Error(), splitErr))
+		}
+	case protocolICMP:
+		if cfg.Endpoint == "" {
+			err = multierr.Append(err, errMissingEndpoint)
+		} else if _, _, splitErr := net.SplitHostPort(cfg.Endpoint); splitErr == nil {
+			err = multierr.Append(err, errInvalidICMPTarget)
+		}
+		if cfg.Send != "" || cfg.Expect != "" {
+			err = multierr.Append(err, errTCPOnlySettings)
+		}
+		if cfg.Count < 0 {
+			err = multierr.Append(err, errNegativeCount)
+		}
+	default:
+		err = multierr.Append(err, errInvalidProtocol)
+	}
+
+	return err
+}
+
+func (cfg *targetConfig) protocol() string {
+	if cfg.Protocol == "" {
+		return protocolTCP
+	}
+	return cfg.Protocol
+}
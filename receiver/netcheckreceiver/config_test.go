@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.uber.org/multierr"
+)
+
+// check that OTel Collector patterns are implemented
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+	if err := componenttest.CheckConfigStruct(&Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewID(typeStr).String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.CollectionInterval = 30 * time.Second
+	expected.Targets = []*targetConfig{
+		{Endpoint: "db.example.com:5432"},
+		{Endpoint: "cache.example.com:6379", Timeout: 2 * time.Second, Send: "PING\r\n", Expect: "+PONG"},
+		{Endpoint: "gateway.example.com", Protocol: protocolICMP, Count: 5, Interval: 200 * time.Millisecond, Privileged: true},
+	}
+	require.Equal(t, expected, cfg)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			desc:        "no targets",
+			cfg:         &Config{},
+			expectedErr: errMissingTargets,
+		},
+		{
+			desc: "missing endpoint",
+			cfg: &Config{
+				Targets: []*targetConfig{{}, {Protocol: protocolICMP}},
+			},
+			expectedErr: multierr.Combine(errMissingEndpoint, errMissingEndpoint),
+		},
+		{
+			desc: "tcp endpoint without port",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com"}},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`"endpoint" must be in the form of <hostname>:<port> for tcp targets: address example.com: missing port in address`),
+			),
+		},
+		{
+			desc: "icmp endpoint with port",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com:80", Protocol: protocolICMP}},
+			},
+			expectedErr: errInvalidICMPTarget,
+		},
+		{
+			desc: "icmp with tcp settings",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com", Protocol: protocolICMP, Expect: "pong", Count: -1}},
+			},
+			expectedErr: multierr.Combine(errTCPOnlySettings, errNegativeCount),
+		},
+		{
+			desc: "invalid protocol",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com:53", Protocol: "udp"}},
+			},
+			expectedErr: errInvalidProtocol,
+		},
+		{
+			desc: "valid config",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{Endpoint: "example.com:22", Expect: "SSH-2.0"},
+					{Endpoint: "[2001:db8::1]:443", Protocol: protocolTCP},
+					{Endpoint: "2001:db8::1", Protocol: protocolICMP},
+					{Endpoint: "example.com", Protocol: protocolICMP, Count: 10},
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if tc.expectedErr != nil {
+				require.EqualError(t, actualErr, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+			}
+		})
+	}
+}
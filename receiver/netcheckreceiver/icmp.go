@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	protocolNumberICMP   = 1
+	protocolNumberICMPv6 = 58
+)
+
+var (
+	// echoID distinguishes the echo requests of concurrent checks on raw sockets.
+	// Datagram sockets are not shared, the kernel sets the identifier itself.
+	echoID atomic.Uint32
+
+	echoPayload = []byte("opentelemetry-collector-netcheck")
+)
+
+// pingResult holds the outcome of the echo requests sent to a target.
+type pingResult struct {
+	sent     int
+	received int
+	totalRTT time.Duration
+}
+
+// ping sends the configured number of ICMP echo requests to the target, waiting for each reply in turn.
+func ping(ctx context.Context, cfg *targetConfig) (pingResult, error) {
+	var result pingResult
+
+	count := cfg.Count
+	if count == 0 {
+		count = defaultCount
+	}
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	ip, err := resolve(ctx, cfg.Endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	network, listenAddr := "udp4", "0.0.0.0"
+	protocolNumber := protocolNumberICMP
+	var requestType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if ip.To4() == nil {
+		network, listenAddr = "udp6", "::"
+		protocolNumber = protocolNumberICMPv6
+		requestType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	var dst net.Addr = &net.UDPAddr{IP: ip}
+	if cfg.Privileged {
+		network = "ip4:icmp"
+		if ip.To4() == nil {
+			network = "ip6:ipv6-icmp"
+		}
+		dst = &net.IPAddr{IP: ip}
+	}
+
+	conn, err := icmp.ListenPacket(network, listenAddr)
+	if err != nil {
+		return result, err
+	}
+	defer conn.Close()
+
+	id := int(echoID.Add(1) & 0xffff)
+	for seq := 0; seq < count; seq++ {
+		if seq > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		request, err := (&icmp.Message{
+			Type: requestType,
+			Body: &icmp.Echo{ID: id, Seq: seq, Data: echoPayload},
+		}).Marshal(nil)
+		if err != nil {
+			return result, err
+		}
+
+		start := time.Now()
+		if _, err = conn.WriteTo(request, dst); err != nil {
+			return result, err
+		}
+		result.sent++
+
+		received, err := awaitReply(conn, protocolNumber, replyType, ip, id, seq, cfg.Privileged, start.Add(timeout))
+		if err != nil {
+			return result, err
+		}
+		if received {
+			result.received++
+			result.totalRTT += time.Since(start)
+		}
+	}
+	return result, nil
+}
+
+// awaitReply reads from conn until the reply to the echo request is received or the deadline passes.
+func awaitReply(conn *icmp.PacketConn, protocolNumber int, replyType icmp.Type, from net.IP, id, seq int, matchID bool, deadline time.Time) (bool, error) {
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if !peerIP(peer).Equal(from) {
+			continue
+		}
+
+		msg, err := icmp.ParseMessage(protocolNumber, buf[:n])
+		if err != nil || msg.Type != replyType {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok || echo.Seq != seq || (matchID && echo.ID != id) {
+			continue
+		}
+		return true, nil
+	}
+}
+
+// resolve returns the IP address of host, preferring IPv4.
+func resolve(ctx context.Context, host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			return addr.IP, nil
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return addrs[0].IP, nil
+}
+
+func peerIP(addr net.Addr) net.IP {
+	switch addr := addr.(type) {
+	case *net.UDPAddr:
+		return addr.IP
+	case *net.IPAddr:
+		return addr.IP
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsSettings provides settings for netcheckreceiver metrics.
+type MetricsSettings struct {
+	NetcheckError      MetricSettings `mapstructure:"netcheck.error"`
+	NetcheckPacketLoss MetricSettings `mapstructure:"netcheck.packet_loss"`
+	NetcheckRtt        MetricSettings `mapstructure:"netcheck.rtt"`
+	NetcheckUp         MetricSettings `mapstructure:"netcheck.up"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		NetcheckError: MetricSettings{
+			Enabled: true,
+		},
+		NetcheckPacketLoss: MetricSettings{
+			Enabled: true,
+		},
+		NetcheckRtt: MetricSettings{
+			Enabled: true,
+		},
+		NetcheckUp: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// ResourceAttributeSettings provides common settings for a particular metric.
+type ResourceAttributeSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ResourceAttributesSettings provides settings for netcheckreceiver metrics.
+type ResourceAttributesSettings struct {
+}
+
+func DefaultResourceAttributesSettings() ResourceAttributesSettings {
+	return ResourceAttributesSettings{}
+}
+
+// AttributeNetcheckProtocol specifies the a value netcheck.protocol attribute.
+type AttributeNetcheckProtocol int
+
+const (
+	_ AttributeNetcheckProtocol = iota
+	AttributeNetcheckProtocolTcp
+	AttributeNetcheckProtocolIcmp
+)
+
+// String returns the string representation of the AttributeNetcheckProtocol.
+func (av AttributeNetcheckProtocol) String() string {
+	switch av {
+	case AttributeNetcheckProtocolTcp:
+		return "tcp"
+	case AttributeNetcheckProtocolIcmp:
+		return "icmp"
+	}
+	return ""
+}
+
+// MapAttributeNetcheckProtocol is a helper map of string to AttributeNetcheckProtocol attribute value.
+var MapAttributeNetcheckProtocol = map[string]AttributeNetcheckProtocol{
+	"tcp":  AttributeNetcheckProtocolTcp,
+	"icmp": AttributeNetcheckProtocolIcmp,
+}
+
+type metricNetcheckError struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills netcheck.error metric with initial data.
+func (m *metricNetcheckError) init() {
+	m.data.SetName("netcheck.error")
+	m.data.SetDescription("Records errors occurring during checks.")
+	m.data.SetUnit("{error}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNetcheckError) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue string, errorMessageAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("netcheck.target", netcheckTargetAttributeValue)
+	dp.Attributes().PutStr("netcheck.protocol", netcheckProtocolAttributeValue)
+	dp.Attributes().PutStr("error.message", errorMessageAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNetcheckError) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNetcheckError) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNetcheckError(settings MetricSettings) metricNetcheckError {
+	m := metricNetcheckError{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNetcheckPacketLoss struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills netcheck.packet_loss metric with initial data.
+func (m *metricNetcheckPacketLoss) init() {
+	m.data.SetName("netcheck.packet_loss")
+	m.data.SetDescription("Ratio of ICMP echo requests which were not answered.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNetcheckPacketLoss) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netcheckTargetAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("netcheck.target", netcheckTargetAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNetcheckPacketLoss) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNetcheckPacketLoss) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNetcheckPacketLoss(settings MetricSettings) metricNetcheckPacketLoss {
+	m := metricNetcheckPacketLoss{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNetcheckRtt struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills netcheck.rtt metric with initial data.
+func (m *metricNetcheckRtt) init() {
+	m.data.SetName("netcheck.rtt")
+	m.data.SetDescription("Round trip time to the target, the time to establish the connection for TCP checks and the average echo round trip time for ICMP checks.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNetcheckRtt) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val float64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(val)
+	dp.Attributes().PutStr("netcheck.target", netcheckTargetAttributeValue)
+	dp.Attributes().PutStr("netcheck.protocol", netcheckProtocolAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNetcheckRtt) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNetcheckRtt) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNetcheckRtt(settings MetricSettings) metricNetcheckRtt {
+	m := metricNetcheckRtt{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricNetcheckUp struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills netcheck.up metric with initial data.
+func (m *metricNetcheckUp) init() {
+	m.data.SetName("netcheck.up")
+	m.data.SetDescription("1 if the target was reachable, otherwise 0. TCP targets must also send the expected response, ICMP targets must answer at least one echo request.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricNetcheckUp) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("netcheck.target", netcheckTargetAttributeValue)
+	dp.Attributes().PutStr("netcheck.protocol", netcheckProtocolAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricNetcheckUp) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricNetcheckUp) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricNetcheckUp(settings MetricSettings) metricNetcheckUp {
+	m := metricNetcheckUp{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilderConfig is a structural subset of an otherwise 1-1 copy of metadata.yaml
+type MetricsBuilderConfig struct {
+	Metrics            MetricsSettings            `mapstructure:"metrics"`
+	ResourceAttributes ResourceAttributesSettings `mapstructure:"resource_attributes"`
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                  pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity            int                 // maximum observed number of metrics per resource.
+	resourceCapacity           int                 // maximum observed number of resource attributes.
+	metricsBuffer              pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                  component.BuildInfo // contains version information
+	resourceAttributesSettings ResourceAttributesSettings
+	metricNetcheckError        metricNetcheckError
+	metricNetcheckPacketLoss   metricNetcheckPacketLoss
+	metricNetcheckRtt          metricNetcheckRtt
+	metricNetcheckUp           metricNetcheckUp
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func DefaultMetricsBuilderConfig() MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            DefaultMetricsSettings(),
+		ResourceAttributes: DefaultResourceAttributesSettings(),
+	}
+}
+
+func NewMetricsBuilderConfig(ms MetricsSettings, ras ResourceAttributesSettings) MetricsBuilderConfig {
+	return MetricsBuilderConfig{
+		Metrics:            ms,
+		ResourceAttributes: ras,
+	}
+}
+
+func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                  pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:              pmetric.NewMetrics(),
+		buildInfo:                  settings.BuildInfo,
+		resourceAttributesSettings: mbc.ResourceAttributes,
+		metricNetcheckError:        newMetricNetcheckError(mbc.Metrics.NetcheckError),
+		metricNetcheckPacketLoss:   newMetricNetcheckPacketLoss(mbc.Metrics.NetcheckPacketLoss),
+		metricNetcheckRtt:          newMetricNetcheckRtt(mbc.Metrics.NetcheckRtt),
+		metricNetcheckUp:           newMetricNetcheckUp(mbc.Metrics.NetcheckUp),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(ResourceAttributesSettings, pmetric.ResourceMetrics)
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(ras ResourceAttributesSettings, rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/netcheckreceiver")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricNetcheckError.emit(ils.Metrics())
+	mb.metricNetcheckPacketLoss.emit(ils.Metrics())
+	mb.metricNetcheckRtt.emit(ils.Metrics())
+	mb.metricNetcheckUp.emit(ils.Metrics())
+
+	for _, op := range rmo {
+		op(mb.resourceAttributesSettings, rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := mb.metricsBuffer
+	mb.metricsBuffer = pmetric.NewMetrics()
+	return metrics
+}
+
+// RecordNetcheckErrorDataPoint adds a data point to netcheck.error metric.
+func (mb *MetricsBuilder) RecordNetcheckErrorDataPoint(ts pcommon.Timestamp, val int64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue AttributeNetcheckProtocol, errorMessageAttributeValue string) {
+	mb.metricNetcheckError.recordDataPoint(mb.startTime, ts, val, netcheckTargetAttributeValue, netcheckProtocolAttributeValue.String(), errorMessageAttributeValue)
+}
+
+// RecordNetcheckPacketLossDataPoint adds a data point to netcheck.packet_loss metric.
+func (mb *MetricsBuilder) RecordNetcheckPacketLossDataPoint(ts pcommon.Timestamp, val float64, netcheckTargetAttributeValue string) {
+	mb.metricNetcheckPacketLoss.recordDataPoint(mb.startTime, ts, val, netcheckTargetAttributeValue)
+}
+
+// RecordNetcheckRttDataPoint adds a data point to netcheck.rtt metric.
+func (mb *MetricsBuilder) RecordNetcheckRttDataPoint(ts pcommon.Timestamp, val float64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue AttributeNetcheckProtocol) {
+	mb.metricNetcheckRtt.recordDataPoint(mb.startTime, ts, val, netcheckTargetAttributeValue, netcheckProtocolAttributeValue.String())
+}
+
+// RecordNetcheckUpDataPoint adds a data point to netcheck.up metric.
+func (mb *MetricsBuilder) RecordNetcheckUpDataPoint(ts pcommon.Timestamp, val int64, netcheckTargetAttributeValue string, netcheckProtocolAttributeValue AttributeNetcheckProtocol) {
+	mb.metricNetcheckUp.recordDataPoint(mb.startTime, ts, val, netcheckTargetAttributeValue, netcheckProtocolAttributeValue.String())
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
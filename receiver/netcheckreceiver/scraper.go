@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver/internal/metadata"
+)
+
+// maxExpectSize limits how much of a TCP response is read looking for the expected string.
+const maxExpectSize = 64 * 1024
+
+var errExpectNotFound = errors.New("expected response not received")
+
+type netcheckScraper struct {
+	cfg      *Config
+	settings component.TelemetrySettings
+	mb       *metadata.MetricsBuilder
+	mu       sync.Mutex
+}
+
+// scrape checks every target concurrently and produces metrics based on the results
+func (s *netcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var wg sync.WaitGroup
+	wg.Add(len(s.cfg.Targets))
+	for _, target := range s.cfg.Targets {
+		go func(target *targetConfig) {
+			defer wg.Done()
+			switch target.protocol() {
+			case protocolTCP:
+				s.checkTCP(ctx, target)
+			case protocolICMP:
+				s.checkICMP(ctx, target)
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return s.mb.Emit(), nil
+}
+
+func (s *netcheckScraper) checkTCP(ctx context.Context, cfg *targetConfig) {
+	rtt, err := checkTCP(ctx, cfg)
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	up := int64(1)
+	if err != nil {
+		up = 0
+		s.mb.RecordNetcheckErrorDataPoint(now, int64(1), cfg.Endpoint, metadata.AttributeNetcheckProtocolTcp, err.Error())
+	}
+	if rtt > 0 {
+		s.mb.RecordNetcheckRttDataPoint(now, durationMilliseconds(rtt), cfg.Endpoint, metadata.AttributeNetcheckProtocolTcp)
+	}
+	s.mb.RecordNetcheckUpDataPoint(now, up, cfg.Endpoint, metadata.AttributeNetcheckProtocolTcp)
+}
+
+func (s *netcheckScraper) checkICMP(ctx context.Context, cfg *targetConfig) {
+	result, err := ping(ctx, cfg)
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.mb.RecordNetcheckErrorDataPoint(now, int64(1), cfg.Endpoint, metadata.AttributeNetcheckProtocolIcmp, err.Error())
+	}
+
+	up := int64(0)
+	if result.received > 0 {
+		up = 1
+		s.mb.RecordNetcheckRttDataPoint(now, durationMilliseconds(result.totalRTT/time.Duration(result.received)), cfg.Endpoint, metadata.AttributeNetcheckProtocolIcmp)
+	}
+	if result.sent > 0 {
+		s.mb.RecordNetcheckPacketLossDataPoint(now, float64(result.sent-result.received)/float64(result.sent), cfg.Endpoint)
+	}
+	s.mb.RecordNetcheckUpDataPoint(now, up, cfg.Endpoint, metadata.AttributeNetcheckProtocolIcmp)
+}
+
+// checkTCP connects to the target, then sends and expects the configured data.
+// It returns the time taken to establish the connection, zero if it failed.
+func checkTCP(ctx context.Context, cfg *targetConfig) (time.Duration, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Endpoint)
+	if err != nil {
+		return 0, err
+	}
+	rtt := time.Since(start)
+	defer conn.Close()
+
+	if cfg.Send == "" && cfg.Expect == "" {
+		return rtt, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return rtt, err
+		}
+	}
+
+	if cfg.Send != "" {
+		if _, err = io.WriteString(conn, cfg.Send); err != nil {
+			return rtt, err
+		}
+	}
+
+	if cfg.Expect != "" {
+		if err = expect(conn, []byte(cfg.Expect)); err != nil {
+			return rtt, err
+		}
+	}
+	return rtt, nil
+}
+
+// expect reads from r until the expected bytes are received.
+func expect(r io.Reader, expected []byte) error {
+	var received []byte
+	buf := make([]byte, 4096)
+	for len(received) < maxExpectSize {
+		n, err := r.Read(buf)
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, expected) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", errExpectNotFound, err)
+		}
+	}
+	return errExpectNotFound
+}
+
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func newScraper(cfg *Config, settings receiver.CreateSettings) *netcheckScraper {
+	return &netcheckScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+	}
+}
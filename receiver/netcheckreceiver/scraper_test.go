@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"golang.org/x/net/icmp"
+)
+
+type checkResult struct {
+	up         map[string]int64
+	rtt        map[string]float64
+	packetLoss map[string]float64
+	errors     map[string]string
+}
+
+// collect indexes the data points by "<protocol> <target>", or "<target>" for packet loss.
+func collect(md pmetric.Metrics) checkResult {
+	result := checkResult{
+		up:         map[string]int64{},
+		rtt:        map[string]float64{},
+		packetLoss: map[string]float64{},
+		errors:     map[string]string{},
+	}
+	key := func(dp pmetric.NumberDataPoint) string {
+		target, _ := dp.Attributes().Get("netcheck.target")
+		if protocol, ok := dp.Attributes().Get("netcheck.protocol"); ok {
+			return protocol.Str() + " " + target.Str()
+		}
+		return target.Str()
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Name() {
+				case "netcheck.up":
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						result.up[key(dps.At(l))] = dps.At(l).IntValue()
+					}
+				case "netcheck.rtt":
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						result.rtt[key(dps.At(l))] = dps.At(l).DoubleValue()
+					}
+				case "netcheck.packet_loss":
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						result.packetLoss[key(dps.At(l))] = dps.At(l).DoubleValue()
+					}
+				case "netcheck.error":
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						msg, _ := dps.At(l).Attributes().Get("error.message")
+						result.errors[key(dps.At(l))] = msg.Str()
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func scrape(t *testing.T, targets ...*targetConfig) checkResult {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = targets
+	require.NoError(t, cfg.Validate())
+
+	md, err := newScraper(cfg, receivertest.NewNopCreateSettings()).scrape(context.Background())
+	require.NoError(t, err)
+	return collect(md)
+}
+
+// listen starts a TCP server handling every connection with handler.
+func listen(t *testing.T, handler func(conn net.Conn)) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handler(conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestScrapeTCP(t *testing.T) {
+	silent := listen(t, func(conn net.Conn) {
+		_, _ = io.Copy(io.Discard, conn)
+	})
+	banner := listen(t, func(conn net.Conn) {
+		_, _ = io.WriteString(conn, "SSH-2.0-OpenSSH_9.0\r\n")
+		_, _ = io.Copy(io.Discard, conn)
+	})
+	echo := listen(t, func(conn net.Conn) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil {
+			return
+		}
+		if line == "PING\r\n" {
+			_, _ = io.WriteString(conn, "+PONG\r\n")
+		}
+	})
+	wrongBanner := listen(t, func(conn net.Conn) {
+		_, _ = io.WriteString(conn, "220 smtp.example.com ESMTP\r\n")
+	})
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	result := scrape(t,
+		&targetConfig{Endpoint: silent},
+		&targetConfig{Endpoint: banner, Expect: "SSH-2.0"},
+		&targetConfig{Endpoint: echo, Protocol: protocolTCP, Send: "PING\r\n", Expect: "+PONG"},
+		&targetConfig{Endpoint: wrongBanner, Expect: "SSH-2.0"},
+		&targetConfig{Endpoint: closed},
+	)
+
+	require.Equal(t, map[string]int64{
+		"tcp " + silent:      1,
+		"tcp " + banner:      1,
+		"tcp " + echo:        1,
+		"tcp " + wrongBanner: 0,
+		"tcp " + closed:      0,
+	}, result.up)
+
+	// The connection time is reported whenever the connection was established.
+	require.Len(t, result.rtt, 4)
+	require.NotContains(t, result.rtt, "tcp "+closed)
+	for _, rtt := range result.rtt {
+		require.Greater(t, rtt, 0.0)
+	}
+
+	require.Empty(t, result.packetLoss)
+	require.Len(t, result.errors, 2)
+	require.Contains(t, result.errors["tcp "+wrongBanner], errExpectNotFound.Error())
+	require.Contains(t, result.errors["tcp "+closed], "connection refused")
+}
+
+func TestScrapeTCPExpectTimeout(t *testing.T) {
+	silent := listen(t, func(conn net.Conn) {
+		_, _ = io.Copy(io.Discard, conn)
+	})
+
+	start := time.Now()
+	result := scrape(t, &targetConfig{Endpoint: silent, Expect: "hello", Timeout: 100 * time.Millisecond})
+
+	require.Less(t, time.Since(start), 5*time.Second)
+	require.Equal(t, map[string]int64{"tcp " + silent: 0}, result.up)
+	require.Contains(t, result.errors["tcp "+silent], errExpectNotFound.Error())
+	require.Contains(t, result.errors["tcp "+silent], "i/o timeout")
+}
+
+// skipWithoutICMP skips the test when ICMP sockets of the given kind cannot be opened.
+func skipWithoutICMP(t *testing.T, privileged bool) {
+	network := "udp4"
+	if privileged {
+		network = "ip4:icmp"
+	}
+	conn, err := icmp.ListenPacket(network, "0.0.0.0")
+	if errors.Is(err, os.ErrPermission) || errors.Is(err, syscall.EPROTONOSUPPORT) || errors.Is(err, syscall.EACCES) || errors.Is(err, syscall.EPERM) {
+		t.Skipf("cannot open %s ICMP sockets: %v", network, err)
+	}
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestScrapeICMP(t *testing.T) {
+	for _, privileged := range []bool{false, true} {
+		name := "unprivileged"
+		if privileged {
+			name = "privileged"
+		}
+		t.Run(name, func(t *testing.T) {
+			skipWithoutICMP(t, privileged)
+
+			result := scrape(t, &targetConfig{
+				Endpoint:   "127.0.0.1",
+				Protocol:   protocolICMP,
+				Count:      2,
+				Interval:   10 * time.Millisecond,
+				Timeout:    time.Second,
+				Privileged: privileged,
+			})
+
+			require.Empty(t, result.errors)
+			require.Equal(t, map[string]int64{"icmp 127.0.0.1": 1}, result.up)
+			require.Equal(t, map[string]float64{"127.0.0.1": 0}, result.packetLoss)
+			require.Contains(t, result.rtt, "icmp 127.0.0.1")
+		})
+	}
+}
+
+func TestScrapeICMPUnresolvable(t *testing.T) {
+	result := scrape(t, &targetConfig{Endpoint: "netcheck.invalid", Protocol: protocolICMP})
+
+	require.Equal(t, map[string]int64{"icmp netcheck.invalid": 0}, result.up)
+	require.Contains(t, result.errors, "icmp netcheck.invalid")
+	require.Empty(t, result.packetLoss)
+}
@@ -0,0 +1,224 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+const (
+	writePath = "/api/v1/write"
+
+	snappyEncoding = "snappy"
+	receiveFormat  = "prometheus_remote_write"
+)
+
+var (
+	errNilNextConsumer = errors.New("nil next consumer")
+	errMessageTooLarge = errors.New("write request too large")
+)
+
+type remoteWriteReceiver struct {
+	config       *Config
+	settings     receiver.CreateSettings
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server *http.Server
+	wg     sync.WaitGroup
+
+	// metadata caches the metric family metadata, which Prometheus sends separately from the samples.
+	metadataMu sync.Mutex
+	metadata   map[string]prompb.MetricMetadata
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*remoteWriteReceiver, error) {
+	if nextConsumer == nil {
+		return nil, errNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteWriteReceiver{
+		config:       config,
+		settings:     settings,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+		metadata:     make(map[string]prompb.MetricMetadata),
+	}, nil
+}
+
+func (r *remoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(writePath, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, router)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+func (r *remoteWriteReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *remoteWriteReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if encoding := req.Header.Get("Content-Encoding"); encoding != "" && encoding != snappyEncoding {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body := http.MaxBytesReader(w, req.Body, r.config.MaxMessageSize)
+	writeReq, err := decodeWriteRequest(body, r.config.MaxMessageSize)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) || errors.Is(err, errMessageTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("failed to decode write request: %v", err), status)
+		return
+	}
+
+	r.updateMetadata(writeReq)
+	if len(writeReq.Timeseries) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	md, err := prometheusremotewrite.ToMetrics(writeReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, receiveFormat, md.DataPointCount(), err)
+	if err != nil {
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		status := http.StatusInternalServerError
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// updateMetadata records the metadata of the request, and completes it with the metadata
+// previously received for the other metric families.
+func (r *remoteWriteReceiver) updateMetadata(writeReq *prompb.WriteRequest) {
+	r.metadataMu.Lock()
+	defer r.metadataMu.Unlock()
+
+	for _, md := range writeReq.Metadata {
+		r.metadata[md.MetricFamilyName] = md
+	}
+	if len(writeReq.Timeseries) == 0 {
+		return
+	}
+
+	writeReq.Metadata = writeReq.Metadata[:0]
+	for _, md := range r.metadata {
+		writeReq.Metadata = append(writeReq.Metadata, md)
+	}
+}
+
+// decodeWriteRequest decodes a snappy-compressed write request, checking its decoded size
+// against maxSize before decoding it.
+func decodeWriteRequest(body io.Reader, maxSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, err
+	}
+	if int64(size) > maxSize {
+		return nil, fmt.Errorf("%w: decoded size %d exceeds %d bytes", errMessageTooLarge, size, maxSize)
+	}
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, err
+	}
+	writeReq := &prompb.WriteRequest{}
+	if err = writeReq.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	return writeReq, nil
+}
@@ -0,0 +1,340 @@
+// Copyright 2023, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, nextConsumer consumer.Metrics) string {
+	return startReceiverWithConfig(t, createDefaultConfig().(*Config), nextConsumer)
+}
+
+func startReceiverWithConfig(t *testing.T, cfg *Config, nextConsumer consumer.Metrics) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), nextConsumer)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+
+	return "http://" + cfg.Endpoint + writePath
+}
+
+func write(t *testing.T, url string, body []byte, encoding string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", encoding)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func encode(t *testing.T, writeReq *prompb.WriteRequest) []byte {
+	buf, err := writeReq.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func labels(lbs ...string) []prompb.Label {
+	var out []prompb.Label
+	for i := 0; i < len(lbs); i += 2 {
+		out = append(out, prompb.Label{Name: lbs[i], Value: lbs[i+1]})
+	}
+	return out
+}
+
+func TestWrite(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	writeReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  labels("__name__", "http_requests_total", "job", "api", "instance", "host-1:8080", "code", "200"),
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1000}},
+			},
+			{
+				Labels:  labels("__name__", "latency_seconds_bucket", "job", "api", "instance", "host-1:8080", "le", "1"),
+				Samples: []prompb.Sample{{Value: 2, Timestamp: 1000}},
+			},
+			{
+				Labels:  labels("__name__", "latency_seconds_bucket", "job", "api", "instance", "host-1:8080", "le", "+Inf"),
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+			{
+				Labels:  labels("__name__", "latency_seconds_sum", "job", "api", "instance", "host-1:8080"),
+				Samples: []prompb.Sample{{Value: 1.5, Timestamp: 1000}},
+			},
+			{
+				Labels:  labels("__name__", "latency_seconds_count", "job", "api", "instance", "host-1:8080"),
+				Samples: []prompb.Sample{{Value: 3, Timestamp: 1000}},
+			},
+			{
+				Labels: labels("__name__", "size_bytes", "job", "api", "instance", "host-1:8080"),
+				Histograms: []prompb.Histogram{{
+					Count:          &prompb.Histogram_CountInt{CountInt: 3},
+					ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 0},
+					Sum:            96,
+					Schema:         0,
+					PositiveSpans:  []prompb.BucketSpan{{Offset: 5, Length: 2}},
+					PositiveDeltas: []int64{1, 1},
+					Timestamp:      1000,
+				}},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "http_requests_total", Help: "Requests served."},
+			{Type: prompb.MetricMetadata_HISTOGRAM, MetricFamilyName: "latency_seconds", Help: "Request latency."},
+		},
+	}
+
+	resp := write(t, url, encode(t, writeReq), snappyEncoding)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	rm := md.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{
+		conventions.AttributeServiceName:       "api",
+		conventions.AttributeServiceInstanceID: "host-1:8080",
+	}, rm.Resource().Attributes().AsRaw())
+
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, metrics.Len())
+
+	assert.Equal(t, "http_requests_total", metrics.At(0).Name())
+	assert.Equal(t, "Requests served.", metrics.At(0).Description())
+	require.Equal(t, pmetric.MetricTypeSum, metrics.At(0).Type())
+	assert.Equal(t, 10.0, metrics.At(0).Sum().DataPoints().At(0).DoubleValue())
+
+	assert.Equal(t, "latency_seconds", metrics.At(1).Name())
+	require.Equal(t, pmetric.MetricTypeHistogram, metrics.At(1).Type())
+	hdp := metrics.At(1).Histogram().DataPoints().At(0)
+	assert.Equal(t, []float64{1}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{2, 1}, hdp.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(3), hdp.Count())
+	assert.Equal(t, 1.5, hdp.Sum())
+
+	assert.Equal(t, "size_bytes", metrics.At(2).Name())
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metrics.At(2).Type())
+	edp := metrics.At(2).ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, uint64(3), edp.Count())
+	assert.Equal(t, int32(4), edp.Positive().Offset())
+	assert.Equal(t, []uint64{1, 2}, edp.Positive().BucketCounts().AsRaw())
+}
+
+func TestWriteMetadataCache(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	metadataReq := &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "process_cpu_seconds", Help: "CPU time.", Unit: "seconds"},
+		},
+	}
+	resp := write(t, url, encode(t, metadataReq), snappyEncoding)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+
+	samplesReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  labels("__name__", "process_cpu_seconds"),
+				Samples: []prompb.Sample{{Value: 3.5, Timestamp: 1000}},
+			},
+		},
+	}
+	resp = write(t, url, encode(t, samplesReq), snappyEncoding)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	metric := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "process_cpu_seconds", metric.Name())
+	assert.Equal(t, "CPU time.", metric.Description())
+	assert.Equal(t, "seconds", metric.Unit())
+	assert.Equal(t, pmetric.MetricTypeSum, metric.Type())
+}
+
+func TestWriteInvalidRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	noName := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  labels("job", "api"),
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		body     []byte
+		encoding string
+		status   int
+	}{
+		{
+			desc:     "invalid snappy",
+			body:     []byte("not snappy"),
+			encoding: snappyEncoding,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "invalid protobuf",
+			body:     snappy.Encode(nil, []byte("not protobuf")),
+			encoding: snappyEncoding,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "unsupported encoding",
+			body:     encode(t, noName),
+			encoding: "br",
+			status:   http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:     "no metric name",
+			body:     encode(t, noName),
+			encoding: snappyEncoding,
+			status:   http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp := write(t, url, tC.body, tC.encoding)
+			assert.Equal(t, tC.status, resp.StatusCode)
+		})
+	}
+	assert.Empty(t, sink.AllMetrics())
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestWriteTooLarge(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxMessageSize = 1024
+	url := startReceiverWithConfig(t, cfg, sink)
+
+	large := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  labels("__name__", "up", "job", strings.Repeat("a", 2048)),
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, err := gw.Write(encode(t, large))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	testCases := []struct {
+		desc     string
+		body     []byte
+		encoding string
+	}{
+		{
+			desc: "snappy decoded length",
+			// A snappy block starts with the varint encoded length of the decoded data.
+			body:     append(binary.AppendUvarint(nil, 1<<30), "truncated"...),
+			encoding: snappyEncoding,
+		},
+		{
+			desc:     "decoded request",
+			body:     encode(t, large),
+			encoding: snappyEncoding,
+		},
+		{
+			desc:     "decompressed body",
+			body:     gzipped.Bytes(),
+			encoding: "gzip",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp := write(t, url, tC.body, tC.encoding)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+		})
+	}
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestWriteConsumerError(t *testing.T) {
+	writeReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  labels("__name__", "up"),
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc   string
+		err    error
+		status int
+	}{
+		{
+			desc:   "retryable",
+			err:    errors.New("downstream unavailable"),
+			status: http.StatusInternalServerError,
+		},
+		{
+			desc:   "permanent",
+			err:    consumererror.NewPermanent(errors.New("invalid data")),
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tC.err))
+			resp := write(t, url, encode(t, writeReq), snappyEncoding)
+			assert.Equal(t, tC.status, resp.StatusCode)
+		})
+	}
+}
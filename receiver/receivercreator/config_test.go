@@ -113,14 +113,28 @@ func TestLoadConfig(t *testing.T) {
 					component.NewIDWithName("mock_observer", "with_name"),
 				},
 				ResourceAttributes: map[observer.EndpointType]map[string]string{
-					observer.ContainerType: {"container.key": "container.value"},
-					observer.PodType:       {"pod.key": "pod.value"},
-					observer.PortType:      {"port.key": "port.value"},
-					observer.HostPortType:  {"hostport.key": "hostport.value"},
-					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ContainerType:  {"container.key": "container.value"},
+					observer.PodType:        {"pod.key": "pod.value"},
+					observer.PortType:       {"port.key": "port.value"},
+					observer.HostPortType:   {"hostport.key": "hostport.value"},
+					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
+					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "discovery"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.WatchObservers = []component.ID{component.NewID("mock_observer")}
+				cfg.Discovery = discoveryConfig{
+					Enabled:          true,
+					AllowedReceivers: []string{"redis", "nginx"},
+				}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -164,6 +178,17 @@ func TestInvalidReceiverResourceAttributeValueType(t *testing.T) {
 	require.Nil(t, cfg)
 }
 
+func TestInvalidDiscovery(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	require.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid-discovery.yaml"), factories)
+	require.Contains(t, err.Error(), "error reading configuration for \"receiver_creator\": `discovery.allowed_receivers` must be set when discovery is enabled")
+	require.Nil(t, cfg)
+}
+
 type nopWithEndpointConfig struct {
 	Endpoint string `mapstructure:"endpoint"`
 	IntField int    `mapstructure:"int_field"`
@@ -0,0 +1,123 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// discoveryAnnotationPrefix is the prefix of the pod annotations used to configure receivers.
+	// Annotations can be scoped to a single container port by appending the port number,
+	// e.g. io.opentelemetry.discovery.metrics.6379/scraper.
+	discoveryAnnotationPrefix = "io.opentelemetry.discovery.metrics"
+	// scraperAnnotation holds the type of the receiver to start.
+	scraperAnnotation = "scraper"
+	// configAnnotation holds the receiver config as a YAML map.
+	configAnnotation = "config"
+	// discoveredReceiverName is the name given to receivers created from annotations.
+	discoveredReceiverName = "discovery"
+)
+
+// discoveryConfig configures the creation of receivers from pod annotations.
+type discoveryConfig struct {
+	// Enabled turns on receiver creation from pod annotations.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers is the list of receiver types that can be created from annotations.
+	AllowedReceivers []string `mapstructure:"allowed_receivers"`
+}
+
+func (d discoveryConfig) allows(receiverType component.Type) bool {
+	for _, allowed := range d.AllowedReceivers {
+		if component.Type(allowed) == receiverType {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverReceiver starts the receiver configured by the annotations of the pod
+// behind a port endpoint, if any.
+func (obs *observerHandler) discoverReceiver(env observer.EndpointEnv, e observer.Endpoint) {
+	template, ok, err := templateFromAnnotations(e)
+	if err != nil {
+		obs.params.TelemetrySettings.Logger.Error("invalid discovery annotations", zap.String("endpoint_id", string(e.ID)), zap.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	if !obs.config.Discovery.allows(template.id.Type()) {
+		obs.params.TelemetrySettings.Logger.Warn("receiver type from discovery annotations is not allowed",
+			zap.String("receiver", string(template.id.Type())),
+			zap.String("endpoint_id", string(e.ID)))
+		return
+	}
+
+	obs.startReceiver(template, env, e)
+}
+
+// templateFromAnnotations builds a receiver template from the pod annotations of a
+// port endpoint. Annotations for the specific port take precedence over the ones
+// for the whole pod.
+func templateFromAnnotations(e observer.Endpoint) (receiverTemplate, bool, error) {
+	port, ok := e.Details.(*observer.Port)
+	if !ok {
+		return receiverTemplate{}, false, nil
+	}
+	annotations := port.Pod.Annotations
+
+	prefix := fmt.Sprintf("%s.%d/", discoveryAnnotationPrefix, port.Port)
+	scraper, ok := annotations[prefix+scraperAnnotation]
+	if !ok {
+		prefix = discoveryAnnotationPrefix + "/"
+		if scraper, ok = annotations[prefix+scraperAnnotation]; !ok {
+			return receiverTemplate{}, false, nil
+		}
+	}
+
+	scraper = strings.TrimSpace(scraper)
+	if scraper == "" || strings.Contains(scraper, "/") {
+		return receiverTemplate{}, false, fmt.Errorf("invalid receiver type %q in %q annotation", scraper, prefix+scraperAnnotation)
+	}
+	id := component.NewIDWithName(component.Type(scraper), discoveredReceiverName)
+
+	cfg := userConfigMap{}
+	if raw, ok := annotations[prefix+configAnnotation]; ok {
+		if err := yaml.Unmarshal([]byte(raw), &cfg); err != nil {
+			return receiverTemplate{}, false, fmt.Errorf("failed to parse %q annotation: %w", prefix+configAnnotation, err)
+		}
+		if cfg == nil {
+			cfg = userConfigMap{}
+		}
+	}
+
+	return receiverTemplate{
+		receiverConfig: receiverConfig{
+			id:         id,
+			config:     cfg,
+			endpointID: e.ID,
+		},
+		ResourceAttributes: map[string]interface{}{},
+	}, true, nil
+}
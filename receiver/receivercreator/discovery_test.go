@@ -0,0 +1,141 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func annotatedPortEndpoint(annotations map[string]string) observer.Endpoint {
+	annotatedPod := pod
+	annotatedPod.Annotations = annotations
+	return observer.Endpoint{
+		ID:     "port-1",
+		Target: "localhost:1234",
+		Details: &observer.Port{
+			Name:      "http",
+			Pod:       annotatedPod,
+			Port:      1234,
+			Transport: observer.ProtocolTCP,
+		},
+	}
+}
+
+func TestOnAddDiscovery(t *testing.T) {
+	for _, test := range []struct {
+		name                   string
+		disabled               bool
+		endpoint               observer.Endpoint
+		expectedReceiverConfig component.Config
+	}{
+		{
+			name: "pod annotations",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "with.endpoint",
+				"io.opentelemetry.discovery.metrics/config":  "int_field: 42",
+			}),
+			expectedReceiverConfig: &nopWithEndpointConfig{
+				IntField: 42,
+				Endpoint: "localhost:1234",
+			},
+		},
+		{
+			name: "port annotations take precedence",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper":      "without.endpoint",
+				"io.opentelemetry.discovery.metrics.1234/scraper": "with.endpoint",
+				"io.opentelemetry.discovery.metrics.1234/config":  "endpoint: http://`endpoint`/`pod.labels[\"app\"]`",
+			}),
+			expectedReceiverConfig: &nopWithEndpointConfig{
+				IntField: 1234,
+				Endpoint: "http://localhost:1234/redis",
+			},
+		},
+		{
+			name: "annotations for another port",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.8080/scraper": "with.endpoint",
+			}),
+		},
+		{
+			name: "receiver type not allowed",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "nop",
+			}),
+		},
+		{
+			name: "invalid receiver type",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "with.endpoint/name",
+			}),
+		},
+		{
+			name: "invalid config",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "with.endpoint",
+				"io.opentelemetry.discovery.metrics/config":  "- not a map",
+			}),
+		},
+		{
+			name: "invalid config expansion",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "with.endpoint",
+				"io.opentelemetry.discovery.metrics/config":  "endpoint: '`unclosed'",
+			}),
+		},
+		{
+			name:     "discovery disabled",
+			disabled: true,
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "with.endpoint",
+			}),
+		},
+		{
+			name:     "not a port endpoint",
+			endpoint: podEndpoint,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Discovery = discoveryConfig{
+				Enabled:          !test.disabled,
+				AllowedReceivers: []string{"with.endpoint", "without.endpoint"},
+			}
+
+			handler, mr := newObserverHandler(t, cfg)
+			handler.OnAdd([]observer.Endpoint{test.endpoint})
+
+			if test.expectedReceiverConfig == nil {
+				assert.Equal(t, 0, handler.receiversByEndpointID.Size())
+				require.Nil(t, mr.startedComponent)
+				return
+			}
+
+			require.NoError(t, mr.lastError)
+			assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+			rcvr, ok := mr.startedComponent.(*nopWithEndpointReceiver)
+			require.True(t, ok)
+			require.Equal(t, test.expectedReceiverConfig, rcvr.cfg)
+			require.True(t, strings.HasPrefix(rcvr.ID.Name(), "discovery/"), rcvr.ID.Name())
+		})
+	}
+}
@@ -103,6 +103,53 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "10.96.0.10:6379",
+	Details: &observer.K8sService{
+		Name:        "redis",
+		UID:         "service-uid",
+		Namespace:   "default",
+		Labels:      map[string]string{"app": "redis"},
+		Annotations: map[string]string{"scrape": "true"},
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.96.0.10",
+		PortName:    "redis",
+		Port:        6379,
+		Transport:   observer.ProtocolTCP,
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/metrics",
+	Details: &observer.K8sIngress{
+		Name:      "web",
+		UID:       "ingress-uid",
+		Namespace: "default",
+		Labels:    map[string]string{"app": "web"},
+		Scheme:    "https",
+		Host:      "example.com",
+		Path:      "/metrics",
+	},
+}
+
+var consulServiceEndpoint = observer.Endpoint{
+	ID:     "consul.service-1",
+	Target: "10.0.0.5:6379",
+	Details: &observer.ConsulService{
+		Name:         "redis",
+		ServiceID:    "redis-1",
+		Node:         "node-1",
+		Datacenter:   "dc1",
+		Address:      "10.0.0.5",
+		Port:         6379,
+		Tags:         []string{"primary"},
+		Meta:         map[string]string{"version": "7"},
+		HealthStatus: "passing",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
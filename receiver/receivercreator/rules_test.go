@@ -42,6 +42,9 @@ func Test_ruleEval(t *testing.T) {
 		{"annotations", args{`type == "pod" && annotations["scrape"] == "true"`, podEndpoint}, true, false},
 		{"basic container", args{`type == "container" && labels["region"] == "east-1"`, containerEndpoint}, true, false},
 		{"basic k8s.node", args{`type == "k8s.node" && kubelet_endpoint_port == 10250`, k8sNodeEndpoint}, true, false},
+		{"basic k8s.service", args{`type == "k8s.service" && port == 6379 && labels["app"] == "redis"`, k8sServiceEndpoint}, true, false},
+		{"basic k8s.ingress", args{`type == "k8s.ingress" && scheme == "https" && path == "/metrics"`, k8sIngressEndpoint}, true, false},
+		{"basic consul.service", args{`type == "consul.service" && name == "redis" && "primary" in tags && health_status == "passing"`, consulServiceEndpoint}, true, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -80,6 +83,9 @@ func Test_newRule(t *testing.T) {
 		{"valid pod", args{`type=="pod" && port_name == "http"`}, false},
 		{"valid hostport", args{`type == "hostport" && port_name == "http"`}, false},
 		{"valid container", args{`type == "container" && port == 8080`}, false},
+		{"valid k8s.service", args{`type == "k8s.service" && port_name == "redis"`}, false},
+		{"valid k8s.ingress", args{`type == "k8s.ingress" && host == "example.com"`}, false},
+		{"valid consul.service", args{`type == "consul.service" && meta["version"] == "7"`}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -18,11 +18,13 @@ import (
 	"context"
 	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -43,10 +45,13 @@ var (
 
 // NewFactory creates a factory for the StatsD receiver.
 func NewFactory() receiver.Factory {
+	_ = view.Register(MetricViews()...)
+
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, stability),
+		receiver.WithLogs(createLogsReceiver, component.StabilityLevelDevelopment),
 	)
 }
 
@@ -69,6 +74,48 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
-	c := cfg.(*Config)
-	return New(params, *c, consumer)
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r, err := getOrAddReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextLogsConsumer = consumer
+	return r, nil
 }
+
+func getOrAddReceiver(params receiver.CreateSettings, cfg component.Config) (*sharedcomponent.SharedComponent, error) {
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var recv *statsdReceiver
+		recv, err = newReceiver(params, *cfg.(*Config))
+		return recv
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// This is the map of already created StatsD receivers for particular configurations.
+// The metrics and logs pipelines must share one receiver per configuration, since
+// both are fed from the same listening socket.
+var receivers = sharedcomponent.NewSharedComponents()
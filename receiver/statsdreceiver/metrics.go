@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsdreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagReceiverKey, _  = tag.NewKey("receiver")
+	tagTransportKey, _ = tag.NewKey("transport")
+
+	statConnectionsOpened = stats.Int64("statsd_receiver_connections_opened", "Number of connections opened to the statsd receiver", stats.UnitDimensionless)
+	statConnectionsClosed = stats.Int64("statsd_receiver_connections_closed", "Number of connections to the statsd receiver that were closed", stats.UnitDimensionless)
+	statParseErrors       = stats.Int64("statsd_receiver_parse_errors", "Number of statsd messages that failed to be parsed", stats.UnitDimensionless)
+)
+
+// MetricViews return metric views for the statsd receiver.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagReceiverKey, tagTransportKey}
+
+	countConnectionsOpened := &view.View{
+		Name:        statConnectionsOpened.Name(),
+		Measure:     statConnectionsOpened,
+		Description: statConnectionsOpened.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countConnectionsClosed := &view.View{
+		Name:        statConnectionsClosed.Name(),
+		Measure:     statConnectionsClosed,
+		Description: statConnectionsClosed.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	countParseErrors := &view.View{
+		Name:        statParseErrors.Name(),
+		Measure:     statParseErrors,
+		Description: statParseErrors.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		countConnectionsOpened,
+		countConnectionsClosed,
+		countParseErrors,
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	attrType                   = "dogstatsd.type"
+	attrEventTitle             = "dogstatsd.event.title"
+	attrEventPriority          = "dogstatsd.event.priority"
+	attrEventAlertType         = "dogstatsd.event.alert_type"
+	attrEventAggregationKey    = "dogstatsd.event.aggregation_key"
+	attrEventSourceTypeName    = "dogstatsd.event.source_type_name"
+	attrServiceCheckName       = "dogstatsd.service_check.name"
+	attrServiceCheckStatus     = "dogstatsd.service_check.status"
+	attrHostName               = "host.name"
+	typeEvent                  = "event"
+	typeServiceCheck           = "service_check"
+	defaultEventPriority       = "normal"
+	defaultEventAlertType      = "info"
+	serviceCheckStatusOK       = 0
+	serviceCheckStatusWarning  = 1
+	serviceCheckStatusCritical = 2
+	serviceCheckStatusUnknown  = 3
+)
+
+type dogStatsDEvent struct {
+	title          string
+	text           string
+	timestamp      time.Time
+	hostname       string
+	priority       string
+	alertType      string
+	aggregationKey string
+	sourceTypeName string
+	tags           []attribute.KeyValue
+}
+
+type dogStatsDServiceCheck struct {
+	name      string
+	status    int
+	timestamp time.Time
+	hostname  string
+	message   string
+	tags      []attribute.KeyValue
+}
+
+// parseEvent parses a DogStatsD event of the form
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|k:<aggregation key>|s:<source type>|#<tags>
+func parseEvent(line string) (dogStatsDEvent, error) {
+	result := dogStatsDEvent{
+		priority:  defaultEventPriority,
+		alertType: defaultEventAlertType,
+	}
+
+	header, rest, found := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !found {
+		return result, fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, found := strings.Cut(header, ",")
+	if !found {
+		return result, fmt.Errorf("invalid event header: %s", header)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return result, fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return result, fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	if len(rest) < titleLen+1+textLen || rest[titleLen] != '|' {
+		return result, fmt.Errorf("event title and text do not match the declared lengths: %s", line)
+	}
+
+	result.title = rest[:titleLen]
+	result.text = strings.ReplaceAll(rest[titleLen+1:titleLen+1+textLen], `\n`, "\n")
+
+	rest = rest[titleLen+1+textLen:]
+	if rest == "" {
+		return result, nil
+	}
+	if rest[0] != '|' {
+		return result, fmt.Errorf("event title and text do not match the declared lengths: %s", line)
+	}
+
+	for _, part := range strings.Split(rest[1:], "|") {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			ts, err := parseUnixSeconds(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return result, err
+			}
+			result.timestamp = ts
+		case strings.HasPrefix(part, "h:"):
+			result.hostname = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "p:"):
+			result.priority = strings.TrimPrefix(part, "p:")
+		case strings.HasPrefix(part, "t:"):
+			result.alertType = strings.TrimPrefix(part, "t:")
+		case strings.HasPrefix(part, "k:"):
+			result.aggregationKey = strings.TrimPrefix(part, "k:")
+		case strings.HasPrefix(part, "s:"):
+			result.sourceTypeName = strings.TrimPrefix(part, "s:")
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
+			}
+			result.tags = append(result.tags, tags...)
+		default:
+			return result, fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+
+	return result, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check of the form
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>
+func parseServiceCheck(line string) (dogStatsDServiceCheck, error) {
+	result := dogStatsDServiceCheck{}
+
+	parts := strings.Split(strings.TrimPrefix(line, serviceCheckPrefix), "|")
+	if len(parts) < 2 {
+		return result, fmt.Errorf("invalid service check format: %s", line)
+	}
+
+	result.name = parts[0]
+	if result.name == "" {
+		return result, fmt.Errorf("empty service check name")
+	}
+	status, err := strconv.Atoi(parts[1])
+	if err != nil || status < serviceCheckStatusOK || status > serviceCheckStatusUnknown {
+		return result, fmt.Errorf("invalid service check status: %s", parts[1])
+	}
+	result.status = status
+
+	for i, part := range parts[2:] {
+		switch {
+		case strings.HasPrefix(part, "m:"):
+			// The message is always last and may itself contain '|'.
+			result.message = strings.Join(append([]string{strings.TrimPrefix(part, "m:")}, parts[i+3:]...), "|")
+			return result, nil
+		case strings.HasPrefix(part, "d:"):
+			ts, err := parseUnixSeconds(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return result, err
+			}
+			result.timestamp = ts
+		case strings.HasPrefix(part, "h:"):
+			result.hostname = strings.TrimPrefix(part, "h:")
+		case strings.HasPrefix(part, "#"):
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
+			}
+			result.tags = append(result.tags, tags...)
+		default:
+			return result, fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	return result, nil
+}
+
+func parseUnixSeconds(s string) (time.Time, error) {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse timestamp: %s", s)
+	}
+	return time.Unix(secs, 0), nil
+}
+
+func (p *StatsDParser) aggregateEvent(line string) error {
+	event, err := parseEvent(line)
+	if err != nil {
+		return err
+	}
+
+	lr := p.appendLogRecord(event.timestamp, event.hostname, event.tags)
+	lr.Body().SetStr(event.text)
+	switch event.alertType {
+	case "error":
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+	case "warning":
+		lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	default:
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+	}
+	lr.SetSeverityText(event.alertType)
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attrType, typeEvent)
+	attrs.PutStr(attrEventTitle, event.title)
+	attrs.PutStr(attrEventPriority, event.priority)
+	attrs.PutStr(attrEventAlertType, event.alertType)
+	if event.aggregationKey != "" {
+		attrs.PutStr(attrEventAggregationKey, event.aggregationKey)
+	}
+	if event.sourceTypeName != "" {
+		attrs.PutStr(attrEventSourceTypeName, event.sourceTypeName)
+	}
+	return nil
+}
+
+func (p *StatsDParser) aggregateServiceCheck(line string) error {
+	check, err := parseServiceCheck(line)
+	if err != nil {
+		return err
+	}
+
+	lr := p.appendLogRecord(check.timestamp, check.hostname, check.tags)
+	lr.Body().SetStr(check.message)
+	switch check.status {
+	case serviceCheckStatusOK:
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText("OK")
+	case serviceCheckStatusWarning:
+		lr.SetSeverityNumber(plog.SeverityNumberWarn)
+		lr.SetSeverityText("WARNING")
+	case serviceCheckStatusCritical:
+		lr.SetSeverityNumber(plog.SeverityNumberError)
+		lr.SetSeverityText("CRITICAL")
+	case serviceCheckStatusUnknown:
+		lr.SetSeverityText("UNKNOWN")
+	}
+
+	attrs := lr.Attributes()
+	attrs.PutStr(attrType, typeServiceCheck)
+	attrs.PutStr(attrServiceCheckName, check.name)
+	attrs.PutInt(attrServiceCheckStatus, int64(check.status))
+	return nil
+}
+
+func (p *StatsDParser) appendLogRecord(timestamp time.Time, hostname string, tags []attribute.KeyValue) plog.LogRecord {
+	rls := p.logs.ResourceLogs()
+	if rls.Len() == 0 {
+		rls.AppendEmpty().ScopeLogs().AppendEmpty()
+	}
+	lr := rls.At(0).ScopeLogs().At(0).LogRecords().AppendEmpty()
+
+	now := timeNowFunc()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+
+	for _, kv := range tags {
+		lr.Attributes().PutStr(string(kv.Key), kv.Value.AsString())
+	}
+	if hostname != "" {
+		lr.Attributes().PutStr(attrHostName, hostname)
+	}
+	return lr
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func Test_ParseEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantEvent dogStatsDEvent
+		err       error
+	}{
+		{
+			name:  "title and text only",
+			input: "_e{5,4}:title|text",
+			wantEvent: dogStatsDEvent{
+				title:     "title",
+				text:      "text",
+				priority:  "normal",
+				alertType: "info",
+			},
+		},
+		{
+			name:  "all fields",
+			input: `_e{6,12}:deploy|line1\nline2|d:1600000000|h:web-1|p:low|t:warning|k:deploys|s:jenkins|#env:prod,team:core`,
+			wantEvent: dogStatsDEvent{
+				title:          "deploy",
+				text:           "line1\nline2",
+				timestamp:      time.Unix(1600000000, 0),
+				hostname:       "web-1",
+				priority:       "low",
+				alertType:      "warning",
+				aggregationKey: "deploys",
+				sourceTypeName: "jenkins",
+				tags:           []attribute.KeyValue{attribute.String("env", "prod"), attribute.String("team", "core")},
+			},
+		},
+		{
+			name:  "text containing separator",
+			input: "_e{1,3}:t|a|b|t:error",
+			wantEvent: dogStatsDEvent{
+				title:     "t",
+				text:      "a|b",
+				priority:  "normal",
+				alertType: "error",
+			},
+		},
+		{
+			name:  "missing header terminator",
+			input: "_e{5,4}title|text",
+			err:   errors.New("invalid event format: _e{5,4}title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{x,4}:title|text",
+			err:   errors.New("invalid event title length: x"),
+		},
+		{
+			name:  "lengths do not match",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match the declared lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "_e{5,4}:title|text|d:yesterday",
+			err:   errors.New("parse timestamp: yesterday"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:foo",
+			err:   errors.New("unrecognized event part: x:foo"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEvent(tt.input)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantEvent, got)
+			}
+		})
+	}
+}
+
+func Test_ParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantCheck dogStatsDServiceCheck
+		err       error
+	}{
+		{
+			name:  "name and status only",
+			input: "_sc|db.up|0",
+			wantCheck: dogStatsDServiceCheck{
+				name:   "db.up",
+				status: 0,
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_sc|db.up|2|d:1600000000|h:db-1|#env:prod|m:connection refused | retrying",
+			wantCheck: dogStatsDServiceCheck{
+				name:      "db.up",
+				status:    2,
+				timestamp: time.Unix(1600000000, 0),
+				hostname:  "db-1",
+				message:   "connection refused | retrying",
+				tags:      []attribute.KeyValue{attribute.String("env", "prod")},
+			},
+		},
+		{
+			name:  "missing status",
+			input: "_sc|db.up",
+			err:   errors.New("invalid service check format: _sc|db.up"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|db.up|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "invalid tag",
+			input: "_sc|db.up|0|#env",
+			err:   errors.New("invalid tag format: [env]"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServiceCheck(tt.input)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCheck, got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_AggregateEventsAndServiceChecks(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	assert.NoError(t, p.Aggregate("_e{6,6}:deploy|v1.2.3|h:web-1|t:error|#env:prod"))
+	assert.NoError(t, p.Aggregate("_sc|db.up|1|d:700|m:slow"))
+	assert.NoError(t, p.Aggregate("test.metric:42|c"))
+
+	// Flushing the metrics first, as the receiver does, must not drop the logs.
+	assert.Equal(t, 1, p.GetMetrics().MetricCount())
+	logs := p.GetLogs()
+	require.Equal(t, 2, logs.LogRecordCount())
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+
+	event := records.At(0)
+	assert.Equal(t, "v1.2.3", event.Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, event.SeverityNumber())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), event.Timestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), event.ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{
+		"dogstatsd.type":             "event",
+		"dogstatsd.event.title":      "deploy",
+		"dogstatsd.event.priority":   "normal",
+		"dogstatsd.event.alert_type": "error",
+		"host.name":                  "web-1",
+		"env":                        "prod",
+	}, event.Attributes().AsRaw())
+
+	check := records.At(1)
+	assert.Equal(t, "slow", check.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, check.SeverityNumber())
+	assert.Equal(t, "WARNING", check.SeverityText())
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(700, 0)), check.Timestamp())
+	assert.Equal(t, map[string]interface{}{
+		"dogstatsd.type":                 "service_check",
+		"dogstatsd.service_check.name":   "db.up",
+		"dogstatsd.service_check.status": int64(1),
+	}, check.Attributes().AsRaw())
+
+	// Logs are reset after being read and do not include metrics.
+	assert.Equal(t, 0, p.GetLogs().LogRecordCount())
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -32,7 +33,7 @@ var (
 )
 
 type (
-	MetricType   string // From the statsd line e.g., "c", "g", "h"
+	MetricType   string // From the statsd line e.g., "c", "g", "h", "s", "d"
 	TypeName     string // How humans describe the MetricTypes ("counter", "gauge")
 	ObserverType string // How the server will aggregate histogram and timings ("gauge", "summary")
 )
@@ -40,16 +41,20 @@ type (
 const (
 	tagMetricType = "metric_type"
 
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	SetType          MetricType = "s"
+	DistributionType MetricType = "d"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	SetTypeName          TypeName = "set"
+	DistributionTypeName TypeName = "distribution"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -78,17 +83,27 @@ var defaultObserverCategory = ObserverCategory{
 	method: DefaultObserverType,
 }
 
+// defaultDistributionCategory is used for DogStatsD distributions, which
+// are meant to be aggregated into histograms unless configured otherwise.
+var defaultDistributionCategory = ObserverCategory{
+	method:          HistogramObserver,
+	histogramConfig: expoHistogramConfig(HistogramConfig{}),
+}
+
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]map[string]struct{}
 	timersAndDistributions []pmetric.ScopeMetrics
+	logs                   plog.Logs
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	timerEvents            ObserverCategory
 	histogramEvents        ObserverCategory
+	distributionEvents     ObserverCategory
 	lastIntervalTime       time.Time
 }
 
@@ -111,6 +126,7 @@ type histogramMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
+	setValue    string
 	addition    bool
 	unit        string
 	sampleRate  float64
@@ -132,6 +148,10 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case SetType:
+		return SetTypeName
+	case DistributionType:
+		return DistributionTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -143,13 +163,16 @@ func (p *StatsDParser) resetState(when time.Time) {
 	p.timersAndDistributions = nil
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
 	p.histograms = make(map[statsDMetricDescription]histogramMetric)
+	p.sets = make(map[statsDMetricDescription]map[string]struct{})
 }
 
 func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logs = plog.NewLogs()
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
+	p.distributionEvents = defaultDistributionCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).validate()
@@ -161,6 +184,9 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
 		}
 	}
 	return nil
@@ -214,10 +240,28 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 			rm.ScopeMetrics().AppendEmpty(),
 		)
 	}
+
+	for desc, members := range p.sets {
+		buildSetMetric(
+			desc,
+			len(members),
+			now,
+			rm.ScopeMetrics().AppendEmpty(),
+		)
+	}
 	p.resetState(now)
 	return metrics
 }
 
+// GetLogs gets the events and service checks received since the last call
+// and resets the collected logs. Unlike GetMetrics, it does not reset the
+// metric aggregation state.
+func (p *StatsDParser) GetLogs() plog.Logs {
+	logs := p.logs
+	p.logs = plog.NewLogs()
+	return logs
+}
+
 var timeNowFunc = time.Now
 
 func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
@@ -226,12 +270,21 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
+	case DistributionType:
+		return p.distributionEvents
 	}
 	return defaultObserverCategory
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.aggregateEvent(line)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.aggregateServiceCheck(line)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
 	if err != nil {
 		return err
@@ -259,7 +312,15 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case SetType:
+		members, ok := p.sets[parsedMetric.description]
+		if !ok {
+			members = make(map[string]struct{})
+			p.sets[parsedMetric.description] = members
+		}
+		members[parsedMetric.setValue] = struct{}{}
+
+	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
 		case GaugeObserver:
@@ -330,7 +391,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, SetType, DistributionType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -352,27 +413,26 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
+			kvs = append(kvs, tags...)
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+
+	if result.description.metricType == SetType {
+		// Set members are opaque identifiers, only their uniqueness matters.
+		result.setValue = valueStr
+		result.addition = false
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -388,3 +448,15 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	return result, nil
 }
+
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+	}
+	return kvs, nil
+}
@@ -21,6 +21,8 @@ import (
 
 	"github.com/lightstep/go-expohisto/mapping/logarithm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
 
@@ -84,6 +86,18 @@ func Test_ParseMessageToMetric(t *testing.T) {
 			input: "test.metric:42.abc|c",
 			err:   errors.New("parse metric value string: 42.abc"),
 		},
+		{
+			name:  "distribution metric",
+			input: "test.metric:42.5|d|#key:value",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42.5,
+				false,
+				"d",
+				0,
+				[]string{"key"},
+				[]string{"value"}),
+		},
 		{
 			name:  "unhandled metric type",
 			input: "test.metric:42|unhandled_type",
@@ -1225,3 +1239,92 @@ func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsDParser_AggregateSet(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	for _, line := range []string{
+		"users:alice|s|#region:us",
+		"users:bob|s|#region:us",
+		"users:alice|s|#region:us",
+		"users:+carol|s|#region:us",
+		"users:alice|s|#region:eu",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	got := map[string]int64{}
+	metrics := p.GetMetrics()
+	ilm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	require.Equal(t, 2, ilm.Len())
+	for i := 0; i < ilm.Len(); i++ {
+		m := ilm.At(i).Metrics().At(0)
+		assert.Equal(t, "users", m.Name())
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		dp := m.Gauge().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(711, 0)), dp.Timestamp())
+		region, ok := dp.Attributes().Get("region")
+		require.True(t, ok)
+		got[region.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"us": 3, "eu": 1}, got)
+
+	// Unique members are counted per interval.
+	assert.NoError(t, p.Aggregate("users:alice|s|#region:us"))
+	metrics = p.GetMetrics()
+	require.Equal(t, 1, metrics.ResourceMetrics().At(0).ScopeMetrics().Len())
+	assert.Equal(t, int64(1), metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestStatsDParser_AggregateDistribution(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	tests := []struct {
+		name       string
+		mapping    []TimerHistogramMapping
+		expectType pmetric.MetricType
+	}{
+		{
+			name:       "default histogram",
+			expectType: pmetric.MetricTypeExponentialHistogram,
+		},
+		{
+			name:       "mapped to summary",
+			mapping:    []TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "summary"}},
+			expectType: pmetric.MetricTypeSummary,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &StatsDParser{}
+			assert.NoError(t, p.Initialize(false, false, tt.mapping))
+			assert.NoError(t, p.Aggregate("latency:1|d"))
+			assert.NoError(t, p.Aggregate("latency:2|d"))
+			assert.NoError(t, p.Aggregate("latency:4|d|@0.5"))
+
+			metrics := p.GetMetrics()
+			require.Equal(t, 1, metrics.ResourceMetrics().At(0).ScopeMetrics().Len())
+			m := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+			assert.Equal(t, "latency", m.Name())
+			require.Equal(t, tt.expectType, m.Type())
+			switch m.Type() {
+			case pmetric.MetricTypeExponentialHistogram:
+				dp := m.ExponentialHistogram().DataPoints().At(0)
+				assert.Equal(t, uint64(4), dp.Count())
+				assert.Equal(t, 11.0, dp.Sum())
+				assert.Equal(t, 1.0, dp.Min())
+				assert.Equal(t, 4.0, dp.Max())
+			case pmetric.MetricTypeSummary:
+				dp := m.Summary().DataPoints().At(0)
+				assert.Equal(t, uint64(4), dp.Count())
+				assert.Equal(t, 11.0, dp.Sum())
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
 
@@ -32,17 +33,19 @@ import (
 )
 
 var _ receiver.Metrics = (*statsdReceiver)(nil)
+var _ receiver.Logs = (*statsdReceiver)(nil)
 
 // statsdReceiver implements the receiver.Metrics for StatsD protocol.
 type statsdReceiver struct {
 	settings receiver.CreateSettings
 	config   *Config
 
-	server       transport.Server
-	reporter     transport.Reporter
-	parser       protocol.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server           transport.Server
+	reporter         transport.Reporter
+	parser           protocol.Parser
+	nextConsumer     consumer.Metrics
+	nextLogsConsumer consumer.Logs
+	cancel           context.CancelFunc
 }
 
 // New creates the StatsD receiver with the given parameters.
@@ -55,30 +58,50 @@ func New(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = nextConsumer
+	return r, nil
+}
+
+// newReceiver creates a statsdReceiver without any next consumer. Metrics
+// and logs consumers are attached by the factory, since a single receiver
+// serves both pipelines for a given configuration.
+func newReceiver(set receiver.CreateSettings, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
 
-	rep, err := newReporter(set)
+	if config.NetAddr.Transport == "" {
+		config.NetAddr.Transport = defaultTransport
+	}
+
+	rep, err := newReporter(set, strings.ToLower(config.NetAddr.Transport))
 	if err != nil {
 		return nil, err
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		reporter:     rep,
-		parser:       &protocol.StatsDParser{},
+		settings: set,
+		config:   &config,
+		reporter: rep,
+		parser:   &protocol.StatsDParser{},
 	}
 	return r, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
+	case "unix":
+		return transport.NewUnixServer(config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q", config.NetAddr.Transport)
@@ -99,7 +122,7 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				host.ReportFatalError(err)
 			}
@@ -110,11 +133,17 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			select {
 			case <-ticker.C:
 				metrics := r.parser.GetMetrics()
-				if metrics.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
+				if r.nextConsumer != nil && metrics.ResourceMetrics().At(0).ScopeMetrics().Len() > 0 {
 					r.Flush(ctx, metrics, r.nextConsumer)
 				}
+				logs := r.parser.GetLogs()
+				if r.nextLogsConsumer != nil && logs.LogRecordCount() > 0 {
+					r.FlushLogs(ctx, logs, r.nextLogsConsumer)
+				}
 			case rawMetric := <-transferChan:
-				_ = r.parser.Aggregate(rawMetric)
+				if err := r.parser.Aggregate(rawMetric); err != nil {
+					r.reporter.OnTranslationError(ctx, err)
+				}
 			case <-ctx.Done():
 				ticker.Stop()
 				return
@@ -143,3 +172,7 @@ func (r *statsdReceiver) Flush(ctx context.Context, metrics pmetric.Metrics, nex
 
 	return nil
 }
+
+func (r *statsdReceiver) FlushLogs(ctx context.Context, logs plog.Logs, nextConsumer consumer.Logs) error {
+	return nextConsumer.ConsumeLogs(ctx, logs)
+}
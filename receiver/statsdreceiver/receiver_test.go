@@ -140,6 +140,23 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "tcp with 4s interval",
+			configFn: func() *Config {
+				return &Config{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  defaultBindEndpoint,
+						Transport: "tcp",
+					},
+					AggregationInterval: 4 * time.Second,
+				}
+			},
+			clientFn: func(t *testing.T) *client.StatsD {
+				c, err := client.NewStatsD(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -196,3 +213,36 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func Test_statsdreceiver_FlushLogs(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	r, err := newReceiver(receivertest.NewNopCreateSettings(), Config{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  addr,
+			Transport: defaultTransport,
+		},
+		AggregationInterval: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	r.nextConsumer = metricsSink
+	r.nextLogsConsumer = logsSink
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("_e{6,6}:deploy|v1.2.3\ntest.metric:42|c"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return logsSink.LogRecordCount() == 1 && metricsSink.DataPointCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	record := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "v1.2.3", record.Body().Str())
+}
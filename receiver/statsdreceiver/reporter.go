@@ -17,6 +17,8 @@ package statsdreceiver // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/receiver"
@@ -31,14 +33,15 @@ type reporter struct {
 	logger        *zap.Logger
 	sugaredLogger *zap.SugaredLogger // Used for generic debug logging
 	obsrecv       *obsreport.Receiver
+	statsTags     []tag.Mutator
 }
 
 var _ transport.Reporter = (*reporter)(nil)
 
-func newReporter(set receiver.CreateSettings) (transport.Reporter, error) {
+func newReporter(set receiver.CreateSettings, transportName string) (transport.Reporter, error) {
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             set.ID,
-		Transport:              "tcp",
+		Transport:              transportName,
 		ReceiverCreateSettings: set,
 	})
 	if err != nil {
@@ -48,6 +51,10 @@ func newReporter(set receiver.CreateSettings) (transport.Reporter, error) {
 		logger:        set.Logger,
 		sugaredLogger: set.Logger.Sugar(),
 		obsrecv:       obsrecv,
+		statsTags: []tag.Mutator{
+			tag.Upsert(tagReceiverKey, set.ID.String()),
+			tag.Upsert(tagTransportKey, transportName),
+		},
 	}, nil
 }
 
@@ -68,6 +75,7 @@ func (r *reporter) OnTranslationError(ctx context.Context, err error) {
 	}
 
 	r.logger.Debug("StatsD translation error", zap.Error(err))
+	_ = stats.RecordWithTags(ctx, r.statsTags, statParseErrors.M(1))
 
 	// Using annotations since multiple translation errors can happen in the
 	// same client message/request. The time itself is not relevant.
@@ -103,6 +111,16 @@ func (r *reporter) OnMetricsProcessed(
 	r.obsrecv.EndMetricsOp(ctx, "statsd", numReceivedMessages, err)
 }
 
+// OnConnectionOpened is called when a client connects to a connection-oriented transport.
+func (r *reporter) OnConnectionOpened() {
+	_ = stats.RecordWithTags(context.Background(), r.statsTags, statConnectionsOpened.M(1))
+}
+
+// OnConnectionClosed is called when a client connection is closed.
+func (r *reporter) OnConnectionClosed() {
+	_ = stats.RecordWithTags(context.Background(), r.statsTags, statConnectionsClosed.M(1))
+}
+
 func (r *reporter) OnDebugf(template string, args ...interface{}) {
 	if r.logger.Check(zap.DebugLevel, "debug") != nil {
 		r.sugaredLogger.Debugf(template, args...)
@@ -19,7 +19,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -32,7 +35,7 @@ func TestReporterObservability(t *testing.T) {
 		require.NoError(t, tt.Shutdown(context.Background()))
 	}()
 
-	reporter, err := newReporter(tt.ToReceiverCreateSettings())
+	reporter, err := newReporter(tt.ToReceiverCreateSettings(), "tcp")
 	require.NoError(t, err)
 
 	ctx := reporter.OnDataReceived(context.Background())
@@ -48,3 +51,52 @@ func TestReporterObservability(t *testing.T) {
 
 	require.NoError(t, tt.CheckReceiverMetrics("tcp", 17, 10))
 }
+
+func TestReporterConnectionAndParseErrorMetrics(t *testing.T) {
+	// Views may already be registered by NewFactory, use a dedicated receiver ID
+	// to avoid counting measurements recorded by other tests.
+	require.NoError(t, view.Register(MetricViews()...))
+
+	receiverID := component.NewIDWithName(typeStr, "fake_receiver_connections")
+	tt, err := obsreporttest.SetupTelemetry(receiverID)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tt.Shutdown(context.Background()))
+	}()
+
+	reporter, err := newReporter(tt.ToReceiverCreateSettings(), "tcp")
+	require.NoError(t, err)
+
+	reporter.OnConnectionOpened()
+	reporter.OnConnectionOpened()
+	reporter.OnConnectionClosed()
+	reporter.OnTranslationError(context.Background(), errors.New("fake error for tests"))
+
+	for name, want := range map[string]float64{
+		"statsd_receiver_connections_opened": 2,
+		"statsd_receiver_connections_closed": 1,
+		"statsd_receiver_parse_errors":       1,
+	} {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		var found bool
+		for _, row := range rows {
+			if !containsTag(row.Tags, tag.Tag{Key: tagReceiverKey, Value: receiverID.String()}) {
+				continue
+			}
+			found = true
+			assert.True(t, containsTag(row.Tags, tag.Tag{Key: tagTransportKey, Value: "tcp"}), name)
+			assert.Equal(t, want, row.Data.(*view.SumData).Value, name)
+		}
+		assert.True(t, found, name)
+	}
+}
+
+func containsTag(tags []tag.Tag, want tag.Tag) bool {
+	for _, t := range tags {
+		if t == want {
+			return true
+		}
+	}
+	return false
+}
@@ -17,12 +17,15 @@ package transport // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 )
 
 // MockReporter provides a Reporter that provides some useful functionalities for
 // tests (eg.: wait for certain number of messages).
 type MockReporter struct {
 	wgMetricsProcessed sync.WaitGroup
+	connectionsOpened  atomic.Int64
+	connectionsClosed  atomic.Int64
 }
 
 var _ Reporter = (*MockReporter)(nil)
@@ -45,6 +48,24 @@ func (m *MockReporter) OnMetricsProcessed(ctx context.Context, numReceivedMessag
 	m.wgMetricsProcessed.Done()
 }
 
+func (m *MockReporter) OnConnectionOpened() {
+	m.connectionsOpened.Add(1)
+}
+
+func (m *MockReporter) OnConnectionClosed() {
+	m.connectionsClosed.Add(1)
+}
+
+// ConnectionsOpened returns the number of OnConnectionOpened calls.
+func (m *MockReporter) ConnectionsOpened() int64 {
+	return m.connectionsOpened.Load()
+}
+
+// ConnectionsClosed returns the number of OnConnectionClosed calls.
+func (m *MockReporter) ConnectionsClosed() int64 {
+	return m.connectionsClosed.Load()
+}
+
 func (m *MockReporter) OnDebugf(template string, args ...interface{}) {
 }
 
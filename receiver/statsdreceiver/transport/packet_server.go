@@ -19,40 +19,50 @@ import (
 	"errors"
 	"io"
 	"net"
+	"os"
 	"strings"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-type udpServer struct {
+type packetServer struct {
+	network    string
 	packetConn net.PacketConn
 	reporter   Reporter
 }
 
-var _ (Server) = (*udpServer)(nil)
+var _ (Server) = (*packetServer)(nil)
 
 // NewUDPServer creates a transport.Server using UDP as its transport.
 func NewUDPServer(addr string) (Server, error) {
-	packetConn, err := net.ListenPacket("udp", addr)
+	return newPacketServer("udp", addr)
+}
+
+// NewUnixgramServer creates a transport.Server using Unix datagram sockets
+// as its transport. The socket is created at path and removed on Close.
+func NewUnixgramServer(path string) (Server, error) {
+	return newPacketServer("unixgram", path)
+}
+
+func newPacketServer(network string, addr string) (Server, error) {
+	packetConn, err := net.ListenPacket(network, addr)
 	if err != nil {
 		return nil, err
 	}
 
-	u := udpServer{
+	u := packetServer{
+		network:    network,
 		packetConn: packetConn,
 	}
 	return &u, nil
 }
 
-func (u *udpServer) ListenAndServe(
+func (u *packetServer) ListenAndServe(
 	parser protocol.Parser,
-	nextConsumer consumer.Metrics,
 	reporter Reporter,
 	transferChan chan<- string,
 ) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
+	if parser == nil || reporter == nil {
 		return errNilListenAndServeParameters
 	}
 
@@ -67,7 +77,8 @@ func (u *udpServer) ListenAndServe(
 			u.handlePacket(bufCopy, transferChan)
 		}
 		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				u.network,
 				u.packetConn.LocalAddr(),
 				err)
 			var netErr net.Error
@@ -81,11 +92,18 @@ func (u *udpServer) ListenAndServe(
 	}
 }
 
-func (u *udpServer) Close() error {
-	return u.packetConn.Close()
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	if u.network == "unixgram" {
+		// Unlike stream listeners, datagram sockets do not unlink their file on close.
+		if rmErr := os.Remove(u.packetConn.LocalAddr().String()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
 }
 
-func (u *udpServer) handlePacket(
+func (u *packetServer) handlePacket(
 	data []byte,
 	transferChan chan<- string,
 ) {
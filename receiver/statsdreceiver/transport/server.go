@@ -18,8 +18,6 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/collector/consumer"
-
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
@@ -35,7 +33,6 @@ type Server interface {
 	// the Parser and passed to the next consumer.
 	ListenAndServe(
 		p protocol.Parser,
-		mc consumer.Metrics,
 		r Reporter,
 		transferChan chan<- string,
 	) error
@@ -65,6 +62,14 @@ type Reporter interface {
 	// the next consumer - the reporter is expected to handle nil error too.
 	OnMetricsProcessed(ctx context.Context, numReceivedMessages int, err error)
 
+	// OnConnectionOpened is called when a client connects to a
+	// connection-oriented transport such as TCP or Unix stream sockets.
+	OnConnectionOpened()
+
+	// OnConnectionClosed is called when a connection previously reported
+	// via OnConnectionOpened is closed.
+	OnConnectionClosed()
+
 	// OnDebugf allows less structured reporting for debugging scenarios.
 	OnDebugf(
 		template string,
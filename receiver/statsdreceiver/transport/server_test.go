@@ -16,6 +16,8 @@ package transport
 
 import (
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -24,7 +26,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
@@ -34,47 +35,68 @@ import (
 func Test_Server_ListenAndServe(t *testing.T) {
 
 	tests := []struct {
-		name          string
-		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		name            string
+		getAddrFn       func(t *testing.T) string
+		buildServerFn   func(addr string) (Server, error)
+		buildClientFn   func(addr string) (*client.StatsD, error)
+		connectionBased bool
 	}{
 		{
 			name:          "udp",
+			getAddrFn:     getAvailableUDPAddress,
 			buildServerFn: NewUDPServer,
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name: "tcp",
+			getAddrFn: func(t *testing.T) string {
+				return testutil.GetAvailableLocalAddress(t)
+			},
+			buildServerFn: NewTCPServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+			connectionBased: true,
+		},
+		{
+			name:          "unixgram",
+			getAddrFn:     getSocketPath,
+			buildServerFn: NewUnixgramServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unixgram", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+		},
+		{
+			name:          "unix",
+			getAddrFn:     getSocketPath,
+			buildServerFn: NewUnixServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unix", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+			connectionBased: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
-
-			// Endpoint should be free.
-			ln0, err := net.ListenPacket("udp", addr)
-			require.NoError(t, err)
-			require.NotNil(t, ln0)
-
-			// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
-			ln1, err := net.ListenPacket("udp", addr)
-			require.Error(t, err)
-			require.Nil(t, ln1)
-
-			// Unbind the local address so the mock UDP service can use it
-			ln0.Close()
+			addr := tt.getAddrFn(t)
 
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
-			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
-			require.NoError(t, err)
 			mr := NewMockReporter(1)
 			var transferChan = make(chan string, 10)
 
@@ -82,12 +104,12 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			wgListenAndServe.Add(1)
 			go func() {
 				defer wgListenAndServe.Done()
-				assert.Error(t, srv.ListenAndServe(p, mc, mr, transferChan))
+				assert.Error(t, srv.ListenAndServe(p, mr, transferChan))
 			}()
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 			err = gc.SendMetric(client.Metric{
@@ -111,6 +133,87 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 			wgListenAndServe.Wait()
 			assert.Equal(t, 1, len(transferChan))
+			assert.Equal(t, "test.metric:42|c", <-transferChan)
+
+			if tt.connectionBased {
+				assert.Equal(t, int64(1), mr.ConnectionsOpened())
+				assert.Equal(t, int64(1), mr.ConnectionsClosed())
+			} else {
+				assert.Equal(t, int64(0), mr.ConnectionsOpened())
+			}
 		})
 	}
 }
+
+func Test_StreamServer_MultipleLines(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr)
+	require.NoError(t, err)
+
+	mr := NewMockReporter(0)
+	transferChan := make(chan string, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, mr, transferChan))
+	}()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	// The last line is not terminated and is only delivered once the client disconnects.
+	_, err = conn.Write([]byte("a:1|c\n\nb:2|g\r\nc:3|ms"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		return len(transferChan) == 3
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "a:1|c", <-transferChan)
+	assert.Equal(t, "b:2|g", <-transferChan)
+	assert.Equal(t, "c:3|ms", <-transferChan)
+
+	require.NoError(t, srv.Close())
+	<-done
+}
+
+func Test_UnixgramServer_RemovesSocket(t *testing.T) {
+	path := getSocketPath(t)
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Close())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func getAvailableUDPAddress(t *testing.T) string {
+	addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+
+	// Endpoint should be free.
+	ln0, err := net.ListenPacket("udp", addr)
+	require.NoError(t, err)
+	require.NotNil(t, ln0)
+
+	// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
+	ln1, err := net.ListenPacket("udp", addr)
+	require.Error(t, err)
+	require.Nil(t, ln1)
+
+	// Unbind the local address so the mock UDP service can use it
+	ln0.Close()
+	return addr
+}
+
+func getSocketPath(t *testing.T) string {
+	return filepath.Join(t.TempDir(), "statsd.sock")
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// maxLineSize is the largest newline-delimited message accepted on a stream
+// connection. Longer lines cause the connection to be closed.
+const maxLineSize = 1024 * 1024
+
+type streamServer struct {
+	network  string
+	listener net.Listener
+	reporter Reporter
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ (Server) = (*streamServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+// Messages are expected to be newline-delimited.
+func NewTCPServer(addr string) (Server, error) {
+	return newStreamServer("tcp", addr)
+}
+
+// NewUnixServer creates a transport.Server using Unix stream sockets as its
+// transport. Messages are expected to be newline-delimited.
+func NewUnixServer(path string) (Server, error) {
+	return newStreamServer("unix", path)
+}
+
+func newStreamServer(network string, addr string) (Server, error) {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &streamServer{
+		network:  network,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}, nil
+}
+
+func (s *streamServer) ListenAndServe(
+	parser protocol.Parser,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	s.reporter = reporter
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.reporter.OnDebugf("%s Transport (%s) - Accept error: %v",
+				s.network,
+				s.listener.Addr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+
+		if !s.track(conn) {
+			conn.Close()
+			continue
+		}
+		go s.handleConn(conn, transferChan)
+	}
+}
+
+// track registers an accepted connection, it returns false if the server
+// is already closed.
+func (s *streamServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *streamServer) handleConn(conn net.Conn, transferChan chan<- string) {
+	s.reporter.OnConnectionOpened()
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		s.reporter.OnConnectionClosed()
+		s.wg.Done()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		s.reporter.OnDebugf("%s Transport (%s) - Read error from %s: %v",
+			s.network,
+			s.listener.Addr(),
+			conn.RemoteAddr(),
+			err)
+	}
+}
+
+// Close stops accepting connections, closes the open ones and waits for
+// their handlers to finish.
+func (s *streamServer) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+// Supported STARTTLS protocols
+const (
+	startTLSSMTP     = "smtp"
+	startTLSIMAP     = "imap"
+	startTLSPostgres = "postgres"
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errMissingSources  = errors.New(`at least one of "targets" or "files" must be specified`)
+	errMissingEndpoint = errors.New(`"endpoint" not specified in config`)
+	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <hostname>:<port>`)
+	errMissingPath     = errors.New(`"path" not specified in config`)
+	errInvalidStartTLS = fmt.Errorf(`"starttls" must be one of %q, %q or %q`, startTLSSMTP, startTLSIMAP, startTLSPostgres)
+
+	errConfigNotTLSCheck = errors.New("config was not a TLS check receiver config")
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	// Targets are TLS endpoints whose certificate chain is retrieved with a handshake.
+	Targets []*targetConfig `mapstructure:"targets"`
+	// Files are PEM encoded certificate files, or directories containing them.
+	Files []*fileConfig `mapstructure:"files"`
+}
+
+// targetConfig defines a TLS endpoint to check.
+type targetConfig struct {
+	// Endpoint is the host:port to connect to.
+	Endpoint string `mapstructure:"endpoint"`
+	// ServerName is sent as SNI and used to verify the certificate, the endpoint host if not set.
+	ServerName string `mapstructure:"server_name"`
+	// StartTLS is the protocol used to upgrade a plain connection to TLS, if any.
+	StartTLS string `mapstructure:"starttls"`
+	// CAFile is the PEM encoded CA bundle used to validate the chain, the system roots if not set.
+	CAFile string `mapstructure:"ca_file"`
+	// Timeout bounds connecting and completing the handshake.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// fileConfig defines a certificate file or directory to check.
+type fileConfig struct {
+	// Path is a PEM encoded certificate file, or a directory of them.
+	Path string `mapstructure:"path"`
+	// CAFile is the PEM encoded CA bundle used to validate the chain, the system roots if not set.
+	CAFile string `mapstructure:"ca_file"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.Targets) == 0 && len(cfg.Files) == 0 {
+		err = multierr.Append(err, errMissingSources)
+	}
+
+	for _, target := range cfg.Targets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	for _, file := range cfg.Files {
+		if file.Path == "" {
+			err = multierr.Append(err, errMissingPath)
+		}
+	}
+
+	return err
+}
+
+// Validate validates the endpoint and STARTTLS protocol of the target.
+func (cfg *targetConfig) Validate() error {
+	var err error
+
+	if cfg.Endpoint == "" {
+		err = multierr.Append(err, errMissingEndpoint)
+	} else if _, _, splitErr := net.SplitHostPort(cfg.Endpoint); splitErr != nil {
+		err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), splitErr))
+	}
+
+	switch cfg.StartTLS {
+	case "", startTLSSMTP, startTLSIMAP, startTLSPostgres:
+	default:
+		err = multierr.Append(err, errInvalidStartTLS)
+	}
+
+	return err
+}
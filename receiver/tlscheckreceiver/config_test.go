@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.uber.org/multierr"
+)
+
+// check that OTel Collector patterns are implemented
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+	if err := componenttest.CheckConfigStruct(&Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewID(typeStr).String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.CollectionInterval = time.Hour
+	expected.Targets = []*targetConfig{
+		{Endpoint: "example.com:443"},
+		{Endpoint: "mail.example.com:587", ServerName: "smtp.example.com", StartTLS: startTLSSMTP, Timeout: 5 * time.Second},
+	}
+	expected.Files = []*fileConfig{
+		{Path: "/etc/ssl/certs/server.pem", CAFile: "/etc/ssl/certs/ca.pem"},
+	}
+	require.Equal(t, expected, cfg)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			desc:        "no targets or files",
+			cfg:         &Config{},
+			expectedErr: errMissingSources,
+		},
+		{
+			desc: "missing endpoint",
+			cfg: &Config{
+				Targets: []*targetConfig{{}},
+			},
+			expectedErr: errMissingEndpoint,
+		},
+		{
+			desc: "endpoint without port",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com"}},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`"endpoint" must be in the form of <hostname>:<port>: address example.com: missing port in address`),
+			),
+		},
+		{
+			desc: "invalid starttls",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "example.com:21", StartTLS: "ftp"}},
+			},
+			expectedErr: errInvalidStartTLS,
+		},
+		{
+			desc: "missing path",
+			cfg: &Config{
+				Files: []*fileConfig{{CAFile: "/etc/ssl/certs/ca.pem"}},
+			},
+			expectedErr: errMissingPath,
+		},
+		{
+			desc: "valid config",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{Endpoint: "example.com:443"},
+					{Endpoint: "mail.example.com:143", StartTLS: startTLSIMAP},
+					{Endpoint: "db.example.com:5432", StartTLS: startTLSPostgres},
+				},
+				Files: []*fileConfig{{Path: "/etc/ssl/certs"}},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if tc.expectedErr != nil {
+				require.EqualError(t, actualErr, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+			}
+		})
+	}
+}
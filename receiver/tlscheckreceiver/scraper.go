@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver/internal/metadata"
+)
+
+const defaultTimeout = 10 * time.Second
+
+var (
+	errNoCertificates = errors.New("no certificates found")
+
+	// certificateExtensions are the file extensions read when a directory is configured.
+	certificateExtensions = map[string]bool{".pem": true, ".crt": true, ".cer": true, ".cert": true}
+)
+
+type tlscheckScraper struct {
+	cfg      *Config
+	settings component.TelemetrySettings
+	mb       *metadata.MetricsBuilder
+	mu       sync.Mutex
+
+	// roots holds the CA pool of each ca_file, nil meaning the system roots.
+	roots map[string]*x509.CertPool
+}
+
+// start loads the CA bundles used to validate certificate chains
+func (s *tlscheckScraper) start(_ context.Context, _ component.Host) error {
+	s.roots = map[string]*x509.CertPool{}
+	for _, target := range s.cfg.Targets {
+		if err := s.loadRoots(target.CAFile); err != nil {
+			return err
+		}
+	}
+	for _, file := range s.cfg.Files {
+		if err := s.loadRoots(file.CAFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *tlscheckScraper) loadRoots(caFile string) error {
+	if caFile == "" {
+		return nil
+	}
+	if _, ok := s.roots[caFile]; ok {
+		return nil
+	}
+	pemData, err := os.ReadFile(filepath.Clean(caFile))
+	if err != nil {
+		return fmt.Errorf("failed to load CA file %q: %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return fmt.Errorf("failed to load CA file %q: %w", caFile, errNoCertificates)
+	}
+	s.roots[caFile] = pool
+	return nil
+}
+
+// scrape retrieves the certificates of every target and file and produces metrics based on them
+func (s *tlscheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var wg sync.WaitGroup
+	wg.Add(len(s.cfg.Targets))
+	for _, target := range s.cfg.Targets {
+		go func(target *targetConfig) {
+			defer wg.Done()
+			s.checkTarget(ctx, target)
+		}(target)
+	}
+
+	for _, file := range s.cfg.Files {
+		s.checkFile(file)
+	}
+	wg.Wait()
+
+	return s.mb.Emit(), nil
+}
+
+func (s *tlscheckScraper) checkTarget(ctx context.Context, cfg *targetConfig) {
+	serverName := cfg.ServerName
+	if serverName == "" {
+		serverName, _, _ = net.SplitHostPort(cfg.Endpoint)
+	}
+
+	certs, err := fetchCertificates(ctx, cfg, serverName)
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.mb.RecordTlscheckErrorDataPoint(now, int64(1), cfg.Endpoint, err.Error())
+		return
+	}
+	s.recordChain(now, cfg.Endpoint, certs, s.roots[cfg.CAFile], serverName)
+}
+
+func (s *tlscheckScraper) checkFile(cfg *fileConfig) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	paths, err := certificatePaths(cfg.Path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.mb.RecordTlscheckErrorDataPoint(now, int64(1), cfg.Path, err.Error())
+		return
+	}
+
+	for _, path := range paths {
+		certs, err := readCertificates(path)
+		if err != nil {
+			s.mb.RecordTlscheckErrorDataPoint(now, int64(1), path, err.Error())
+			continue
+		}
+		s.recordChain(now, path, certs, s.roots[cfg.CAFile], "")
+	}
+}
+
+// recordChain records the expiry of every certificate of the chain, leaf first, and whether the chain is valid.
+func (s *tlscheckScraper) recordChain(now pcommon.Timestamp, source string, certs []*x509.Certificate, roots *x509.CertPool, serverName string) {
+	for i, cert := range certs {
+		daysLeft := time.Until(cert.NotAfter).Hours() / 24
+		s.mb.RecordTlscheckTimeLeftDataPoint(now, daysLeft, source, int64(i), cert.Subject.String(), cert.Issuer.String(), subjectAltNames(cert))
+	}
+
+	valid := int64(1)
+	if err := verifyChain(certs, roots, serverName); err != nil {
+		s.settings.Logger.Debug("certificate chain validation failed", zap.String("source", source), zap.Error(err))
+		valid = 0
+	}
+	s.mb.RecordTlscheckChainValidDataPoint(now, valid, source)
+}
+
+// fetchCertificates connects to the target and returns the certificates presented during the handshake.
+func fetchCertificates(ctx context.Context, cfg *targetConfig, serverName string) ([]*x509.Certificate, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.StartTLS != "" {
+		if err = startTLS(conn, cfg.StartTLS); err != nil {
+			return nil, fmt.Errorf("%s STARTTLS failed: %w", cfg.StartTLS, err)
+		}
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: serverName,
+		// The chain is verified separately so that certificates are reported even when they are not trusted.
+		InsecureSkipVerify: true, // #nosec G402
+	})
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, err
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, errNoCertificates
+	}
+	return certs, nil
+}
+
+// certificatePaths returns path if it is a file, or the certificate files found under it if it is a directory.
+func certificatePaths(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && certificateExtensions[strings.ToLower(filepath.Ext(p))] {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// readCertificates parses the PEM encoded certificates of a file, in order.
+func readCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errNoCertificates
+	}
+	return certs, nil
+}
+
+// verifyChain verifies the leaf certificate using the rest of the chain as intermediates.
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool, serverName string) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// subjectAltNames returns the subject alternative names of the certificate as a comma separated list.
+func subjectAltNames(cert *x509.Certificate) string {
+	names := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses)+len(cert.EmailAddresses)+len(cert.URIs))
+	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	return strings.Join(names, ",")
+}
+
+func newScraper(cfg *Config, settings receiver.CreateSettings) *tlscheckScraper {
+	return &tlscheckScraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
+	}
+}
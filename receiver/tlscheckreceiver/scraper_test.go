@@ -0,0 +1,528 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// generateCert creates a certificate valid for the given duration, signed by parent or self-signed if parent is nil.
+func generateCert(t *testing.T, commonName string, validFor time.Duration, isCA bool, parent *testCert, dnsNames ...string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.IPAddresses = nil
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+func writePEM(t *testing.T, path string, certs ...*x509.Certificate) {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0600))
+}
+
+func tlsConfig(chain ...*testCert) *tls.Config {
+	certificate := tls.Certificate{PrivateKey: chain[0].key, Leaf: chain[0].cert}
+	for _, c := range chain {
+		certificate.Certificate = append(certificate.Certificate, c.cert.Raw)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12}
+}
+
+type timeLeftPoint struct {
+	source   string
+	position int64
+	subject  string
+	issuer   string
+	san      string
+	daysLeft float64
+}
+
+type scrapeResult struct {
+	timeLeft []timeLeftPoint
+	valid    map[string]int64
+	errors   map[string]string
+}
+
+func collect(t *testing.T, md pmetric.Metrics) scrapeResult {
+	result := scrapeResult{valid: map[string]int64{}, errors: map[string]string{}}
+	str := func(dp pmetric.NumberDataPoint, key string) string {
+		v, ok := dp.Attributes().Get(key)
+		require.True(t, ok, key)
+		return v.AsString()
+	}
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				switch m.Name() {
+				case "tlscheck.time_left":
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						position, _ := dp.Attributes().Get("tlscheck.chain.position")
+						result.timeLeft = append(result.timeLeft, timeLeftPoint{
+							source:   str(dp, "tlscheck.source"),
+							position: position.Int(),
+							subject:  str(dp, "tlscheck.x509.subject"),
+							issuer:   str(dp, "tlscheck.x509.issuer"),
+							san:      str(dp, "tlscheck.x509.san"),
+							daysLeft: dp.DoubleValue(),
+						})
+					}
+				case "tlscheck.chain.valid":
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						result.valid[str(dps.At(l), "tlscheck.source")] = dps.At(l).IntValue()
+					}
+				case "tlscheck.error":
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						result.errors[str(dps.At(l), "tlscheck.source")] = str(dps.At(l), "error.message")
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+func scrape(t *testing.T, cfg *Config) scrapeResult {
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	return collect(t, md)
+}
+
+func TestScraperStart(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, generateCert(t, "Test CA", time.Hour, true, nil).cert)
+	emptyFile := filepath.Join(dir, "empty.pem")
+	require.NoError(t, os.WriteFile(emptyFile, nil, 0600))
+
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr string
+	}{
+		{
+			desc: "valid CA file",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "localhost:443", CAFile: caFile}},
+				Files:   []*fileConfig{{Path: dir, CAFile: caFile}},
+			},
+		},
+		{
+			desc: "missing CA file",
+			cfg: &Config{
+				Targets: []*targetConfig{{Endpoint: "localhost:443", CAFile: filepath.Join(dir, "missing.pem")}},
+			},
+			expectedErr: "failed to load CA file",
+		},
+		{
+			desc: "CA file without certificates",
+			cfg: &Config{
+				Files: []*fileConfig{{Path: dir, CAFile: emptyFile}},
+			},
+			expectedErr: errNoCertificates.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			scraper := newScraper(tc.cfg, receivertest.NewNopCreateSettings())
+			err := scraper.start(context.Background(), componenttest.NewNopHost())
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestScrapeTarget(t *testing.T) {
+	server := newTLSServer()
+	defer server.Close()
+	endpoint := server.Listener.Addr().String()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, server.Certificate())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{Endpoint: endpoint, CAFile: caFile},
+	}
+	result := scrape(t, cfg)
+
+	require.Empty(t, result.errors)
+	require.Len(t, result.timeLeft, 1)
+	point := result.timeLeft[0]
+	require.Equal(t, endpoint, point.source)
+	require.Equal(t, int64(0), point.position)
+	require.Equal(t, server.Certificate().Subject.String(), point.subject)
+	require.Equal(t, server.Certificate().Issuer.String(), point.issuer)
+	expectedSAN := append([]string{}, server.Certificate().DNSNames...)
+	for _, ip := range server.Certificate().IPAddresses {
+		expectedSAN = append(expectedSAN, ip.String())
+	}
+	require.Equal(t, strings.Join(expectedSAN, ","), point.san)
+	require.InDelta(t, time.Until(server.Certificate().NotAfter).Hours()/24, point.daysLeft, 0.01)
+	require.Equal(t, map[string]int64{endpoint: 1}, result.valid)
+}
+
+func TestScrapeTargetServerName(t *testing.T) {
+	server := newTLSServer()
+	defer server.Close()
+	endpoint := server.Listener.Addr().String()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, server.Certificate())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{Endpoint: endpoint, CAFile: caFile, ServerName: "example.com"},
+	}
+	require.Equal(t, map[string]int64{endpoint: 1}, scrape(t, cfg).valid)
+
+	cfg.Targets = []*targetConfig{
+		{Endpoint: endpoint, CAFile: caFile, ServerName: "other.example.org"},
+	}
+	require.Equal(t, map[string]int64{endpoint: 0}, scrape(t, cfg).valid)
+
+	// The test server certificate is not trusted by the system roots.
+	cfg.Targets = []*targetConfig{
+		{Endpoint: endpoint},
+	}
+	result := scrape(t, cfg)
+	require.Len(t, result.timeLeft, 1)
+	require.Equal(t, map[string]int64{endpoint: 0}, result.valid)
+}
+
+func TestScrapeTargetChain(t *testing.T) {
+	root := generateCert(t, "Test Root CA", 365*24*time.Hour, true, nil)
+	intermediate := generateCert(t, "Test Intermediate CA", 30*24*time.Hour, true, root)
+	leaf := generateCert(t, "server.example.com", 10*24*time.Hour, false, intermediate, "server.example.com")
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig(leaf, intermediate))
+	require.NoError(t, err)
+	defer listener.Close()
+	go serveHandshakes(listener)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, root.cert)
+
+	endpoint := listener.Addr().String()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{
+		{Endpoint: endpoint, ServerName: "server.example.com", CAFile: caFile},
+	}
+	result := scrape(t, cfg)
+
+	require.Empty(t, result.errors)
+	require.Len(t, result.timeLeft, 2)
+	for _, point := range result.timeLeft {
+		switch point.position {
+		case 0:
+			require.Equal(t, "CN=server.example.com", point.subject)
+			require.Equal(t, "CN=Test Intermediate CA", point.issuer)
+			require.Equal(t, "server.example.com,127.0.0.1", point.san)
+			require.InDelta(t, 10, point.daysLeft, 0.01)
+		case 1:
+			require.Equal(t, "CN=Test Intermediate CA", point.subject)
+			require.Equal(t, "CN=Test Root CA", point.issuer)
+			require.Equal(t, "", point.san)
+			require.InDelta(t, 30, point.daysLeft, 0.01)
+		default:
+			t.Fatalf("unexpected chain position %d", point.position)
+		}
+	}
+	require.Equal(t, map[string]int64{endpoint: 1}, result.valid)
+}
+
+func TestScrapeTargetError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{{Endpoint: endpoint, Timeout: time.Second}}
+	result := scrape(t, cfg)
+
+	require.Empty(t, result.timeLeft)
+	require.Empty(t, result.valid)
+	require.Contains(t, result.errors[endpoint], "connection refused")
+}
+
+func TestScrapeStartTLS(t *testing.T) {
+	root := generateCert(t, "Test Root CA", 365*24*time.Hour, true, nil)
+	leaf := generateCert(t, "mail.example.com", 24*time.Hour, false, root, "mail.example.com")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, root.cert)
+
+	testCases := []struct {
+		protocol  string
+		negotiate func(conn net.Conn) error
+	}{
+		{
+			protocol: startTLSSMTP,
+			negotiate: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				if _, err := io.WriteString(conn, "220 mail.example.com ESMTP\r\n"); err != nil {
+					return err
+				}
+				if _, err := r.ReadString('\n'); err != nil {
+					return err
+				}
+				if _, err := io.WriteString(conn, "250-mail.example.com\r\n250-PIPELINING\r\n250 STARTTLS\r\n"); err != nil {
+					return err
+				}
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				if line != "STARTTLS\r\n" {
+					_, err = io.WriteString(conn, "500 unexpected command\r\n")
+					return err
+				}
+				_, err = io.WriteString(conn, "220 Ready to start TLS\r\n")
+				return err
+			},
+		},
+		{
+			protocol: startTLSIMAP,
+			negotiate: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				if _, err := io.WriteString(conn, "* OK [CAPABILITY IMAP4rev1 STARTTLS] ready\r\n"); err != nil {
+					return err
+				}
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				tag, _, _ := strings.Cut(line, " ")
+				_, err = io.WriteString(conn, "* CAPABILITY IMAP4rev1\r\n"+tag+" OK Begin TLS negotiation now\r\n")
+				return err
+			},
+		},
+		{
+			protocol: startTLSPostgres,
+			negotiate: func(conn net.Conn) error {
+				request := make([]byte, 8)
+				if _, err := io.ReadFull(conn, request); err != nil {
+					return err
+				}
+				_, err := conn.Write([]byte{'S'})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.protocol, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer listener.Close()
+
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				if tc.negotiate(conn) != nil {
+					return
+				}
+				_ = tls.Server(conn, tlsConfig(leaf)).Handshake()
+			}()
+
+			endpoint := listener.Addr().String()
+			cfg := createDefaultConfig().(*Config)
+			cfg.Targets = []*targetConfig{
+				{Endpoint: endpoint, ServerName: "mail.example.com", StartTLS: tc.protocol, CAFile: caFile, Timeout: 5 * time.Second},
+			}
+			result := scrape(t, cfg)
+
+			require.Empty(t, result.errors)
+			require.Len(t, result.timeLeft, 1)
+			require.Equal(t, "CN=mail.example.com", result.timeLeft[0].subject)
+			require.Equal(t, map[string]int64{endpoint: 1}, result.valid)
+		})
+	}
+}
+
+func TestScrapeStartTLSRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		request := make([]byte, 8)
+		if _, err := io.ReadFull(conn, request); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte{'N'})
+	}()
+
+	endpoint := listener.Addr().String()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{{Endpoint: endpoint, StartTLS: startTLSPostgres, Timeout: 5 * time.Second}}
+	result := scrape(t, cfg)
+
+	require.Empty(t, result.timeLeft)
+	require.Equal(t, map[string]string{endpoint: "postgres STARTTLS failed: server does not support SSL"}, result.errors)
+}
+
+func TestScrapeFiles(t *testing.T) {
+	root := generateCert(t, "Test Root CA", 365*24*time.Hour, true, nil)
+	intermediate := generateCert(t, "Test Intermediate CA", 30*24*time.Hour, true, root)
+	leaf := generateCert(t, "server.example.com", 10*24*time.Hour, false, intermediate, "server.example.com")
+	expired := generateCert(t, "expired.example.com", -24*time.Hour, false, root, "expired.example.com")
+
+	dir := t.TempDir()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, root.cert)
+
+	chainFile := filepath.Join(dir, "chain.pem")
+	writePEM(t, chainFile, leaf.cert, intermediate.cert)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0700))
+	expiredFile := filepath.Join(dir, "nested", "expired.crt")
+	writePEM(t, expiredFile, expired.cert)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0600))
+	// Files without a certificate extension are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.txt"), []byte("ignored"), 0600))
+
+	missing := filepath.Join(dir, "missing.pem")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Files = []*fileConfig{
+		{Path: dir, CAFile: caFile},
+		{Path: missing},
+	}
+	result := scrape(t, cfg)
+
+	require.Equal(t, map[string]int64{chainFile: 1, expiredFile: 0}, result.valid)
+	require.Len(t, result.errors, 2)
+	require.Equal(t, errNoCertificates.Error(), result.errors[invalidFile])
+	require.Contains(t, result.errors[missing], "no such file or directory")
+
+	require.Len(t, result.timeLeft, 3)
+	for _, point := range result.timeLeft {
+		switch {
+		case point.source == chainFile && point.position == 0:
+			require.Equal(t, "CN=server.example.com", point.subject)
+			require.InDelta(t, 10, point.daysLeft, 0.01)
+		case point.source == chainFile && point.position == 1:
+			require.Equal(t, "CN=Test Intermediate CA", point.subject)
+			require.InDelta(t, 30, point.daysLeft, 0.01)
+		case point.source == expiredFile:
+			require.Equal(t, "CN=expired.example.com", point.subject)
+			require.InDelta(t, -1, point.daysLeft, 0.01)
+		default:
+			t.Fatalf("unexpected data point %+v", point)
+		}
+	}
+}
+
+// newTLSServer starts a test server which does not log the handshakes the scraper abandons.
+func newTLSServer() *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	return server
+}
+
+// serveHandshakes completes the TLS handshake of every accepted connection.
+func serveHandshakes(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			_ = conn.(*tls.Conn).Handshake()
+		}()
+	}
+}
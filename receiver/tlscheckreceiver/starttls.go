@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlscheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tlscheckreceiver"
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+)
+
+const (
+	// ehloName is the name the receiver introduces itself with to SMTP servers.
+	ehloName = "localhost"
+	// imapTag tags the STARTTLS command sent to IMAP servers.
+	imapTag = "a001"
+	// postgresSSLRequestCode is the request code of the PostgreSQL SSLRequest message.
+	postgresSSLRequestCode = 80877103
+)
+
+// startTLS negotiates the upgrade of a plain text connection to TLS for the
+// given protocol. On success the next bytes exchanged are the TLS handshake.
+func startTLS(conn net.Conn, protocol string) error {
+	switch protocol {
+	case startTLSSMTP:
+		return startTLSSMTPConn(conn)
+	case startTLSIMAP:
+		return startTLSIMAPConn(conn)
+	case startTLSPostgres:
+		return startTLSPostgresConn(conn)
+	}
+	return errInvalidStartTLS
+}
+
+func startTLSSMTPConn(conn net.Conn) error {
+	tp := textproto.NewConn(conn)
+	if _, _, err := tp.ReadResponse(220); err != nil {
+		return err
+	}
+	if err := tp.PrintfLine("EHLO %s", ehloName); err != nil {
+		return err
+	}
+	if _, _, err := tp.ReadResponse(250); err != nil {
+		return err
+	}
+	if err := tp.PrintfLine("STARTTLS"); err != nil {
+		return err
+	}
+	_, _, err := tp.ReadResponse(220)
+	return err
+}
+
+func startTLSIMAPConn(conn net.Conn) error {
+	tp := textproto.NewConn(conn)
+	greeting, err := tp.ReadLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("unexpected greeting: %s", greeting)
+	}
+	if err = tp.PrintfLine("%s STARTTLS", imapTag); err != nil {
+		return err
+	}
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return err
+		}
+		// Untagged responses may precede the tagged completion result.
+		if !strings.HasPrefix(line, imapTag+" ") {
+			continue
+		}
+		if !strings.HasPrefix(line, imapTag+" OK") {
+			return fmt.Errorf("unexpected response: %s", line)
+		}
+		return nil
+	}
+}
+
+func startTLSPostgresConn(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return err
+	}
+	if response[0] != 'S' {
+		return fmt.Errorf("server does not support SSL")
+	}
+	return nil
+}